@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
@@ -14,16 +15,25 @@ import (
 	_ "github.com/neyrzx/youmusic/docs"
 	"github.com/neyrzx/youmusic/internal/config"
 	"github.com/neyrzx/youmusic/internal/delivery/rest"
+	v1 "github.com/neyrzx/youmusic/internal/delivery/rest/v1"
 	"github.com/neyrzx/youmusic/internal/domain/repositories"
 	"github.com/neyrzx/youmusic/internal/domain/services"
 	"github.com/neyrzx/youmusic/internal/gateways"
+	"github.com/neyrzx/youmusic/pkg/blob"
 	"github.com/neyrzx/youmusic/pkg/httpclient"
 	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/neyrzx/youmusic/pkg/ratelimit"
 	"github.com/neyrzx/youmusic/pkg/validator"
 	"github.com/sethvargo/go-envconfig"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+// lyricsIndexBatch сколько треков без сигнатуры текста индексируется одной транзакцией.
+const lyricsIndexBatch = 500
+
+// searchKeysBatch сколько ключей поиска заполняется одним запросом.
+const searchKeysBatch = 1000
+
 func main() {
 	ctx := context.Background()
 
@@ -44,6 +54,11 @@ func main() {
 	}
 
 	e := echo.New()
+	// IP клиента используется как ключ ограничения частоты запросов анонимных клиентов.
+	e.IPExtractor = echo.ExtractIPDirect()
+	if cfg.Server.TrustForwardedFor {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	}
 
 	valid, err := validator.NewValidator()
 	if err != nil {
@@ -81,10 +96,94 @@ func main() {
 	client := httpclient.NewHTTPClient(cfg.GatewayMusicInfo)
 	tracksRepository := repositories.NewTracksRepository(db)
 	musicInfoGateway := gateways.NewMusicInfoGateway(client, cfg.GatewayMusicInfo)
-	tracksService := services.NewTracksService(tracksRepository, musicInfoGateway)
+	tracksService := services.NewTracksService(tracksRepository, musicInfoGateway, cfg.TracksService)
+	idempotencyRepository := repositories.NewIdempotencyRepository(db)
+
+	audioStore, err := blob.NewLocalFS(cfg.Audio.StorageDir)
+	if err != nil {
+		l.Error().Err(err).Msg("failed to blob.NewLocalFS")
+	}
+	audioService := services.NewAudioService(repositories.NewAudioRepository(db), audioStore)
+
+	coversStore, err := blob.NewLocalFS(cfg.Covers.StorageDir)
+	if err != nil {
+		l.Error().Err(err).Msg("failed to blob.NewLocalFS")
+	}
+	coversService := services.NewCoversService(repositories.NewCoversRepository(db), coversStore, cfg.Covers)
+
+	playlistsRepository := repositories.NewPlaylistsRepository(db)
+	playlistsService := services.NewPlaylistsService(playlistsRepository, tracksService)
+	smartPlaylistsService := services.NewSmartPlaylistsService(repositories.NewSmartPlaylistsRepository(db), tracksService)
+
+	usersRepository := repositories.NewUsersRepository(db)
+	authService, err := services.NewAuthService(usersRepository, cfg.Auth)
+	if err != nil {
+		// Без ключа подписи токенов запускать API нельзя: все изменяющие запросы требуют аутентификации.
+		l.Fatal().Err(err).Msg("failed to services.NewAuthService")
+	}
+
+	apiKeysService := services.NewAPIKeysService(repositories.NewAPIKeysRepository(db), cfg.APIKeys)
+	rateLimitsRepository := repositories.NewRateLimitsRepository(db)
+
+	var quotaStore ratelimit.Store = rateLimitsRepository
+	if cfg.APIKeys.QuotaStore == "memory" {
+		quotaStore = ratelimit.NewMemoryStore()
+	}
+	quota := v1.NewQuota(ratelimit.NewLimiter(quotaStore), cfg.APIKeys.QuotaWindow)
+
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimit.Store == "postgres" {
+		rateLimitStore = rateLimitsRepository
+	}
+	rateLimit := v1.NewRateLimit(ratelimit.NewLimiter(rateLimitStore), cfg.RateLimit)
+
+	scrobblesRepository := repositories.NewScrobblesRepository(db)
+	scrobblesService := services.NewScrobblesService(scrobblesRepository, playlistsRepository, cfg.Scrobbles)
+	ensureScrobblePartitions := func(ctx context.Context) {
+		skipped, err := scrobblesRepository.EnsurePartitions(ctx, time.Now(), cfg.Scrobbles.PartitionsAhead)
+		if err != nil {
+			l.Error().Err(err).Msg("failed to scrobblesRepository.EnsurePartitions")
+		}
+		if len(skipped) > 0 {
+			l.Warn().Strs("partitions", skipped).Msg("scrobbles_default has rows for these months: " +
+				"detach scrobbles_default, create the partitions, move the rows into them and attach scrobbles_default back")
+		}
+	}
+	ensureScrobblePartitions(ctx)
+
+	statsService := services.NewStatsService(repositories.NewStatsRepository(db))
+	similarTracksService := services.NewSimilarTracksService(repositories.NewSimilarityRepository(db))
+	indexLyrics := func(ctx context.Context) {
+		if _, err := similarTracksService.IndexMissing(ctx, lyricsIndexBatch); err != nil {
+			l.Error().Err(err).Msg("failed to similarTracksService.IndexMissing")
+		}
+	}
 
 	// Routes
-	rest.InitAPI(e, tracksService)
+	rest.InitAPI(e, rest.Deps{
+		Tracks:         tracksService,
+		Audio:          audioService,
+		Covers:         coversService,
+		Playlists:      playlistsService,
+		PlaylistFiles:  playlistsService,
+		SmartPlaylists: smartPlaylistsService,
+		Favorites:      services.NewFavoritesService(repositories.NewFavoritesRepository(db)),
+		Scrobbles:      scrobblesService,
+		Stats:          statsService,
+		SimilarTracks:  similarTracksService,
+		Duplicates:     services.NewDuplicatesService(repositories.NewDuplicatesRepository(db)),
+		AuthService:    authService,
+		APIKeys:        apiKeysService,
+
+		Auth:        v1.NewAuth(authService, apiKeysService),
+		Idempotency: v1.NewIdempotency(idempotencyRepository, cfg.Idempotency),
+		Quota:       quota,
+		RateLimit:   rateLimit,
+
+		AudioConfig:     cfg.Audio,
+		CoversConfig:    cfg.Covers,
+		RateLimitConfig: cfg.RateLimit,
+	})
 	e.GET(cfg.SwaggerDocPath, echoSwagger.WrapHandler)
 
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
@@ -96,6 +195,83 @@ func main() {
 		}
 	}()
 
+	// Background jobs
+	// Тексты треков из импорта и созданных до появления сигнатур индексируются при старте и затем периодически.
+	go indexLyrics(ctx)
+
+	// Ключи поиска новых строк пишутся вместе с ними, при старте заполняются ключи строк, созданных до миграции 000016.
+	go func() {
+		if _, err := repositories.NewSearchKeysRepository(db).FillMissing(ctx, searchKeysBatch); err != nil {
+			l.Error().Err(err).Msg("failed to searchKeysRepository.FillMissing")
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(cfg.Idempotency.CleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := idempotencyRepository.DeleteExpired(ctx); err != nil {
+					l.Error().Err(err).Msg("failed to idempotencyRepository.DeleteExpired")
+				}
+				if _, err := usersRepository.DeleteExpiredRefreshTokens(ctx); err != nil {
+					l.Error().Err(err).Msg("failed to usersRepository.DeleteExpiredRefreshTokens")
+				}
+				if _, err := rateLimitsRepository.DeleteExpired(ctx); err != nil {
+					l.Error().Err(err).Msg("failed to rateLimitsRepository.DeleteExpired")
+				}
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(cfg.SimilarTracks.IndexInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				indexLyrics(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(cfg.Scrobbles.PartitionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ensureScrobblePartitions(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(cfg.Stats.RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := statsService.Refresh(ctx); err != nil {
+					l.Error().Err(err).Msg("failed to statsService.Refresh")
+				}
+			}
+		}
+	}()
+
 	<-ctx.Done()
 	ctx, cancelFunc := context.WithTimeout(context.Background(), cfg.Server.GracefulShoutdownTimeout)
 	defer cancelFunc()
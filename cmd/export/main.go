@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories"
+	"github.com/neyrzx/youmusic/internal/exporter"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Выгрузка библиотеки для офлайн бэкапа, аналог GET /api/v1/export.
+//
+//	go run ./cmd/export -format ndjson -out backup.ndjson [-artist Muse] [-track ...] [-releasedyear 2006] [-link ...]
+//
+// CSV и NDJSON выгрузку можно загрузить обратно через cmd/import.
+func main() {
+	var (
+		format       = flag.String("format", string(exporter.FormatNDJSON), "output format: json, ndjson or csv")
+		out          = flag.String("out", "", "output file (stdout if empty)")
+		artist       = flag.String("artist", "", "filter by artist")
+		track        = flag.String("track", "", "filter by track title")
+		releasedYear = flag.String("releasedyear", "", "filter by release year")
+		link         = flag.String("link", "", "filter by link")
+	)
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	l := logger.DefaultLogger().With().Str("app", "export").Logger()
+
+	if config.GetCurrentRunningMode() == config.ModeLocal {
+		if err := godotenv.Load(); err != nil {
+			l.Error().Err(err).Msg("failed to godotenv.Load")
+		}
+	}
+
+	var cfg config.App
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg}); err != nil {
+		l.Fatal().Err(err).Msg("failed to envconfig.ProcessWith")
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			l.Fatal().Err(err).Msg("failed to os.Create")
+		}
+		defer file.Close()
+		w = file
+	}
+
+	buffered := bufio.NewWriter(w)
+
+	encoder, err := exporter.NewEncoder(buffered, exporter.Format(*format))
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to exporter.NewEncoder")
+	}
+
+	db, err := pgxpool.New(ctx, cfg.Database.ConnectionURI())
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to pgxpool.New")
+	}
+	defer db.Close()
+
+	count := 0
+	err = repositories.NewTracksRepository(db).ExportTracks(ctx, entities.TrackGetListFilters{
+		Artist:       *artist,
+		Track:        *track,
+		ReleasedYear: *releasedYear,
+		Link:         *link,
+	}, func(track entities.Track) error {
+		count++
+		return encoder.Encode(track)
+	})
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil {
+		l.Error().Err(err).Int("exported", count).Msg("export failed")
+		db.Close()
+		os.Exit(1)
+	}
+
+	l.Info().Int("exported", count).Msg("export finished")
+}
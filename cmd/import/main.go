@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/repositories"
+	"github.com/neyrzx/youmusic/internal/importer"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Импорт библиотеки треков из CSV или NDJSON.
+//
+//	go run ./cmd/import -file library.csv [-format csv|ndjson] [-dry-run] [-batch-size 500]
+//
+// Прогресс сохраняется в <file>.checkpoint, повторный запуск продолжает с места остановки.
+// Отклонённые записи дописываются в <file>.rejects.ndjson.
+func main() {
+	var (
+		file       = flag.String("file", "", "path to CSV or NDJSON file")
+		format     = flag.String("format", "", "input format: csv or ndjson (by file extension if empty)")
+		dryRun     = flag.Bool("dry-run", false, "validate and load in rolled back transactions")
+		batchSize  = flag.Int("batch-size", 500, "records per transaction")
+		checkpoint = flag.String("checkpoint", "", "checkpoint file (default <file>.checkpoint)")
+		rejects    = flag.String("rejects", "", "reject file (default <file>.rejects.ndjson)")
+	)
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	l := logger.DefaultLogger().With().Str("app", "import").Logger()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if config.GetCurrentRunningMode() == config.ModeLocal {
+		if err := godotenv.Load(); err != nil {
+			l.Error().Err(err).Msg("failed to godotenv.Load")
+		}
+	}
+
+	var cfg config.App
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg}); err != nil {
+		l.Fatal().Err(err).Msg("failed to envconfig.ProcessWith")
+	}
+
+	inputFormat := importer.Format(*format)
+	if inputFormat == "" {
+		var err error
+		if inputFormat, err = importer.FormatFromPath(*file); err != nil {
+			l.Fatal().Err(err).Msg("failed to importer.FormatFromPath")
+		}
+	}
+
+	if *checkpoint == "" {
+		*checkpoint = *file + ".checkpoint"
+	}
+	if *rejects == "" {
+		*rejects = *file + ".rejects.ndjson"
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to os.Open")
+	}
+	defer input.Close()
+
+	reader, err := importer.NewReader(input, inputFormat)
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to importer.NewReader")
+	}
+
+	db, err := pgxpool.New(ctx, cfg.Database.ConnectionURI())
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to pgxpool.New")
+	}
+	defer db.Close()
+
+	im := importer.NewImporter(repositories.NewTracksRepository(db), importer.Options{
+		BatchSize:      *batchSize,
+		DryRun:         *dryRun,
+		CheckpointPath: *checkpoint,
+		RejectPath:     *rejects,
+	})
+
+	stats, err := im.Run(ctx, reader)
+
+	event := l.Info()
+	if err != nil {
+		event = l.Error().Err(err)
+	}
+	event.
+		Bool("dryRun", *dryRun).
+		Int("skipped", stats.Skipped).
+		Int("created", stats.Created).
+		Int("exists", stats.Exists).
+		Int("rejected", stats.Rejected).
+		Msg("import finished")
+
+	if err != nil {
+		// Выход через os.Exit не выполнит defer, закрываем ресурсы явно.
+		db.Close()
+		input.Close()
+		os.Exit(1)
+	}
+}
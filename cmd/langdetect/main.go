@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/repositories"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Определение языка текстов треков, созданных до миграции 000017 или загруженных через cmd/import.
+//
+//	go run ./cmd/langdetect [-batch 500] [-all]
+//
+// По умолчанию обрабатываются только треки без языка, с -all язык пересчитывается у всех треков.
+func main() {
+	var (
+		batch = flag.Int("batch", 500, "tracks per transaction")
+		all   = flag.Bool("all", false, "redetect language of all tracks, not only tracks without language")
+	)
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	l := logger.DefaultLogger().With().Str("app", "langdetect").Logger()
+
+	if *batch <= 0 {
+		l.Fatal().Int("batch", *batch).Msg("batch must be positive")
+	}
+
+	if config.GetCurrentRunningMode() == config.ModeLocal {
+		if err := godotenv.Load(); err != nil {
+			l.Error().Err(err).Msg("failed to godotenv.Load")
+		}
+	}
+
+	var cfg config.App
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg}); err != nil {
+		l.Fatal().Err(err).Msg("failed to envconfig.ProcessWith")
+	}
+
+	db, err := pgxpool.New(ctx, cfg.Database.ConnectionURI())
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to pgxpool.New")
+	}
+	defer db.Close()
+
+	processed, err := services.NewLanguagesService(repositories.NewLanguagesRepository(db)).Backfill(ctx, *batch, *all)
+	if err != nil {
+		l.Error().Err(err).Int("processed", processed).Msg("backfill failed")
+		db.Close()
+		os.Exit(1)
+	}
+
+	l.Info().Int("processed", processed).Msg("backfill finished")
+}
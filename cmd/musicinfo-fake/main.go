@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/gateways/fake"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/sethvargo/go-envconfig"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+func main() {
+	ctx := context.Background()
+
+	l := logger.DefaultLogger().With().Str("app", "musicinfo-fake").Logger()
+
+	if config.GetCurrentRunningMode() == config.ModeLocal {
+		if err := godotenv.Load(); err != nil {
+			l.Error().Err(err).Msg("failed to godotenv.Load")
+		}
+	}
+
+	var cfg config.MusicInfoFake
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg}); err != nil {
+		l.Fatal().Err(err).Msg("failed to envconfig.ProcessWith")
+	}
+
+	server := fake.NewMusicInfoServer(cfg)
+
+	count, err := server.LoadFixtures()
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to server.LoadFixtures")
+	}
+	l.Info().Int("count", count).Str("dir", cfg.FixturesDir).Msg("fixtures loaded")
+
+	e := echo.New()
+	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogURI:     true,
+		LogStatus:  true,
+		LogMethod:  true,
+		LogLatency: true,
+		LogValuesFunc: func(_ echo.Context, v middleware.RequestLoggerValues) error {
+			l.Info().
+				Str("URI", v.URI).
+				Int("status", v.Status).
+				Str("method", v.Method).
+				Dur("latency", v.Latency).
+				Msg("request")
+			return nil
+		},
+	}))
+	e.Use(middleware.Recover())
+
+	server.Register(e)
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	go func() {
+		if err = e.Start(cfg.ServerAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.Error().Err(err).Msg("failed to e.Start")
+		}
+	}()
+
+	<-ctx.Done()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelFunc()
+
+	if err = e.Shutdown(ctx); err != nil {
+		l.Error().Err(err).Msg("failed to e.Shutdown")
+	}
+
+	l.Info().Msg("server successfuly shutdown")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/repositories"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/internal/gateways"
+	"github.com/neyrzx/youmusic/internal/scanner"
+	"github.com/neyrzx/youmusic/pkg/blob"
+	"github.com/neyrzx/youmusic/pkg/httpclient"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Сканирование каталога с MP3/FLAC файлами и синхронизация тегов с библиотекой.
+//
+//	go run ./cmd/scan -dir ~/Music
+//
+// Повторный запуск перечитывает только новые и изменённые (по размеру и mtime) файлы.
+func main() {
+	dir := flag.String("dir", "", "path to music library directory")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	l := logger.DefaultLogger().With().Str("app", "scan").Logger()
+
+	if *dir == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if config.GetCurrentRunningMode() == config.ModeLocal {
+		if err := godotenv.Load(); err != nil {
+			l.Error().Err(err).Msg("failed to godotenv.Load")
+		}
+	}
+
+	var cfg config.App
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg}); err != nil {
+		l.Fatal().Err(err).Msg("failed to envconfig.ProcessWith")
+	}
+
+	db, err := pgxpool.New(ctx, cfg.Database.ConnectionURI())
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to pgxpool.New")
+	}
+	defer db.Close()
+
+	client := httpclient.NewHTTPClient(cfg.GatewayMusicInfo)
+	tracksService := services.NewTracksService(
+		repositories.NewTracksRepository(db),
+		gateways.NewMusicInfoGateway(client, cfg.GatewayMusicInfo),
+		cfg.TracksService,
+	)
+
+	coversStore, err := blob.NewLocalFS(cfg.Covers.StorageDir)
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to blob.NewLocalFS")
+	}
+	coversService := services.NewCoversService(repositories.NewCoversRepository(db), coversStore, cfg.Covers)
+
+	report, err := scanner.NewScanner(tracksService, coversService, repositories.NewLibraryRepository(db)).Scan(ctx, *dir)
+
+	for path, reason := range report.Failed {
+		l.Warn().Str("path", path).Str("reason", reason).Msg("file skipped")
+	}
+	for _, path := range report.Missing {
+		l.Info().Str("path", path).Msg("file missing")
+	}
+
+	event := l.Info()
+	if err != nil {
+		event = l.Error().Err(err)
+	}
+	event.
+		Int("new", len(report.New)).
+		Int("changed", len(report.Changed)).
+		Int("unchanged", report.Unchanged).
+		Int("missing", len(report.Missing)).
+		Int("failed", len(report.Failed)).
+		Msg("scan finished")
+
+	if err != nil {
+		// Выход через os.Exit не выполнит defer, закрываем пул явно.
+		db.Close()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Назначение роли пользователю, например первого админа:
+//
+//	go run ./cmd/users -email admin@example.com -role admin
+//
+// Роль попадает в access токен при следующем входе или обновлении токена.
+func main() {
+	var (
+		email = flag.String("email", "", "user email")
+		role  = flag.String("role", "", "new role: viewer, editor, moderator or admin")
+	)
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	l := logger.DefaultLogger().With().Str("app", "users").Logger()
+
+	if *email == "" || *role == "" {
+		l.Fatal().Msg("-email and -role are required")
+	}
+
+	if config.GetCurrentRunningMode() == config.ModeLocal {
+		if err := godotenv.Load(); err != nil {
+			l.Error().Err(err).Msg("failed to godotenv.Load")
+		}
+	}
+
+	var cfg config.App
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg}); err != nil {
+		l.Fatal().Err(err).Msg("failed to envconfig.ProcessWith")
+	}
+
+	db, err := pgxpool.New(ctx, cfg.Database.ConnectionURI())
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to pgxpool.New")
+	}
+	defer db.Close()
+
+	authService, err := services.NewAuthService(repositories.NewUsersRepository(db), cfg.Auth)
+	if err != nil {
+		l.Fatal().Err(err).Msg("failed to services.NewAuthService")
+	}
+
+	if err = authService.SetRole(ctx, *email, entities.Role(*role)); err != nil {
+		l.Fatal().Err(err).Msg("failed to authService.SetRole")
+	}
+
+	l.Info().Str("email", *email).Str("role", *role).Msg("role updated")
+}
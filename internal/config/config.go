@@ -36,11 +36,24 @@ type App struct {
 	Server           Server
 	GatewayMusicInfo GatewayHTTPClient
 	Database         Database
+	Idempotency      Idempotency
+	TracksService    TracksService
+	Audio            Audio
+	Covers           Covers
+	Auth             Auth
+	APIKeys          APIKeys
+	RateLimit        RateLimit
+	Scrobbles        Scrobbles
+	Stats            Stats
+	SimilarTracks    SimilarTracks
 }
 
 type Server struct {
 	ServerAddr               string        `env:"SERVER_ADDR"`
 	GracefulShoutdownTimeout time.Duration `env:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	// TrustForwardedFor брать IP клиента из X-Forwarded-For. Включать только за своим прокси,
+	// иначе клиент подставит любой IP и обойдёт ограничение частоты запросов.
+	TrustForwardedFor bool `env:"SERVER_TRUST_FORWARDED_FOR, default=false"`
 }
 
 type GatewayHTTPClient struct {
@@ -49,6 +62,51 @@ type GatewayHTTPClient struct {
 	RetryStrategyMaxDuration time.Duration `env:"GATEWAY_RETRY_STRATAGY_MAX_DURATION"`
 	RetryStrategyFactor      float64       `env:"GATEWAY_RETRY_STRATAGY_FACTOR"`
 	BaseURL                  string        `env:"GATEWAY_MUSIC_INFO_BASE_URL"`
+
+	// AttemptTimeout ограничивает одну попытку запроса, общий бюджет задаётся контекстом вызывающего и RequestTimeout.
+	AttemptTimeout time.Duration `env:"GATEWAY_ATTEMPT_TIMEOUT, default=3s"`
+	RequestTimeout time.Duration `env:"GATEWAY_REQUEST_TIMEOUT, default=15s"`
+
+	// HedgingEnabled включает отправку второго (хеджирующего) запроса, если первый не ответил за p95 задержек.
+	// HedgingDelay используется пока не набрано достаточно замеров и как нижняя граница задержки.
+	HedgingEnabled bool          `env:"GATEWAY_HEDGING_ENABLED, default=false"`
+	HedgingDelay   time.Duration `env:"GATEWAY_HEDGING_DELAY, default=500ms"`
+}
+
+// MusicInfoFake конфигурация фейкового сервера music-info для локальной разработки и тестов.
+type MusicInfoFake struct {
+	ServerAddr  string `env:"FAKE_MUSIC_INFO_ADDR, default=localhost:9191"`
+	FixturesDir string `env:"FAKE_MUSIC_INFO_FIXTURES_DIR, default=deployments/fixtures/musicinfo"`
+
+	// Latency задержка перед каждым ответом /info, LatencyJitter добавляет к ней случайную величину [0, LatencyJitter).
+	Latency       time.Duration `env:"FAKE_MUSIC_INFO_LATENCY, default=0s"`
+	LatencyJitter time.Duration `env:"FAKE_MUSIC_INFO_LATENCY_JITTER, default=0s"`
+
+	// Вероятности (от 0 до 1) инъекции ошибок в ответ /info.
+	BadRequestRate    float64 `env:"FAKE_MUSIC_INFO_BAD_REQUEST_RATE, default=0"`
+	InternalErrorRate float64 `env:"FAKE_MUSIC_INFO_INTERNAL_ERROR_RATE, default=0"`
+	TimeoutRate       float64 `env:"FAKE_MUSIC_INFO_TIMEOUT_RATE, default=0"`
+
+	// Timeout сколько "зависает" запрос при инъекции таймаута.
+	Timeout time.Duration `env:"FAKE_MUSIC_INFO_TIMEOUT, default=30s"`
+}
+
+// TracksService настройки сервиса треков.
+type TracksService struct {
+	// BatchMaxItems максимальное количество треков в одном запросе POST /tracks/batch.
+	BatchMaxItems int `env:"TRACKS_BATCH_MAX_ITEMS, default=100"`
+	// BatchConcurrency сколько запросов к music-info выполняется параллельно при пакетном создании.
+	BatchConcurrency int `env:"TRACKS_BATCH_CONCURRENCY, default=8"`
+}
+
+// Idempotency настройки обработки заголовка Idempotency-Key.
+type Idempotency struct {
+	// TTL сколько хранится ответ по ключу.
+	TTL time.Duration `env:"IDEMPOTENCY_TTL, default=24h"`
+	// WaitTimeout сколько параллельный дубликат ждёт завершения исходного запроса.
+	WaitTimeout time.Duration `env:"IDEMPOTENCY_WAIT_TIMEOUT, default=30s"`
+	// CleanupInterval период удаления истёкших ключей.
+	CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL, default=1h"`
 }
 
 // Database представляет собой конфигурацию соединений с базой данных, основанную на переменных окружения.
@@ -131,3 +189,95 @@ func (cfg Database) isValidSSLMode(mode string) bool {
 func (cfg Database) isValidConnectTimeout(value int) bool {
 	return value > 0
 }
+
+// Audio настройки хранения загруженных аудиофайлов.
+type Audio struct {
+	// StorageDir каталог локального blob хранилища.
+	StorageDir string `env:"AUDIO_STORAGE_DIR, default=data/audio"`
+	// MaxSize максимальный размер загружаемого файла в байтах.
+	MaxSize int64 `env:"AUDIO_MAX_SIZE, default=209715200"`
+}
+
+// Covers настройки хранения обложек и генерации превью.
+type Covers struct {
+	// StorageDir каталог локального blob хранилища, там же кэшируются превью.
+	StorageDir string `env:"COVERS_STORAGE_DIR, default=data/covers"`
+	// MaxSize максимальный размер загружаемого изображения в байтах.
+	MaxSize int64 `env:"COVERS_MAX_SIZE, default=10485760"`
+	// ThumbnailSizes допустимые значения ?size= (сторона квадрата, в который вписывается превью).
+	ThumbnailSizes []int `env:"COVERS_THUMBNAIL_SIZES, default=64,128,256,512"`
+	JPEGQuality    int   `env:"COVERS_JPEG_QUALITY, default=85"`
+}
+
+// Auth настройки аутентификации пользователей.
+type Auth struct {
+	// JWTSecret ключ подписи access токенов (HS256), не короче 32 байт.
+	JWTSecret string `env:"AUTH_JWT_SECRET"`
+	// AccessTokenTTL время жизни access токена, RefreshTokenTTL - refresh токена.
+	AccessTokenTTL  time.Duration `env:"AUTH_ACCESS_TOKEN_TTL, default=15m"`
+	RefreshTokenTTL time.Duration `env:"AUTH_REFRESH_TOKEN_TTL, default=720h"`
+	// BcryptCost стоимость хеширования паролей.
+	BcryptCost int `env:"AUTH_BCRYPT_COST, default=12"`
+}
+
+// APIKeys настройки API ключей машинных клиентов.
+type APIKeys struct {
+	// DefaultQuota сколько запросов за QuotaWindow разрешено ключу, если квота не указана при создании.
+	DefaultQuota int           `env:"API_KEYS_DEFAULT_QUOTA, default=1000"`
+	QuotaWindow  time.Duration `env:"API_KEYS_QUOTA_WINDOW, default=1h"`
+	// QuotaStore где считаются запросы: postgres (общий счётчик для всех экземпляров) или memory.
+	QuotaStore string `env:"API_KEYS_QUOTA_STORE, default=postgres"`
+	// MaxPerUser сколько действующих ключей может быть у пользователя.
+	MaxPerUser int `env:"API_KEYS_MAX_PER_USER, default=20"`
+}
+
+// RateLimit ограничение частоты запросов одного клиента (API ключ, пользователь или IP).
+// Лимиты - количество запросов за Window, 0 отключает лимит группы.
+type RateLimit struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED, default=true"`
+	// Store где считаются запросы: memory или postgres (общий счётчик для всех экземпляров).
+	Store  string        `env:"RATE_LIMIT_STORE, default=memory"`
+	Window time.Duration `env:"RATE_LIMIT_WINDOW, default=1m"`
+	// Default общий лимит на все маршруты API.
+	Default int `env:"RATE_LIMIT_DEFAULT, default=600"`
+	// IP лимит на все маршруты API для одного IP, считается до аутентификации и защищает
+	// от перебора токенов и API ключей. Должен быть выше Default: за одним IP бывает несколько клиентов.
+	IP int `env:"RATE_LIMIT_IP, default=1200"`
+	// Auth лимит /auth, защищает от перебора паролей.
+	Auth int `env:"RATE_LIMIT_AUTH, default=20"`
+	// TracksCreate лимит создаваемых треков POST /tracks/ и /tracks/batch, каждый трек ходит в music-info.
+	// Трек пакета считается отдельно, пакет больше лимита отклоняется.
+	TracksCreate int `env:"RATE_LIMIT_TRACKS_CREATE, default=30"`
+	// Export лимит /export, выгрузка читает весь каталог.
+	Export int `env:"RATE_LIMIT_EXPORT, default=10"`
+}
+
+// Scrobbles правила учёта прослушиваний.
+type Scrobbles struct {
+	// DedupWindow повтор того же трека тем же пользователем ближе этого интервала считается дубликатом.
+	DedupWindow time.Duration `env:"SCROBBLES_DEDUP_WINDOW, default=30s"`
+	// Прослушивание засчитывается, если трек длиннее MinTrackLength и прослушан на PlayFraction длины
+	// или на PlayThreshold. Для треков без известной длины достаточно MinTrackLength.
+	MinTrackLength time.Duration `env:"SCROBBLES_MIN_TRACK_LENGTH, default=30s"`
+	PlayFraction   float64       `env:"SCROBBLES_PLAY_FRACTION, default=0.5"`
+	PlayThreshold  time.Duration `env:"SCROBBLES_PLAY_THRESHOLD, default=4m"`
+	// MaxAge насколько старые прослушивания принимаются.
+	MaxAge        time.Duration `env:"SCROBBLES_MAX_AGE, default=336h"`
+	BatchMaxItems int           `env:"SCROBBLES_BATCH_MAX_ITEMS, default=50"`
+	// PartitionsAhead на сколько месяцев вперёд создаются секции таблицы прослушиваний.
+	PartitionsAhead int `env:"SCROBBLES_PARTITIONS_AHEAD, default=3"`
+	// PartitionInterval период создания секций и проверки строк в DEFAULT секции.
+	PartitionInterval time.Duration `env:"SCROBBLES_PARTITION_INTERVAL, default=24h"`
+}
+
+// Stats настройки витрин статистики.
+type Stats struct {
+	// RefreshInterval период обновления материализованных представлений /stats.
+	RefreshInterval time.Duration `env:"STATS_REFRESH_INTERVAL, default=15m"`
+}
+
+// SimilarTracks настройки индекса похожих по тексту треков.
+type SimilarTracks struct {
+	// IndexInterval период индексации текстов треков без сигнатуры.
+	IndexInterval time.Duration `env:"SIMILAR_TRACKS_INDEX_INTERVAL, default=1h"`
+}
@@ -2,9 +2,36 @@ package rest
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
 	v1 "github.com/neyrzx/youmusic/internal/delivery/rest/v1"
 )
 
+// Deps сервисы и middleware, из которых собираются маршруты API.
+type Deps struct {
+	Tracks         v1.TracksService
+	Audio          v1.AudioService
+	Covers         v1.CoversService
+	Playlists      v1.PlaylistsService
+	PlaylistFiles  v1.PlaylistFilesService
+	SmartPlaylists v1.SmartPlaylistsService
+	Favorites      v1.FavoritesService
+	Scrobbles      v1.ScrobblesService
+	Stats          v1.StatsService
+	SimilarTracks  v1.SimilarTracksService
+	Duplicates     v1.DuplicatesService
+	AuthService    v1.AuthService
+	APIKeys        v1.APIKeysService
+
+	Auth        *v1.Auth
+	Idempotency *v1.Idempotency
+	Quota       *v1.Quota
+	RateLimit   *v1.RateLimit
+
+	AudioConfig     config.Audio
+	CoversConfig    config.Covers
+	RateLimitConfig config.RateLimit
+}
+
 // @title YouMusic
 // @version 0.0.1
 // @description Это проект был разработан в рамках тестового задания от EffectiveMobile
@@ -18,9 +45,56 @@ import (
 
 // @host localhost:9090
 // @BasePath /api/v1
-func InitAPI(e *echo.Echo, ts v1.TracksService) {
-	api := e.Group("api/v1")
 
-	tracksGroup := api.Group("/tracks")
-	v1.NewTracksHandlers(tracksGroup, ts)
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token из /auth/login в виде "Bearer <token>" или API ключ в виде "ApiKey <key>".
+func InitAPI(e *echo.Echo, deps Deps) {
+	// Лимит по IP считается до аутентификации: запросы с неверными токенами и ключами тоже ограничены
+	// и не доходят до базы бесконечно.
+	api := e.Group("api/v1", deps.RateLimit.LimitIP("ip", deps.RateLimitConfig.IP))
+
+	// limit общий для клиента лимит на все группы, строгие лимиты отдельных маршрутов добавляются к нему.
+	limit := deps.RateLimit.Limit("default", deps.RateLimitConfig.Default)
+	// createLimit общий лимит на всё, что создаёт треки через music-info.
+	createLimit := deps.RateLimit.Limit("tracks-create", deps.RateLimitConfig.TracksCreate)
+	// batchLimit тот же лимит, но пакет расходует его по одному на каждый трек.
+	batchLimit := deps.RateLimit.LimitCost("tracks-create", deps.RateLimitConfig.TracksCreate, v1.TracksBatchCost)
+
+	authGroup := api.Group("/auth", deps.RateLimit.Limit("auth", deps.RateLimitConfig.Auth))
+	v1.NewAuthHandlers(authGroup, deps.AuthService)
+
+	apiKeysGroup := api.Group("/api-keys", deps.Auth.Middleware, limit)
+	v1.NewAPIKeysHandlers(apiKeysGroup, deps.APIKeys)
+
+	tracksGroup := api.Group("/tracks", deps.Auth.Middleware, limit, deps.Quota.Middleware, deps.Idempotency.Middleware)
+	v1.NewTracksHandlers(tracksGroup, deps.Tracks, createLimit, batchLimit)
+	v1.NewAudioHandlers(tracksGroup, deps.Audio, deps.AudioConfig)
+	v1.NewSimilarTracksHandlers(tracksGroup, deps.SimilarTracks)
+
+	albumsGroup := api.Group("/albums", deps.Auth.Middleware, limit, deps.Quota.Middleware, deps.Idempotency.Middleware)
+	v1.NewCoversHandlers(tracksGroup, albumsGroup, deps.Covers, deps.CoversConfig)
+
+	playlistsGroup := api.Group("/playlists", deps.Auth.Middleware, limit, deps.Quota.Middleware, deps.Idempotency.Middleware)
+	v1.NewPlaylistsHandlers(playlistsGroup, deps.Playlists)
+
+	smartPlaylistsGroup := api.Group("/smart-playlists", deps.Auth.Middleware, limit, deps.Quota.Middleware, deps.Idempotency.Middleware)
+	v1.NewSmartPlaylistsHandlers(smartPlaylistsGroup, deps.SmartPlaylists)
+	v1.NewPlaylistFilesHandlers(playlistsGroup, smartPlaylistsGroup, deps.PlaylistFiles, deps.SmartPlaylists, createLimit)
+
+	meGroup := api.Group("/me", deps.Auth.Middleware, limit, deps.Quota.Middleware)
+	v1.NewFavoritesHandlers(meGroup, deps.Favorites)
+
+	scrobblesGroup := api.Group("/scrobbles", deps.Auth.Middleware, limit, deps.Quota.Middleware, deps.Idempotency.Middleware)
+	v1.NewScrobblesHandlers(scrobblesGroup, meGroup, deps.Scrobbles)
+
+	statsGroup := api.Group("/stats", deps.Auth.Middleware, limit, deps.Quota.Middleware)
+	v1.NewStatsHandlers(statsGroup, deps.Stats)
+
+	adminGroup := api.Group("/admin", deps.Auth.Middleware, limit, deps.Quota.Middleware, deps.Idempotency.Middleware)
+	v1.NewDuplicatesHandlers(adminGroup, deps.Duplicates)
+
+	exportGroup := api.Group("/export", deps.Auth.Middleware, limit, deps.RateLimit.Limit("export", deps.RateLimitConfig.Export), deps.Quota.Middleware)
+	v1.NewExportHandlers(exportGroup, deps.Tracks)
 }
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type APIKeysService interface {
+	Create(ctx context.Context, user entities.User, create entities.APIKeyCreate) (entities.APIKeyCreated, error)
+	List(ctx context.Context, userID int) ([]entities.APIKey, error)
+	Revoke(ctx context.Context, userID int, keyID int) error
+}
+
+type APIKeysHandlers struct {
+	apiKeysService APIKeysService
+	logger         *zerolog.Logger
+}
+
+// NewAPIKeysHandlers регистрирует маршруты управления ключами. Все они доступны только по access токену.
+func NewAPIKeysHandlers(g *echo.Group, aks APIKeysService) *APIKeysHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "api_keys").Logger()
+
+	h := &APIKeysHandlers{
+		apiKeysService: aks,
+		logger:         &logger,
+	}
+
+	g.POST("/", h.Create, RequireAccessToken)
+	g.GET("/", h.List, RequireAccessToken)
+	g.DELETE("/:id/", h.Revoke, RequireAccessToken)
+
+	return h
+}
+
+type APIKeyCreateRequest struct {
+	Name   string   `json:"name" validate:"required,max=255" example:"ingestion bot"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required,max=32" example:"tracks:create,tracks:update"`
+	// Quota запросов за окно квоты, 0 - квота по умолчанию.
+	Quota     int        `json:"quota" validate:"gte=0,lte=1000000" example:"1000"`
+	ExpiresAt *time.Time `json:"expiresAt" example:"2030-01-01T00:00:00Z"`
+}
+
+type APIKeyPathParam struct {
+	ID int `json:"-" param:"id" validate:"required,gt=0"`
+}
+
+type APIKeyResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix" example:"ym_AbCdEfGh"`
+	Scopes     []string   `json:"scopes"`
+	Quota      int        `json:"quota"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type APIKeyCreateResponse struct {
+	APIKeyResponse
+	// Key показывается только один раз, сохранить его потом нельзя.
+	Key string `json:"key"`
+}
+
+// Create godoc
+// @Summary      Create API key
+// @Description  Issuing API key for machine clients. Scopes must be permissions of the current user role. The key is returned only once, only its hash is stored. Requires access token, API keys can not manage keys.
+// @Tags         API keys
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.APIKeyCreateRequest true "Key name, scopes, quota and expiry."
+// @Success      201  {object}  v1.APIKeyCreateResponse "Created key"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Authenticated with API key"
+// @Failure      409  {object}  v1.HTTPError "Too many active keys"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /api-keys/ [post]
+func (h *APIKeysHandlers) Create(c echo.Context) (err error) {
+	var request APIKeyCreateRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	user, _ := entities.UserFromContext(c.Request().Context())
+
+	scopes := make([]entities.Permission, 0, len(request.Scopes))
+	for _, scope := range request.Scopes {
+		scopes = append(scopes, entities.Permission(scope))
+	}
+
+	created, err := h.apiKeysService.Create(c.Request().Context(), user, entities.APIKeyCreate{
+		Name:      request.Name,
+		Scopes:    scopes,
+		Quota:     request.Quota,
+		ExpiresAt: request.ExpiresAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyScopesInvalid), errors.Is(err, domain.ErrAPIKeyExpiryInvalid):
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+		case errors.Is(err, domain.ErrAPIKeyLimitReached):
+			return c.JSON(http.StatusConflict, HTTPError{Message: domain.ErrAPIKeyLimitReached.Error()})
+		}
+		h.logger.Err(err).Msg("failed to apiKeysService.Create")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	return c.JSON(http.StatusCreated, APIKeyCreateResponse{
+		APIKeyResponse: apiKeyResponse(created.APIKey),
+		Key:            created.Key,
+	})
+}
+
+// List godoc
+// @Summary      List API keys
+// @Description  Listing API keys of the current user including revoked ones, newest first. Requires access token.
+// @Tags         API keys
+// @Accept       json
+// @Produce			 json
+// @Success      200  {array}   v1.APIKeyResponse "Keys"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Authenticated with API key"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /api-keys/ [get]
+func (h *APIKeysHandlers) List(c echo.Context) (err error) {
+	user, _ := entities.UserFromContext(c.Request().Context())
+
+	keys, err := h.apiKeysService.List(c.Request().Context(), user.ID)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to apiKeysService.List")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, apiKeyResponse(key))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Revoke godoc
+// @Summary      Revoke API key
+// @Description  Revoking API key of the current user. Requests with the key are rejected immediately. Requires access token.
+// @Tags         API keys
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "key id"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Authenticated with API key"
+// @Failure      404  {object}  v1.HTTPError "Key not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /api-keys/{id}/ [delete]
+func (h *APIKeysHandlers) Revoke(c echo.Context) (err error) {
+	var request APIKeyPathParam
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	user, _ := entities.UserFromContext(c.Request().Context())
+
+	if err = h.apiKeysService.Revoke(c.Request().Context(), user.ID, request.ID); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrAPIKeyNotFound.Error()})
+		}
+		h.logger.Err(err).Msg("failed to apiKeysService.Revoke")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+func apiKeyResponse(key entities.APIKey) APIKeyResponse {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		Quota:      key.Quota,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
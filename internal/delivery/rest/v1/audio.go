@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/blob"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// audioContentTypes допустимые Content-Type загружаемых файлов.
+var audioContentTypes = map[string]struct{}{
+	"audio/mpeg":   {},
+	"audio/mp3":    {},
+	"audio/flac":   {},
+	"audio/x-flac": {},
+}
+
+type AudioService interface {
+	Upload(ctx context.Context, upload entities.TrackAudioUpload) (entities.TrackAudio, error)
+	Open(ctx context.Context, trackID int) (entities.TrackAudio, blob.File, error)
+}
+
+type AudioHandlers struct {
+	audioService AudioService
+	cfg          config.Audio
+	logger       *zerolog.Logger
+}
+
+func NewAudioHandlers(g *echo.Group, as AudioService, cfg config.Audio) *AudioHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "audio").Logger()
+
+	h := &AudioHandlers{
+		audioService: as,
+		cfg:          cfg,
+		logger:       &logger,
+	}
+
+	g.PUT("/:id/audio", h.Upload, RequirePermission(entities.PermissionTracksUpdate))
+	g.GET("/:id/audio", h.Download)
+	g.HEAD("/:id/audio", h.Download)
+
+	return h
+}
+
+type AudioPathParam struct {
+	ID int `param:"id" validate:"required,gt=0"`
+}
+
+type AudioUploadResponse struct {
+	TrackID     int    `json:"trackID"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	ETag        string `json:"etag"`
+	DurationMs  int64  `json:"durationMs"`
+}
+
+// Upload godoc
+// @Summary      Upload track audio
+// @Description  Uploads MP3 or FLAC file for the track, replaces the previous one. Duration is read from the file headers. Requires role: editor, moderator, admin.
+// @Tags         Audio
+// @Accept       audio/mpeg
+// @Accept       audio/flac
+// @Produce			 json
+// @Param				 id path int true "track id"
+// @Param				 body body string true "audio file"
+// @Success      200  {object}  v1.AudioUploadResponse "Uploaded audio"
+// @Failure      400  {object}  v1.HTTPError "Bad request or not a valid MP3/FLAC file"
+// @Failure      404  {object}  v1.HTTPError "Track not found"
+// @Failure      411  {object}  v1.HTTPError "Content-Length required"
+// @Failure      413  {object}  v1.HTTPError "File is too large"
+// @Failure      415  {object}  v1.HTTPError "Unsupported Content-Type"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /tracks/{id}/audio [put]
+func (h *AudioHandlers) Upload(c echo.Context) (err error) {
+	var pathParam AudioPathParam
+
+	// Bind не подходит: тело запроса - аудиофайл, а не JSON.
+	if err = (&echo.DefaultBinder{}).BindPathParams(c, &pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	contentType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if _, ok := audioContentTypes[contentType]; err != nil || !ok {
+		return c.JSON(http.StatusUnsupportedMediaType, HTTPError{Message: "Content-Type must be audio/mpeg or audio/flac"})
+	}
+
+	switch size := c.Request().ContentLength; {
+	case size < 0:
+		return c.JSON(http.StatusLengthRequired, HTTPError{Message: "Content-Length is required"})
+	case size == 0:
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body is empty"})
+	case size > h.cfg.MaxSize:
+		return c.JSON(http.StatusRequestEntityTooLarge, HTTPError{Message: fmt.Sprintf("file must not exceed %d bytes", h.cfg.MaxSize)})
+	}
+
+	audio, err := h.audioService.Upload(c.Request().Context(), entities.TrackAudioUpload{
+		TrackID:     pathParam.ID,
+		ContentType: contentType,
+		Body:        http.MaxBytesReader(c.Response(), c.Request().Body, h.cfg.MaxSize),
+	})
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(err, domain.ErrTrackNotFound):
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrTrackNotFound.Error()})
+		case errors.Is(err, domain.ErrTrackAudioInvalid):
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: domain.ErrTrackAudioInvalid.Error()})
+		case errors.As(err, &maxBytesErr):
+			return c.JSON(http.StatusRequestEntityTooLarge, HTTPError{Message: fmt.Sprintf("file must not exceed %d bytes", h.cfg.MaxSize)})
+		}
+		h.logger.Err(err).Int("trackID", pathParam.ID).Msg("failed to audioService.Upload")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	c.Response().Header().Set("ETag", strconv.Quote(audio.ETag))
+
+	return c.JSON(http.StatusOK, AudioUploadResponse{
+		TrackID:     audio.TrackID,
+		ContentType: audio.ContentType,
+		Size:        audio.Size,
+		ETag:        audio.ETag,
+		DurationMs:  audio.Duration.Milliseconds(),
+	})
+}
+
+// Download godoc
+// @Summary      Download track audio
+// @Description  Streams track audio. Supports Range, If-Range, If-None-Match and returns 206 Partial Content for ranges.
+// @Tags         Audio
+// @Produce			 audio/mpeg
+// @Produce			 audio/flac
+// @Param				 id path int true "track id"
+// @Param				 Range header string false "Byte range, e.g. bytes=0-1023"
+// @Param				 If-Range header string false "ETag or Last-Modified the range is valid for"
+// @Success      200  {file}  file "Whole file"
+// @Success      206  {file}  file "Partial content"
+// @Success      304  "Not modified"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Audio not found"
+// @Failure      416  "Range not satisfiable"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /tracks/{id}/audio [get]
+func (h *AudioHandlers) Download(c echo.Context) (err error) {
+	var pathParam AudioPathParam
+
+	// Bind не подходит: тело запроса - аудиофайл, а не JSON.
+	if err = (&echo.DefaultBinder{}).BindPathParams(c, &pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	audio, file, err := h.audioService.Open(c.Request().Context(), pathParam.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTrackAudioNotFound) {
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrTrackAudioNotFound.Error()})
+		}
+		h.logger.Err(err).Int("trackID", pathParam.ID).Msg("failed to audioService.Open")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+	defer file.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, audio.ContentType)
+	header.Set("ETag", strconv.Quote(audio.ETag))
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("Cache-Control", "no-cache")
+
+	// ServeContent сам обрабатывает Range, If-Range, If-None-Match и отвечает 206/304/416.
+	http.ServeContent(c.Response(), c.Request(), "", audio.UpdatedAt, file)
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type AuthService interface {
+	Register(ctx context.Context, register entities.UserRegister) (int, error)
+	Login(ctx context.Context, login entities.UserLogin) (entities.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (entities.AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+type AuthHandlers struct {
+	authService AuthService
+	logger      *zerolog.Logger
+}
+
+func NewAuthHandlers(g *echo.Group, as AuthService) *AuthHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "auth").Logger()
+
+	h := &AuthHandlers{
+		authService: as,
+		logger:      &logger,
+	}
+
+	g.POST("/register", h.Register)
+	g.POST("/login", h.Login)
+	g.POST("/refresh", h.Refresh)
+	g.POST("/logout", h.Logout)
+
+	return h
+}
+
+type AuthRegisterRequest struct {
+	Email string `json:"email" validate:"required,email,max=255" example:"user@example.com"`
+	// Password bcrypt учитывает только первые 72 байта.
+	Password string `json:"password" validate:"required,min=8,max=72" example:"correct horse battery"`
+}
+
+type AuthRegisterResponse struct {
+	UserID int `json:"userID"`
+}
+
+type AuthLoginRequest struct {
+	Email    string `json:"email" validate:"required,max=255" example:"user@example.com"`
+	Password string `json:"password" validate:"required,max=72" example:"correct horse battery"`
+}
+
+type AuthRefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required,max=128"`
+}
+
+type AuthTokensResponse struct {
+	AccessToken string `json:"accessToken"`
+	TokenType   string `json:"tokenType" example:"Bearer"`
+	// ExpiresIn время жизни access токена в секундах.
+	ExpiresIn        int64     `json:"expiresIn"`
+	RefreshToken     string    `json:"refreshToken"`
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
+}
+
+// Register godoc
+// @Summary      Register user
+// @Description  Creating user account
+// @Tags         Auth
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.AuthRegisterRequest true "Email and password."
+// @Success      201  {object}  v1.AuthRegisterResponse "Created user"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      409  {object}  v1.HTTPError "User already exists"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /auth/register [post]
+func (h *AuthHandlers) Register(c echo.Context) (err error) {
+	var request AuthRegisterRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	id, err := h.authService.Register(c.Request().Context(), entities.UserRegister{
+		Email:    request.Email,
+		Password: request.Password,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			return c.JSON(http.StatusConflict, HTTPError{Message: domain.ErrUserAlreadyExists.Error()})
+		}
+		h.logger.Err(err).Msg("failed to authService.Register")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	return c.JSON(http.StatusCreated, AuthRegisterResponse{UserID: id})
+}
+
+// Login godoc
+// @Summary      Login
+// @Description  Issuing short-lived access token and refresh token
+// @Tags         Auth
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.AuthLoginRequest true "Email and password."
+// @Success      200  {object}  v1.AuthTokensResponse "Tokens"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Invalid email or password"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /auth/login [post]
+func (h *AuthHandlers) Login(c echo.Context) (err error) {
+	var request AuthLoginRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	tokens, err := h.authService.Login(c.Request().Context(), entities.UserLogin{
+		Email:    request.Email,
+		Password: request.Password,
+	})
+	if err != nil {
+		return h.authError(c, err, "failed to authService.Login")
+	}
+
+	return c.JSON(http.StatusOK, authTokensResponse(tokens))
+}
+
+// Refresh godoc
+// @Summary      Refresh tokens
+// @Description  Exchanging refresh token for a new token pair. The refresh token can be used only once, reusing it revokes the whole session.
+// @Tags         Auth
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.AuthRefreshRequest true "Refresh token."
+// @Success      200  {object}  v1.AuthTokensResponse "Tokens"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Refresh token is invalid or expired"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /auth/refresh [post]
+func (h *AuthHandlers) Refresh(c echo.Context) (err error) {
+	var request AuthRefreshRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	tokens, err := h.authService.Refresh(c.Request().Context(), request.RefreshToken)
+	if err != nil {
+		return h.authError(c, err, "failed to authService.Refresh")
+	}
+
+	return c.JSON(http.StatusOK, authTokensResponse(tokens))
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Revoking refresh tokens of the session. Issued access tokens stay valid until they expire.
+// @Tags         Auth
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.AuthRefreshRequest true "Refresh token."
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Refresh token is invalid"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /auth/logout [post]
+func (h *AuthHandlers) Logout(c echo.Context) (err error) {
+	var request AuthRefreshRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.authService.Logout(c.Request().Context(), request.RefreshToken); err != nil {
+		return h.authError(c, err, "failed to authService.Logout")
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+func (h *AuthHandlers) authError(c echo.Context, err error, msg string) error {
+	for _, unauthorized := range []error{domain.ErrInvalidCredentials, domain.ErrInvalidRefreshToken} {
+		if errors.Is(err, unauthorized) {
+			return c.JSON(http.StatusUnauthorized, HTTPError{Message: unauthorized.Error()})
+		}
+	}
+
+	h.logger.Err(err).Msg(msg)
+	return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+}
+
+func authTokensResponse(tokens entities.AuthTokens) AuthTokensResponse {
+	return AuthTokensResponse{
+		AccessToken:      tokens.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(time.Until(tokens.AccessExpiresAt).Round(time.Second).Seconds()),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+	}
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (entities.User, error)
+}
+
+const (
+	authSchemeBearer = "Bearer"
+	authSchemeAPIKey = "ApiKey"
+)
+
+// Auth middleware аутентификации по заголовку "Authorization: Bearer <access token>"
+// или "Authorization: ApiKey <key>" для машинных клиентов.
+//
+// Пользователь кладётся в контекст запроса (entities.UserFromContext). Чтение (GET, HEAD, OPTIONS)
+// доступно и без токена, изменение - только аутентифицированным. Невалидный токен или ключ - всегда 401.
+type Auth struct {
+	tokens Authenticator
+	keys   Authenticator
+	logger *zerolog.Logger
+}
+
+func NewAuth(tokens Authenticator, keys Authenticator) *Auth {
+	logger := logger.DefaultLogger().With().Str(packageKey, "auth").Logger()
+
+	return &Auth{tokens: tokens, keys: keys, logger: &logger}
+}
+
+func (m *Auth) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		header := c.Request().Header.Get(echo.HeaderAuthorization)
+		if header == "" {
+			if isSafeMethod(c.Request().Method) {
+				return next(c)
+			}
+			return unauthorized(c, "authentication required")
+		}
+
+		scheme, credential, _ := strings.Cut(header, " ")
+		credential = strings.TrimSpace(credential)
+
+		var (
+			authenticator Authenticator
+			invalid       error
+		)
+		switch {
+		case strings.EqualFold(scheme, authSchemeBearer):
+			authenticator, invalid = m.tokens, domain.ErrInvalidAccessToken
+		case strings.EqualFold(scheme, authSchemeAPIKey):
+			authenticator, invalid = m.keys, domain.ErrInvalidAPIKey
+		}
+		if authenticator == nil || credential == "" {
+			return unauthorized(c, "authorization header must be 'Bearer <token>' or 'ApiKey <key>'")
+		}
+
+		user, err := authenticator.Authenticate(c.Request().Context(), credential)
+		if err != nil {
+			if !errors.Is(err, invalid) {
+				m.logger.Err(err).Msg("failed to authenticator.Authenticate")
+			}
+			return unauthorized(c, invalid.Error())
+		}
+
+		c.SetRequest(c.Request().WithContext(entities.ContextWithUser(c.Request().Context(), user)))
+
+		return next(c)
+	}
+}
+
+func unauthorized(c echo.Context, message string) error {
+	c.Response().Header().Add(echo.HeaderWWWAuthenticate, `Bearer realm="youmusic"`)
+	c.Response().Header().Add(echo.HeaderWWWAuthenticate, `ApiKey realm="youmusic"`)
+	return c.JSON(http.StatusUnauthorized, HTTPError{Message: message})
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// RequirePermission пропускает только пользователей, роли которых есть право permission.
+// Для запросов по API ключу право должно быть ещё и в scopes ключа.
+// Ставится на маршрут после Auth.Middleware: анонимный запрос - 401, не хватает прав - 403.
+func RequirePermission(permission entities.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := entities.UserFromContext(c.Request().Context())
+			if !ok {
+				return unauthorized(c, "authentication required")
+			}
+
+			if !user.Role.Can(permission) {
+				return c.JSON(http.StatusForbidden, HTTPError{
+					Message: fmt.Sprintf("role %q has no %q permission", user.Role, permission),
+				})
+			}
+			if !user.Can(permission) {
+				return c.JSON(http.StatusForbidden, HTTPError{
+					Message: fmt.Sprintf("api key has no %q scope", permission),
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireUser пропускает только аутентифицированные запросы, в том числе на чтение.
+func RequireUser(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, ok := entities.UserFromContext(c.Request().Context()); !ok {
+			return unauthorized(c, "authentication required")
+		}
+
+		return next(c)
+	}
+}
+
+// RequireAccessToken пропускает только запросы с access токеном пользователя. Управлять ключами
+// по API ключу нельзя, иначе утёкший ключ позволил бы выпустить себе замену.
+func RequireAccessToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		user, ok := entities.UserFromContext(c.Request().Context())
+		if !ok {
+			return unauthorized(c, "authentication required")
+		}
+		if user.APIKey != nil {
+			return c.JSON(http.StatusForbidden, HTTPError{Message: "api keys can not be managed with an api key"})
+		}
+
+		return next(c)
+	}
+}
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+)
+
+type TracksBatchCreateRequest struct {
+	Items []TracksCreateRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type TracksBatchItemResponse struct {
+	TrackID int    `json:"trackID,omitempty" example:"1"`
+	Group   string `json:"group" example:"Muse"`
+	Song    string `json:"song" example:"Song name"`
+	Status  string `json:"status" enums:"created,exists,failed" example:"created"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type TracksBatchCreateResponse struct {
+	Items []TracksBatchItemResponse `json:"items"`
+}
+
+// TracksBatchCost стоимость POST /tracks/batch для лимита создания треков - количество элементов.
+// Тело возвращается в запрос для обработчика. Некорректное тело стоит как один трек, его отклонит обработчик.
+func TracksBatchCost(c echo.Context) int {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return 1
+	}
+	c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+	var request struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err = json.Unmarshal(body, &request); err != nil {
+		return 1
+	}
+
+	return len(request.Items)
+}
+
+// CreateBatch godoc
+// @Summary      Create tracks batch
+// @Description  Creating several tracks at once, result is returned for every item. Every item counts against the tracks create rate limit, a batch larger than the whole limit is rejected. Requires role: editor, moderator, admin.
+// @Tags         Tracks
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.TracksBatchCreateRequest true "List of songs and groups."
+// @Success      200  {object}  v1.TracksBatchCreateResponse "Per item results"
+// @Failure      400  {object}  v1.HTTPError "Bad request or batch exceeds rate limit"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      429  {object}  v1.HTTPError "Too many requests, see Retry-After"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /tracks/batch [post]
+func (h *TracksHandlers) CreateBatch(c echo.Context) (err error) {
+	var request TracksBatchCreateRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	tracks := make([]entities.TrackCreate, len(request.Items))
+	for i, item := range request.Items {
+		tracks[i] = entities.TrackCreate{Title: item.Song, Artist: item.Group}
+	}
+
+	var results []entities.TrackBatchResult
+	if results, err = h.trackService.CreateBatch(c.Request().Context(), tracks); err != nil {
+		h.logger.Err(err).Msg("failed to trackService.CreateBatch")
+		if errors.Is(err, domain.ErrTrackBatchTooLarge) {
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong, try again later"})
+	}
+
+	res := TracksBatchCreateResponse{Items: make([]TracksBatchItemResponse, len(results))}
+	for i, result := range results {
+		res.Items[i] = TracksBatchItemResponse{
+			TrackID: result.TrackID,
+			Group:   result.Artist,
+			Song:    result.Title,
+			Status:  string(result.Status),
+			Reason:  result.Reason,
+		}
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
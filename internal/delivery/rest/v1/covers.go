@@ -0,0 +1,305 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/blob"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type CoversService interface {
+	Upload(ctx context.Context, upload entities.CoverUpload) (entities.CoverImage, error)
+	Open(ctx context.Context, request entities.CoverRequest) (entities.CoverImage, blob.File, error)
+}
+
+type CoversHandlers struct {
+	coversService CoversService
+	cfg           config.Covers
+	logger        *zerolog.Logger
+}
+
+// NewCoversHandlers регистрирует маршруты /:id/cover в группах треков и альбомов.
+func NewCoversHandlers(tracks *echo.Group, albums *echo.Group, cs CoversService, cfg config.Covers) *CoversHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "covers").Logger()
+
+	h := &CoversHandlers{
+		coversService: cs,
+		cfg:           cfg,
+		logger:        &logger,
+	}
+
+	tracks.PUT("/:id/cover", h.UploadTrackCover, RequirePermission(entities.PermissionTracksUpdate))
+	tracks.GET("/:id/cover", h.TrackCover)
+	albums.PUT("/:id/cover", h.UploadAlbumCover, RequirePermission(entities.PermissionTracksUpdate))
+	albums.GET("/:id/cover", h.AlbumCover)
+
+	return h
+}
+
+type CoverPathParam struct {
+	ID int `param:"id" validate:"required,gt=0"`
+}
+
+type CoverQuery struct {
+	Size int `query:"size" validate:"gte=0"`
+}
+
+type CoverUploadResponse struct {
+	ContentType string `json:"contentType"`
+	ETag        string `json:"etag"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// UploadTrackCover godoc
+// @Summary      Upload track cover
+// @Description  Uploads JPEG or PNG cover for the track, replaces the previous one. Requires role: editor, moderator, admin.
+// @Tags         Covers
+// @Accept       image/jpeg
+// @Accept       image/png
+// @Produce			 json
+// @Param				 id path int true "track id"
+// @Param				 body body string true "image"
+// @Success      200  {object}  v1.CoverUploadResponse "Uploaded cover"
+// @Failure      400  {object}  v1.HTTPError "Bad request or not a valid image"
+// @Failure      404  {object}  v1.HTTPError "Track not found"
+// @Failure      413  {object}  v1.HTTPError "Image is too large"
+// @Failure      415  {object}  v1.HTTPError "Unsupported Content-Type"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /tracks/{id}/cover [put]
+func (h *CoversHandlers) UploadTrackCover(c echo.Context) error {
+	return h.upload(c, entities.CoverOwnerTrack)
+}
+
+// UploadAlbumCover godoc
+// @Summary      Upload album cover
+// @Description  Uploads JPEG or PNG cover for the album, replaces the previous one. Requires role: editor, moderator, admin.
+// @Tags         Covers
+// @Accept       image/jpeg
+// @Accept       image/png
+// @Produce			 json
+// @Param				 id path int true "album id"
+// @Param				 body body string true "image"
+// @Success      200  {object}  v1.CoverUploadResponse "Uploaded cover"
+// @Failure      400  {object}  v1.HTTPError "Bad request or not a valid image"
+// @Failure      404  {object}  v1.HTTPError "Album not found"
+// @Failure      413  {object}  v1.HTTPError "Image is too large"
+// @Failure      415  {object}  v1.HTTPError "Unsupported Content-Type"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /albums/{id}/cover [put]
+func (h *CoversHandlers) UploadAlbumCover(c echo.Context) error {
+	return h.upload(c, entities.CoverOwnerAlbum)
+}
+
+// TrackCover godoc
+// @Summary      Track cover
+// @Description  Returns track cover or its thumbnail. Format (JPEG or PNG) is negotiated with the Accept header.
+// @Tags         Covers
+// @Produce			 image/jpeg
+// @Produce			 image/png
+// @Param				 id path int true "track id"
+// @Param				 size query int false "Thumbnail size, one of COVERS_THUMBNAIL_SIZES. Original size if empty."
+// @Success      200  {file}  file "Image"
+// @Success      304  "Not modified"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Cover not found"
+// @Failure      406  {object}  v1.HTTPError "Neither image/jpeg nor image/png is acceptable"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /tracks/{id}/cover [get]
+func (h *CoversHandlers) TrackCover(c echo.Context) error {
+	return h.serve(c, entities.CoverOwnerTrack)
+}
+
+// AlbumCover godoc
+// @Summary      Album cover
+// @Description  Returns album cover or its thumbnail. Format (JPEG or PNG) is negotiated with the Accept header.
+// @Tags         Covers
+// @Produce			 image/jpeg
+// @Produce			 image/png
+// @Param				 id path int true "album id"
+// @Param				 size query int false "Thumbnail size, one of COVERS_THUMBNAIL_SIZES. Original size if empty."
+// @Success      200  {file}  file "Image"
+// @Success      304  "Not modified"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Cover not found"
+// @Failure      406  {object}  v1.HTTPError "Neither image/jpeg nor image/png is acceptable"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /albums/{id}/cover [get]
+func (h *CoversHandlers) AlbumCover(c echo.Context) error {
+	return h.serve(c, entities.CoverOwnerAlbum)
+}
+
+func (h *CoversHandlers) upload(c echo.Context, kind entities.CoverOwnerKind) (err error) {
+	var pathParam CoverPathParam
+
+	// Bind не подходит: тело запроса - изображение, а не JSON.
+	if err = (&echo.DefaultBinder{}).BindPathParams(c, &pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	switch contentType := c.Request().Header.Get(echo.HeaderContentType); {
+	case strings.HasPrefix(contentType, "image/jpeg"), strings.HasPrefix(contentType, "image/png"):
+	default:
+		return c.JSON(http.StatusUnsupportedMediaType, HTTPError{Message: "Content-Type must be image/jpeg or image/png"})
+	}
+
+	if c.Request().ContentLength > h.cfg.MaxSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, HTTPError{Message: fmt.Sprintf("image must not exceed %d bytes", h.cfg.MaxSize)})
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, h.cfg.MaxSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.JSON(http.StatusRequestEntityTooLarge, HTTPError{Message: fmt.Sprintf("image must not exceed %d bytes", h.cfg.MaxSize)})
+		}
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	image, err := h.coversService.Upload(c.Request().Context(), entities.CoverUpload{
+		Owner: entities.CoverOwner{Kind: kind, ID: pathParam.ID},
+		Data:  data,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTrackNotFound), errors.Is(err, domain.ErrAlbumNotFound):
+			return c.JSON(http.StatusNotFound, HTTPError{Message: fmt.Sprintf("%s not found", kind)})
+		case errors.Is(err, domain.ErrCoverInvalid):
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: domain.ErrCoverInvalid.Error()})
+		}
+		h.logger.Err(err).Str("kind", string(kind)).Int("id", pathParam.ID).Msg("failed to coversService.Upload")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	c.Response().Header().Set("ETag", strconv.Quote(image.ETag))
+
+	return c.JSON(http.StatusOK, CoverUploadResponse{
+		ContentType: image.ContentType,
+		ETag:        image.ETag,
+		Width:       image.Width,
+		Height:      image.Height,
+	})
+}
+
+func (h *CoversHandlers) serve(c echo.Context, kind entities.CoverOwnerKind) (err error) {
+	var (
+		pathParam  CoverPathParam
+		queryParam CoverQuery
+	)
+
+	if err = (&echo.DefaultBinder{}).BindPathParams(c, &pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+	if err = (&echo.DefaultBinder{}).BindQueryParams(c, &queryParam); err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "size param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	if err = c.Validate(queryParam); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	image, file, err := h.coversService.Open(c.Request().Context(), entities.CoverRequest{
+		Owner:  entities.CoverOwner{Kind: kind, ID: pathParam.ID},
+		Size:   queryParam.Size,
+		Accept: parseAccept(c.Request().Header.Get(echo.HeaderAccept)),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrCoverNotFound):
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrCoverNotFound.Error()})
+		case errors.Is(err, domain.ErrCoverInvalidSize):
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: fmt.Sprintf("size must be one of %v", h.cfg.ThumbnailSizes)})
+		case errors.Is(err, domain.ErrCoverNotAcceptable):
+			return c.JSON(http.StatusNotAcceptable, HTTPError{Message: domain.ErrCoverNotAcceptable.Error()})
+		}
+		h.logger.Err(err).Str("kind", string(kind)).Int("id", pathParam.ID).Msg("failed to coversService.Open")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+	defer file.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, image.ContentType)
+	header.Set("ETag", strconv.Quote(image.ETag))
+	header.Set(echo.HeaderVary, echo.HeaderAccept)
+	header.Set("Cache-Control", "no-cache")
+
+	http.ServeContent(c.Response(), c.Request(), "", image.UpdatedAt, file)
+
+	return nil
+}
+
+// trackCoverURL адрес обложки трека для ответов API, пустой если обложки нет.
+func trackCoverURL(track entities.Track) string {
+	if !track.HasCover {
+		return ""
+	}
+
+	return fmt.Sprintf("/api/v1/tracks/%d/cover", track.ID)
+}
+
+// parseAccept возвращает MIME типы из заголовка Accept в порядке убывания q (при равном q - в порядке заголовка).
+// Типы с q=0 исключаются.
+func parseAccept(header string) []string {
+	type mediaRange struct {
+		mediaType string
+		q         float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if mediaType == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(key, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	accept := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		accept = append(accept, r.mediaType)
+	}
+
+	return accept
+}
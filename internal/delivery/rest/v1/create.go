@@ -16,14 +16,21 @@ type TracksCreateRequest struct {
 
 // Create godoc
 // @Summary      Create track
-// @Description  Creating track
+// @Description  Creating track. Requires role: editor, moderator, admin.
 // @Tags         Tracks
 // @Accept       json
 // @Produce			 json
 // @Param				 input body v1.TracksCreateRequest true "Create track by song and group names."
+// @Param				 Idempotency-Key header string false "Repeated request with the same key and body returns the stored response."
 // @Success      201  {string}  string "Success created"
 // @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      409  {object}  v1.HTTPError "Request with the same Idempotency-Key is still in progress"
+// @Failure      422  {object}  v1.HTTPError "Idempotency-Key is already used with a different request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      429  {object}  v1.HTTPError "Too many requests, see Retry-After"
 // @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
 // @Router       /tracks/ [post]
 func (h *TracksHandlers) Create(c echo.Context) (err error) {
 	var request TracksCreateRequest
@@ -43,7 +50,7 @@ func (h *TracksHandlers) Create(c echo.Context) (err error) {
 		Artist: request.Group,
 	}); err != nil {
 		h.logger.Err(err).Msg("failed to trackService.Create")
-		if errors.Is(err, domain.ErrTrackAlreadyExists) {
+		if errors.Is(err, domain.ErrTrackAlreadyExists) || errors.Is(err, domain.ErrArtistAlreadyExists) {
 			return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong, try again later"})
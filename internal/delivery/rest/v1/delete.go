@@ -12,14 +12,17 @@ type TrackDeletePrarams struct {
 
 // Delete godoc
 // @Summary      Delete track
-// @Description  Deliting track by track id
+// @Description  Deliting track by track id. Requires role: admin.
 // @Tags         Tracks
 // @Accept       json
 // @Produce			 json
 // @Param				 id path int true "track id"
 // @Success      204  {string} string "OK"
 // @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
 // @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
 // @Router       /tracks/{id}/ [delete]
 func (h *TracksHandlers) Delete(c echo.Context) (err error) {
 	var request TrackDeletePrarams
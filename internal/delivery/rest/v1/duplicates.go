@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type DuplicatesService interface {
+	Clusters(ctx context.Context, filters entities.DuplicateFilters) ([]entities.DuplicateCluster, error)
+	Merge(ctx context.Context, merge entities.DuplicateMerge) error
+}
+
+type DuplicatesHandlers struct {
+	duplicatesService DuplicatesService
+	logger            *zerolog.Logger
+}
+
+// NewDuplicatesHandlers регистрирует отчёт о дубликатах и слияние в группе /admin.
+func NewDuplicatesHandlers(g *echo.Group, ds DuplicatesService) *DuplicatesHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "duplicates").Logger()
+
+	h := &DuplicatesHandlers{
+		duplicatesService: ds,
+		logger:            &logger,
+	}
+
+	g.GET("/duplicates", h.List, RequirePermission(entities.PermissionTracksReview))
+	g.POST("/duplicates/merge", h.Merge, RequirePermission(entities.PermissionTracksMerge))
+
+	return h
+}
+
+type DuplicatesListQuery struct {
+	Lyrics        bool    `query:"lyrics"`
+	MinConfidence float64 `query:"minConfidence" validate:"gte=0,lte=1"`
+	Limit         int     `query:"limit" validate:"gte=0,lte=500"`
+	Offset        int     `query:"offset" validate:"gte=0"`
+}
+
+type DuplicateTrackResponse struct {
+	TrackID int    `json:"trackID"`
+	Track   string `json:"track"`
+	// Similarity сходство текста с каноническим треком, только при lyrics=true и если тексты есть у обоих.
+	Similarity *float64 `json:"similarity,omitempty"`
+}
+
+type DuplicateClusterResponse struct {
+	Key              string                   `json:"key"`
+	ArtistID         int                      `json:"artistID"`
+	Artist           string                   `json:"artist"`
+	CanonicalTrackID int                      `json:"canonicalTrackID"`
+	Confidence       float64                  `json:"confidence"`
+	Tracks           []DuplicateTrackResponse `json:"tracks"`
+}
+
+type DuplicatesMergeRequest struct {
+	TargetTrackID int   `json:"targetTrackID" validate:"required,gt=0" example:"1"`
+	TrackIDs      []int `json:"trackIDs" validate:"required,min=1,max=50,dive,gt=0" example:"2,3"`
+}
+
+type DuplicatesMergeResponse struct {
+	TrackID int   `json:"trackID"`
+	Merged  []int `json:"merged"`
+}
+
+// List godoc
+// @Summary      List duplicates
+// @Description  Clusters of tracks of the same artist whose titles differ only in case, width, spaces or version suffixes like "(Remastered)" or "- Live". Requires role: moderator, admin.
+// @Tags         Admin
+// @Accept       json
+// @Produce			 json
+// @Param				 lyrics query bool false "Also compare lyrics, confidence is averaged with lyric similarity."
+// @Param				 minConfidence query number false "Minimal cluster confidence, 0..1."
+// @Param				 limit query int false "Limit result, 50 by default."
+// @Param				 offset query int false "Offset result."
+// @Success      200  {array}   v1.DuplicateClusterResponse "Clusters, most confident first"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/duplicates [get]
+func (h *DuplicatesHandlers) List(c echo.Context) (err error) {
+	var query DuplicatesListQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	clusters, err := h.duplicatesService.Clusters(c.Request().Context(), entities.DuplicateFilters{
+		Lyrics:        query.Lyrics,
+		MinConfidence: query.MinConfidence,
+		Limit:         query.Limit,
+		Offset:        query.Offset,
+	})
+	if err != nil {
+		h.logger.Err(err).Msg("failed to duplicatesService.Clusters")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]DuplicateClusterResponse, 0, len(clusters))
+	for _, cluster := range clusters {
+		tracks := make([]DuplicateTrackResponse, 0, len(cluster.Tracks))
+		for _, track := range cluster.Tracks {
+			tracks = append(tracks, DuplicateTrackResponse{
+				TrackID:    track.TrackID,
+				Track:      track.Title,
+				Similarity: track.Similarity,
+			})
+		}
+		response = append(response, DuplicateClusterResponse{
+			Key:              cluster.Key,
+			ArtistID:         cluster.ArtistID,
+			Artist:           cluster.Artist,
+			CanonicalTrackID: cluster.CanonicalTrackID,
+			Confidence:       cluster.Confidence,
+			Tracks:           tracks,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Merge godoc
+// @Summary      Merge duplicates
+// @Description  Merging tracks into the target track: playlists, scrobbles, favorites and tags are moved to the target, lyrics, audio, cover and album are taken only if the target has none, merged tracks are deleted. All tracks must be in one duplicates cluster. Requires role: admin.
+// @Tags         Admin
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.DuplicatesMergeRequest true "Target track and tracks to merge into it."
+// @Success      200  {object}  v1.DuplicatesMergeResponse "Merged"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      404  {object}  v1.HTTPError "Track not found"
+// @Failure      422  {object}  v1.HTTPError "Tracks are not duplicates"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/duplicates/merge [post]
+func (h *DuplicatesHandlers) Merge(c echo.Context) (err error) {
+	var request DuplicatesMergeRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.duplicatesService.Merge(c.Request().Context(), entities.DuplicateMerge{
+		TargetTrackID: request.TargetTrackID,
+		TrackIDs:      request.TrackIDs,
+	}); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTrackNotFound):
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrTrackNotFound.Error()})
+		case errors.Is(err, domain.ErrDuplicateMergeInvalid):
+			return c.JSON(http.StatusUnprocessableEntity, HTTPError{Message: err.Error()})
+		}
+		h.logger.Err(err).Int("trackID", request.TargetTrackID).Msg("failed to duplicatesService.Merge")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	merged := make([]int, 0, len(request.TrackIDs))
+	for _, id := range request.TrackIDs {
+		if id != request.TargetTrackID && !slices.Contains(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+
+	return c.JSON(http.StatusOK, DuplicatesMergeResponse{TrackID: request.TargetTrackID, Merged: merged})
+}
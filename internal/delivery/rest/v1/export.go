@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/exporter"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// exportFlushEvery через сколько треков ответ сбрасывается клиенту.
+const exportFlushEvery = 100
+
+type ExportHandlers struct {
+	trackService TracksService
+	logger       *zerolog.Logger
+}
+
+func NewExportHandlers(g *echo.Group, ts TracksService) *ExportHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "export").Logger()
+
+	h := &ExportHandlers{
+		trackService: ts,
+		logger:       &logger,
+	}
+
+	g.GET("", h.Export)
+	g.GET("/", h.Export)
+
+	return h
+}
+
+type ExportQuery struct {
+	TracksListQuery
+
+	Format string `query:"format" validate:"omitempty,oneof=json ndjson csv"`
+}
+
+// Export godoc
+// @Summary      Export library
+// @Description  Streaming export of all tracks with lyrics. CSV and NDJSON can be loaded back with cmd/import.
+// @Tags         Export
+// @Produce			 json
+// @Produce			 text/csv
+// @Produce			 application/x-ndjson
+// @Param				 format query string false "Output format." Enums(json, ndjson, csv) default(json)
+// @Param				 limit query string false "Limit result (all tracks if empty)."
+// @Param				 offset query string false "Offset result."
+// @Param				 artist query string false "Name of the artist or group, matches in Cyrillic and Latin (Кино = Kino)."
+// @Param				 track query string false "Title of track, matches in Cyrillic and Latin."
+// @Param				 releasedyear query string false "Release year."
+// @Param				 link query string false "Exact link"
+// @Param				 lang query string false "Lyric language, ISO 639-1."
+// @Success      200  {array}  exporter.Track "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      429  {object}  v1.HTTPError "Too many requests, see Retry-After"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /export [get]
+func (h *ExportHandlers) Export(c echo.Context) (err error) {
+	var query ExportQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	format := exporter.Format(query.Format)
+	if format == "" {
+		format = exporter.FormatJSON
+	}
+
+	res := c.Response()
+	encoder, err := exporter.NewEncoder(res, format)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	// Заголовки отправляются с первым треком, чтобы ошибку до начала выгрузки можно было вернуть обычным JSON.
+	begin := func() {
+		if res.Committed {
+			return
+		}
+		res.Header().Set(echo.HeaderContentType, format.ContentType())
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="library.%s"`, format))
+		res.WriteHeader(http.StatusOK)
+	}
+
+	count := 0
+	err = h.trackService.Export(c.Request().Context(), entities.TrackGetListFilters{
+		Limit:        query.Limit,
+		Offset:       query.Offset,
+		Artist:       query.Artist,
+		Track:        query.Track,
+		ReleasedYear: query.ReleasedYear,
+		Link:         query.Link,
+		Lang:         query.Lang,
+	}, func(track entities.Track) error {
+		begin()
+
+		if err := encoder.Encode(track); err != nil {
+			return err
+		}
+
+		if count++; count%exportFlushEvery == 0 {
+			flush(encoder, res)
+		}
+
+		return nil
+	})
+	if err != nil {
+		h.logger.Err(err).Int("exported", count).Msg("failed to trackService.Export")
+		if !res.Committed {
+			return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+		}
+		abortExport()
+	}
+
+	begin()
+
+	if err = encoder.Close(); err != nil {
+		h.logger.Err(err).Msg("failed to encoder.Close")
+		abortExport()
+	}
+	res.Flush()
+
+	return nil
+}
+
+// abortExport обрывает соединение после отправки заголовков: клиент получает ошибку чтения
+// (без завершающего chunk), а не 200 с обрезанной выгрузкой. http.ErrAbortHandler не
+// перехватывается middleware.Recover и не логируется сервером как паника.
+func abortExport() {
+	panic(http.ErrAbortHandler)
+}
+
+func flush(encoder exporter.Encoder, res *echo.Response) {
+	if f, ok := encoder.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	res.Flush()
+}
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type FavoritesService interface {
+	Add(ctx context.Context, userID int, trackID int) (time.Time, bool, error)
+	Remove(ctx context.Context, userID int, trackID int) error
+	List(ctx context.Context, filters entities.FavoriteGetListFilters) ([]entities.Favorite, error)
+}
+
+type FavoritesHandlers struct {
+	favoritesService FavoritesService
+	logger           *zerolog.Logger
+}
+
+// NewFavoritesHandlers регистрирует маршруты избранного текущего пользователя в группе /me.
+func NewFavoritesHandlers(g *echo.Group, fs FavoritesService) *FavoritesHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "favorites").Logger()
+
+	h := &FavoritesHandlers{
+		favoritesService: fs,
+		logger:           &logger,
+	}
+
+	g.GET("/favorites", h.List, RequireUser)
+	edit := RequirePermission(entities.PermissionFavoritesEdit)
+	g.POST("/favorites/:trackID", h.Add, edit)
+	g.DELETE("/favorites/:trackID", h.Remove, edit)
+
+	return h
+}
+
+type FavoritePathParam struct {
+	TrackID int `json:"-" param:"trackID" validate:"required,gt=0"`
+}
+
+type FavoritesListQuery struct {
+	Limit  int    `query:"limit" validate:"gte=0,lte=500"`
+	Offset int    `query:"offset" validate:"gte=0"`
+	Sort   string `query:"sort" validate:"max=16"`
+}
+
+type FavoriteResponse struct {
+	TrackID     int       `json:"trackID"`
+	FavoritedAt time.Time `json:"favoritedAt"`
+}
+
+type FavoriteTrackResponse struct {
+	TrackID     int       `json:"trackID"`
+	Artist      string    `json:"artist"`
+	Track       string    `json:"track"`
+	Link        string    `json:"link"`
+	Released    time.Time `json:"released"`
+	DurationMs  int64     `json:"durationMs"`
+	CoverURL    string    `json:"coverURL,omitempty"`
+	FavoritedAt time.Time `json:"favoritedAt"`
+}
+
+// List godoc
+// @Summary      List favorites
+// @Description  Listing favorite tracks of the current user
+// @Tags         Favorites
+// @Accept       json
+// @Produce			 json
+// @Param				 limit query int false "Limit result, 50 by default."
+// @Param				 offset query int false "Offset result."
+// @Param				 sort query string false "favorited, title, artist or released, '-' prefix for descending. -favorited by default."
+// @Success      200  {array}   v1.FavoriteTrackResponse "Favorite tracks"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /me/favorites [get]
+func (h *FavoritesHandlers) List(c echo.Context) (err error) {
+	var query FavoritesListQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	user, _ := entities.UserFromContext(c.Request().Context())
+
+	favorites, err := h.favoritesService.List(c.Request().Context(), entities.FavoriteGetListFilters{
+		UserID: user.ID,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+		Sort:   entities.TrackSort(query.Sort),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrFavoriteInvalidSort) {
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+		}
+		h.logger.Err(err).Msg("failed to favoritesService.List")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]FavoriteTrackResponse, 0, len(favorites))
+	for _, favorite := range favorites {
+		response = append(response, FavoriteTrackResponse{
+			TrackID:     favorite.Track.ID,
+			Artist:      favorite.Track.Artist,
+			Track:       favorite.Track.Track,
+			Link:        favorite.Track.Link,
+			Released:    favorite.Track.Released,
+			DurationMs:  favorite.Track.Duration.Milliseconds(),
+			CoverURL:    trackCoverURL(favorite.Track),
+			FavoritedAt: favorite.FavoritedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Add godoc
+// @Summary      Add favorite
+// @Description  Adding track to favorites of the current user. Adding the same track again keeps the original time. API keys need the favorites:edit scope.
+// @Tags         Favorites
+// @Accept       json
+// @Produce			 json
+// @Param				 trackID path int true "track id"
+// @Success      201  {object}  v1.FavoriteResponse "Added"
+// @Success      200  {object}  v1.FavoriteResponse "Already in favorites"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "API key has no scope"
+// @Failure      404  {object}  v1.HTTPError "Track not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /me/favorites/{trackID} [post]
+func (h *FavoritesHandlers) Add(c echo.Context) (err error) {
+	var request FavoritePathParam
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "trackID param is invalid"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	user, _ := entities.UserFromContext(c.Request().Context())
+
+	favoritedAt, created, err := h.favoritesService.Add(c.Request().Context(), user.ID, request.TrackID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTrackNotFound) {
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrTrackNotFound.Error()})
+		}
+		h.logger.Err(err).Int("trackID", request.TrackID).Msg("failed to favoritesService.Add")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	return c.JSON(status, FavoriteResponse{TrackID: request.TrackID, FavoritedAt: favoritedAt})
+}
+
+// Remove godoc
+// @Summary      Remove favorite
+// @Description  Removing track from favorites of the current user. API keys need the favorites:edit scope.
+// @Tags         Favorites
+// @Accept       json
+// @Produce			 json
+// @Param				 trackID path int true "track id"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "API key has no scope"
+// @Failure      404  {object}  v1.HTTPError "Track is not in favorites"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /me/favorites/{trackID} [delete]
+func (h *FavoritesHandlers) Remove(c echo.Context) (err error) {
+	var request FavoritePathParam
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "trackID param is invalid"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	user, _ := entities.UserFromContext(c.Request().Context())
+
+	if err = h.favoritesService.Remove(c.Request().Context(), user.ID, request.TrackID); err != nil {
+		if errors.Is(err, domain.ErrFavoriteNotFound) {
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrFavoriteNotFound.Error()})
+		}
+		h.logger.Err(err).Int("trackID", request.TrackID).Msg("failed to favoritesService.Remove")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
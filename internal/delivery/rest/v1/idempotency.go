@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotency-Replayed"
+
+	// idempotencyKeyMaxLength оставляет место под префикс пользователя в колонке VARCHAR(255).
+	idempotencyKeyMaxLength = 200
+	idempotencyPollInterval = 100 * time.Millisecond
+)
+
+type IdempotencyStore interface {
+	Acquire(ctx context.Context, key string, requestHash string, ttl time.Duration) (record dao.IdempotencyKey, acquired bool, err error)
+	Get(ctx context.Context, key string) (record dao.IdempotencyKey, err error)
+	Complete(ctx context.Context, record dao.IdempotencyKey) (err error)
+	Release(ctx context.Context, key string) (err error)
+}
+
+// Idempotency middleware для POST запросов с заголовком Idempotency-Key.
+//
+// Повтор с тем же ключом и телом получает сохранённый ответ, с другим телом - 422.
+// Параллельный дубликат ждёт завершения исходного запроса. Ответы, после которых запрос
+// повторяют без изменений (releasedStatus), не сохраняются, такой запрос можно повторить с тем же ключом.
+type Idempotency struct {
+	store  IdempotencyStore
+	cfg    config.Idempotency
+	logger *zerolog.Logger
+}
+
+func NewIdempotency(store IdempotencyStore, cfg config.Idempotency) *Idempotency {
+	logger := logger.DefaultLogger().With().Str(packageKey, "idempotency").Logger()
+
+	return &Idempotency{store: store, cfg: cfg, logger: &logger}
+}
+
+func (m *Idempotency) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		key := c.Request().Header.Get(HeaderIdempotencyKey)
+		if c.Request().Method != http.MethodPost || key == "" {
+			return next(c)
+		}
+
+		if len(key) > idempotencyKeyMaxLength {
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: "Idempotency-Key is too long"})
+		}
+
+		// Ключи разных пользователей не пересекаются.
+		if user, ok := entities.UserFromContext(c.Request().Context()); ok {
+			key = fmt.Sprintf("u%d:%s", user.ID, key)
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request().Context()
+		hash := requestHash(c.Request(), body)
+
+		record, acquired, err := m.store.Acquire(ctx, key, hash, m.cfg.TTL)
+		if err != nil {
+			m.logger.Err(err).Msg("failed to store.Acquire")
+			return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong, try again later"})
+		}
+
+		if !acquired {
+			return m.replay(c, record, hash)
+		}
+
+		return m.execute(c, next, key)
+	}
+}
+
+// execute выполняет запрос и сохраняет ответ.
+func (m *Idempotency) execute(c echo.Context, next echo.HandlerFunc, key string) error {
+	recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+	c.Response().Writer = recorder
+
+	err := next(c)
+	if err != nil {
+		c.Error(err)
+	}
+
+	// Ответ уже отправлен клиенту, сохранение не должно зависеть от его соединения.
+	ctx := context.WithoutCancel(c.Request().Context())
+
+	status := c.Response().Status
+	if releasedStatus(status) {
+		if releaseErr := m.store.Release(ctx, key); releaseErr != nil {
+			m.logger.Err(releaseErr).Str("key", key).Msg("failed to store.Release")
+		}
+		return nil
+	}
+
+	if completeErr := m.store.Complete(ctx, dao.IdempotencyKey{
+		Key:                 key,
+		ResponseStatus:      status,
+		ResponseContentType: c.Response().Header().Get(echo.HeaderContentType),
+		ResponseBody:        recorder.body.Bytes(),
+	}); completeErr != nil {
+		m.logger.Err(completeErr).Str("key", key).Msg("failed to store.Complete")
+	}
+
+	return nil
+}
+
+// replay отдаёт сохранённый ответ, при необходимости дожидаясь завершения исходного запроса.
+func (m *Idempotency) replay(c echo.Context, record dao.IdempotencyKey, hash string) (err error) {
+	if record.RequestHash != hash {
+		return c.JSON(http.StatusUnprocessableEntity, HTTPError{Message: "Idempotency-Key is already used with a different request"})
+	}
+
+	ctx, cancelFunc := context.WithTimeout(c.Request().Context(), m.cfg.WaitTimeout)
+	defer cancelFunc()
+
+	ticker := time.NewTicker(idempotencyPollInterval)
+	defer ticker.Stop()
+
+	inProgress := func() error {
+		return c.JSON(http.StatusConflict, HTTPError{Message: "request with this Idempotency-Key is still in progress"})
+	}
+
+	for record.ResponseStatus == 0 {
+		select {
+		case <-ctx.Done():
+			return inProgress()
+		case <-ticker.C:
+		}
+		// Тикер и истечение ожидания могут сработать одновременно, select выбирает случайно.
+		if ctx.Err() != nil {
+			return inProgress()
+		}
+
+		if record, err = m.store.Get(ctx, record.Key); err != nil {
+			if errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+				// Исходный запрос завершился ошибкой и освободил ключ.
+				return c.JSON(http.StatusConflict, HTTPError{Message: "original request failed, retry"})
+			}
+			// Ожидание истекло во время запроса к хранилищу.
+			if ctx.Err() != nil {
+				return inProgress()
+			}
+			m.logger.Err(err).Msg("failed to store.Get")
+			return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong, try again later"})
+		}
+	}
+
+	c.Response().Header().Set(HeaderIdempotencyReplayed, "true")
+	if record.ResponseContentType == "" {
+		return c.NoContent(record.ResponseStatus)
+	}
+
+	return c.Blob(record.ResponseStatus, record.ResponseContentType, record.ResponseBody)
+}
+
+// releasedStatus ответы, которые не сохраняются: 5xx и ответы middleware маршрутов, стоящих после
+// Idempotency, - 429 от лимитов, 401 и 403 от RequirePermission. Повтор с тем же ключом после
+// Retry-After, выдачи роли или scope ключа должен выполнить запрос, а не получить прежний отказ.
+func releasedStatus(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	}
+
+	return status >= http.StatusInternalServerError
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder дублирует тело ответа в буфер.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
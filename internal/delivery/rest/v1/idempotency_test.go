@@ -0,0 +1,273 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
+	v1 "github.com/neyrzx/youmusic/internal/delivery/rest/v1"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIdempotencyStore хранит ключи в памяти.
+type fakeIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]dao.IdempotencyKey
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{records: make(map[string]dao.IdempotencyKey)}
+}
+
+func (s *fakeIdempotencyStore) Acquire(_ context.Context, key, requestHash string, _ time.Duration) (dao.IdempotencyKey, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		return record, false, nil
+	}
+	s.records[key] = dao.IdempotencyKey{Key: key, RequestHash: requestHash}
+
+	return s.records[key], true, nil
+}
+
+func (s *fakeIdempotencyStore) Get(_ context.Context, key string) (dao.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return dao.IdempotencyKey{}, domain.ErrIdempotencyKeyNotFound
+	}
+
+	return record, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(_ context.Context, record dao.IdempotencyKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.RequestHash = s.records[record.Key].RequestHash
+	s.records[record.Key] = record
+
+	return nil
+}
+
+func (s *fakeIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+
+	return nil
+}
+
+type idempotentRequest struct {
+	key              string
+	body             string
+	expectedStatus   int
+	expectedBody     string
+	expectedReplayed bool
+}
+
+func postIdempotent(e *echo.Echo, r idempotentRequest) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(r.body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if r.key != "" {
+		req.Header.Set(v1.HeaderIdempotencyKey, r.key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestIdempotency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// statuses ответы обработчика по порядку вызовов.
+		statuses      []int
+		requests      []idempotentRequest
+		expectedCalls int
+	}{
+		{
+			"case: repeated request gets saved response",
+			[]int{http.StatusCreated, http.StatusCreated},
+			[]idempotentRequest{
+				{"k", `{"a":1}`, http.StatusCreated, `{"call":1}`, false},
+				{"k", `{"a":1}`, http.StatusCreated, `{"call":1}`, true},
+			},
+			1,
+		},
+		{
+			"case: different body with the same key",
+			[]int{http.StatusCreated},
+			[]idempotentRequest{
+				{"k", `{"a":1}`, http.StatusCreated, `{"call":1}`, false},
+				{"k", `{"a":2}`, http.StatusUnprocessableEntity, "", false},
+			},
+			1,
+		},
+		{
+			"case: server error releases key",
+			[]int{http.StatusInternalServerError, http.StatusCreated},
+			[]idempotentRequest{
+				{"k", `{"a":1}`, http.StatusInternalServerError, `{"call":1}`, false},
+				{"k", `{"a":1}`, http.StatusCreated, `{"call":2}`, false},
+				{"k", `{"a":1}`, http.StatusCreated, `{"call":2}`, true},
+			},
+			2,
+		},
+		{
+			"case: client error is saved",
+			[]int{http.StatusBadRequest, http.StatusCreated},
+			[]idempotentRequest{
+				{"k", `{"a":1}`, http.StatusBadRequest, `{"call":1}`, false},
+				{"k", `{"a":1}`, http.StatusBadRequest, `{"call":1}`, true},
+			},
+			1,
+		},
+		{
+			"case: requests without key are not deduplicated",
+			[]int{http.StatusCreated, http.StatusCreated},
+			[]idempotentRequest{
+				{"", `{"a":1}`, http.StatusCreated, `{"call":1}`, false},
+				{"", `{"a":1}`, http.StatusCreated, `{"call":2}`, false},
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			e := echo.New()
+			middleware := v1.NewIdempotency(newFakeIdempotencyStore(), config.Idempotency{TTL: time.Hour, WaitTimeout: time.Second})
+			e.POST("/items", func(c echo.Context) error {
+				calls++
+				return c.JSON(tt.statuses[calls-1], map[string]int{"call": calls})
+			}, middleware.Middleware)
+
+			for i, r := range tt.requests {
+				rec := postIdempotent(e, r)
+
+				assert.Equal(t, r.expectedStatus, rec.Code, "request %d", i)
+				if r.expectedBody != "" {
+					assert.JSONEq(t, r.expectedBody, rec.Body.String(), "request %d", i)
+				}
+				assert.Equal(t, r.expectedReplayed, rec.Header().Get(v1.HeaderIdempotencyReplayed) == "true", "request %d", i)
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestIdempotencyInProgress(t *testing.T) {
+	t.Parallel()
+
+	started, finish := make(chan struct{}), make(chan struct{})
+
+	e := echo.New()
+	middleware := v1.NewIdempotency(newFakeIdempotencyStore(), config.Idempotency{TTL: time.Hour, WaitTimeout: 300 * time.Millisecond})
+	e.POST("/items", func(c echo.Context) error {
+		close(started)
+		<-finish
+		return c.JSON(http.StatusCreated, map[string]int{"call": 1})
+	}, middleware.Middleware)
+
+	request := idempotentRequest{key: "k", body: `{"a":1}`}
+
+	original := make(chan *httptest.ResponseRecorder)
+	go func() { original <- postIdempotent(e, request) }()
+	<-started
+
+	// Дубликат ждёт исходный запрос дольше WaitTimeout.
+	assert.Equal(t, http.StatusConflict, postIdempotent(e, request).Code)
+
+	close(finish)
+	require.Equal(t, http.StatusCreated, (<-original).Code)
+
+	rec := postIdempotent(e, request)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get(v1.HeaderIdempotencyReplayed))
+}
+
+func TestIdempotencyRateLimited(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore()).WithClock(func() time.Time { return now })
+	rateLimit := v1.NewRateLimit(limiter, config.RateLimit{Enabled: true, Window: time.Minute})
+	middleware := v1.NewIdempotency(newFakeIdempotencyStore(), config.Idempotency{TTL: time.Hour, WaitTimeout: time.Second})
+
+	calls := 0
+	e := echo.New()
+	// Лимит маршрута стоит после Idempotency группы, как createLimit в InitAPI.
+	g := e.Group("", middleware.Middleware)
+	g.POST("/items", func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"call": calls})
+	}, rateLimit.Limit("items", 1))
+
+	assert.Equal(t, http.StatusCreated, postIdempotent(e, idempotentRequest{key: "first", body: `{"a":1}`}).Code)
+
+	retry := idempotentRequest{key: "second", body: `{"a":2}`}
+	rec := postIdempotent(e, retry)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+
+	now = now.Add(time.Minute)
+
+	rec = postIdempotent(e, retry)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"call":2}`, rec.Body.String())
+	assert.Empty(t, rec.Header().Get(v1.HeaderIdempotencyReplayed))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyForbidden(t *testing.T) {
+	t.Parallel()
+
+	user := entities.User{ID: 1, Role: entities.RoleViewer}
+	middleware := v1.NewIdempotency(newFakeIdempotencyStore(), config.Idempotency{TTL: time.Hour, WaitTimeout: time.Second})
+
+	calls := 0
+	e := echo.New()
+	authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(entities.ContextWithUser(c.Request().Context(), user)))
+			return next(c)
+		}
+	}
+	// Проверка прав стоит после Idempotency группы, как в NewTracksHandlers.
+	g := e.Group("", authenticate, middleware.Middleware)
+	g.POST("/items", func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"call": calls})
+	}, v1.RequirePermission(entities.PermissionTracksCreate))
+
+	request := idempotentRequest{key: "k", body: `{"a":1}`}
+	require.Equal(t, http.StatusForbidden, postIdempotent(e, request).Code)
+
+	user.Role = entities.RoleEditor
+
+	rec := postIdempotent(e, request)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(v1.HeaderIdempotencyReplayed))
+	assert.Equal(t, 1, calls)
+}
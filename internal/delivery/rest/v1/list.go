@@ -20,6 +20,7 @@ type TracksListQuery struct {
 	Track        string `query:"track"`
 	ReleasedYear string `query:"releasedyear"`
 	Link         string `query:"link"`
+	Lang         string `query:"lang" validate:"omitempty,len=2,lowercase"`
 }
 
 type TracksResponse struct {
@@ -29,20 +30,27 @@ type TracksResponse struct {
 	Lyric    []string  `json:"lyric"`
 	Link     string    `json:"link"`
 	Released time.Time `json:"released"`
+	CoverURL string    `json:"coverURL,omitempty"`
+	// Language язык текста (ISO 639-1), LanguageConfidence уверенность определения от 0 до 1.
+	Language           string  `json:"language,omitempty" example:"ru"`
+	LanguageConfidence float64 `json:"languageConfidence,omitempty" example:"0.98"`
+	// IsFavorite есть ли трек в избранном, только для аутентифицированных запросов.
+	IsFavorite *bool `json:"isFavorite,omitempty"`
 }
 
 // List godoc
 // @Summary      List of tracks
-// @Description  List of tracks with filters
+// @Description  List of tracks with filters. Authenticated requests get isFavorite flag.
 // @Tags         Tracks
 // @Accept       json
 // @Produce			 json
 // @Param				 limit query string false "Limit result."
 // @Param				 offset query string false "Offset result."
-// @Param				 artist query string false "Name of the artist or group."
-// @Param				 track query string false "Title of track."
+// @Param				 artist query string false "Name of the artist or group, matches in Cyrillic and Latin (Кино = Kino)."
+// @Param				 track query string false "Title of track, matches in Cyrillic and Latin."
 // @Param				 releasedyear query string false "List of tracks."
 // @Param				 link query string false "Exact link"
+// @Param				 lang query string false "Lyric language, ISO 639-1 (de, en, es, fr, it, pt, ru, uk)."
 // @Success      200  {array}  v1.TracksResponse "Success response"
 // @Failure      400  {object}  v1.HTTPError "Bad request"
 // @Failure      500  {object}  v1.HTTPError "Internal server error"
@@ -68,22 +76,31 @@ func (h *TracksHandlers) List(c echo.Context) (err error) {
 		Track:        queryparam.Track,
 		ReleasedYear: queryparam.ReleasedYear,
 		Link:         queryparam.Link,
+		Lang:         queryparam.Lang,
 	}); err != nil {
 		h.logger.Err(err).Msg("failed to trackService.GetList")
 		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
 	}
 
+	return c.JSON(http.StatusOK, tracksResponse(tracks))
+}
+
+func tracksResponse(tracks []entities.Track) []TracksResponse {
 	res := []TracksResponse{}
 	for _, track := range tracks {
 		res = append(res, TracksResponse{
-			TrackID:  track.ID,
-			Artist:   track.Artist,
-			Track:    track.Track,
-			Lyric:    track.Lyric,
-			Link:     track.Link,
-			Released: track.Released,
+			TrackID:            track.ID,
+			Artist:             track.Artist,
+			Track:              track.Track,
+			Lyric:              track.Lyric,
+			Link:               track.Link,
+			Released:           track.Released,
+			CoverURL:           trackCoverURL(track),
+			IsFavorite:         track.IsFavorite,
+			Language:           track.Language,
+			LanguageConfidence: track.LanguageConfidence,
 		})
 	}
 
-	return c.JSON(http.StatusOK, res)
+	return res
 }
@@ -0,0 +1,395 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/neyrzx/youmusic/pkg/playlistfile"
+	"github.com/rs/zerolog"
+)
+
+// playlistImportMaxSize максимальный размер импортируемого файла плейлиста.
+const playlistImportMaxSize = 5 << 20
+
+const playlistImportDefaultName = "Imported playlist"
+
+type PlaylistFilesService interface {
+	GetByID(ctx context.Context, id int) (entities.Playlist, error)
+	Import(ctx context.Context, imp entities.PlaylistImport) (entities.PlaylistImportReport, error)
+}
+
+type SmartPlaylistFilesService interface {
+	GetByID(ctx context.Context, id int) (entities.SmartPlaylist, error)
+	Tracks(ctx context.Context, id int) ([]entities.Track, error)
+}
+
+type PlaylistFilesHandlers struct {
+	playlistsService      PlaylistFilesService
+	smartPlaylistsService SmartPlaylistFilesService
+	logger                *zerolog.Logger
+}
+
+// NewPlaylistFilesHandlers экспорт и импорт плейлистов в форматах M3U8 и XSPF. createLimit ставится
+// на импорт с createMissing, который создаёт треки через music-info.
+func NewPlaylistFilesHandlers(
+	playlists *echo.Group,
+	smartPlaylists *echo.Group,
+	ps PlaylistFilesService,
+	sps SmartPlaylistFilesService,
+	createLimit echo.MiddlewareFunc,
+) *PlaylistFilesHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "playlist_files").Logger()
+
+	h := &PlaylistFilesHandlers{
+		playlistsService:      ps,
+		smartPlaylistsService: sps,
+		logger:                &logger,
+	}
+
+	playlists.GET("/:id/export", h.Export)
+	playlists.POST("/import", h.Import,
+		RequirePermission(entities.PermissionPlaylistsEdit),
+		whenCreateMissing(RequirePermission(entities.PermissionTracksCreate), createLimit),
+	)
+	smartPlaylists.GET("/:id/export", h.ExportSmart)
+
+	return h
+}
+
+type PlaylistExportQuery struct {
+	ID     int    `param:"id" validate:"required,gt=0"`
+	Format string `query:"format" validate:"omitempty,oneof=m3u8 m3u xspf"`
+	// Location что писать в адрес записи: auto - загруженное аудио, а если его нет - link.
+	Location string `query:"location" validate:"omitempty,oneof=auto link audio"`
+}
+
+type PlaylistImportQuery struct {
+	Format        string `query:"format" validate:"omitempty,oneof=m3u8 m3u xspf"`
+	Name          string `query:"name" validate:"max=255"`
+	CreateMissing bool   `query:"createMissing"`
+}
+
+type PlaylistImportResponse struct {
+	PlaylistID int                           `json:"playlistID"`
+	Matched    int                           `json:"matched"`
+	Created    int                           `json:"created"`
+	Missing    int                           `json:"missing"`
+	Failed     int                           `json:"failed"`
+	Entries    []PlaylistImportEntryResponse `json:"entries"`
+}
+
+type PlaylistImportEntryResponse struct {
+	Index   int    `json:"index"`
+	Artist  string `json:"artist"`
+	Title   string `json:"title"`
+	Status  string `json:"status" enums:"matched,created,missing,failed"`
+	TrackID int    `json:"trackID,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// whenCreateMissing применяет middlewares только к импорту с createMissing=true.
+func whenCreateMissing(middlewares ...echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withMiddlewares := next
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			withMiddlewares = middlewares[i](withMiddlewares)
+		}
+
+		return func(c echo.Context) error {
+			if createMissing, _ := strconv.ParseBool(c.QueryParam("createMissing")); createMissing {
+				return withMiddlewares(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Export godoc
+// @Summary      Export playlist
+// @Description  Exporting playlist as extended M3U8 or XSPF
+// @Tags         Playlists
+// @Produce			 audio/x-mpegurl
+// @Produce			 application/xspf+xml
+// @Param				 id path int true "playlist id"
+// @Param				 format query string false "File format." Enums(m3u8, xspf) default(m3u8)
+// @Param				 location query string false "Entry location: hosted audio URL, track link or audio if uploaded and link otherwise." Enums(auto, link, audio) default(auto)
+// @Success      200  {string}  string "Playlist file"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Playlist not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /playlists/{id}/export [get]
+func (h *PlaylistFilesHandlers) Export(c echo.Context) (err error) {
+	var query PlaylistExportQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	playlist, err := h.playlistsService.GetByID(c.Request().Context(), query.ID)
+	if err != nil {
+		return h.playlistError(c, err, "failed to playlistsService.GetByID")
+	}
+
+	file := playlistfile.Playlist{
+		Title:   playlist.Name,
+		Entries: make([]playlistfile.Entry, 0, len(playlist.Entries)),
+	}
+	for _, entry := range playlist.Entries {
+		file.Entries = append(file.Entries, playlistfile.Entry{
+			Location: entryLocation(c, query.Location, entry.TrackID, entry.Link, entry.HasAudio),
+			Artist:   entry.Artist,
+			Title:    entry.Track,
+			Duration: entry.Duration,
+		})
+	}
+
+	return h.writePlaylistFile(c, query, file)
+}
+
+// ExportSmart godoc
+// @Summary      Export smart playlist
+// @Description  Evaluating smart playlist rules and exporting tracks as extended M3U8 or XSPF
+// @Tags         SmartPlaylists
+// @Produce			 audio/x-mpegurl
+// @Produce			 application/xspf+xml
+// @Param				 id path int true "smart playlist id"
+// @Param				 format query string false "File format." Enums(m3u8, xspf) default(m3u8)
+// @Param				 location query string false "Entry location: hosted audio URL, track link or audio if uploaded and link otherwise." Enums(auto, link, audio) default(auto)
+// @Success      200  {string}  string "Playlist file"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Smart playlist not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /smart-playlists/{id}/export [get]
+func (h *PlaylistFilesHandlers) ExportSmart(c echo.Context) (err error) {
+	var query PlaylistExportQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	playlist, err := h.smartPlaylistsService.GetByID(c.Request().Context(), query.ID)
+	if err != nil {
+		return h.playlistError(c, err, "failed to smartPlaylistsService.GetByID")
+	}
+
+	tracks, err := h.smartPlaylistsService.Tracks(c.Request().Context(), query.ID)
+	if err != nil {
+		return h.playlistError(c, err, "failed to smartPlaylistsService.Tracks")
+	}
+
+	file := playlistfile.Playlist{
+		Title:   playlist.Name,
+		Entries: make([]playlistfile.Entry, 0, len(tracks)),
+	}
+	for _, track := range tracks {
+		file.Entries = append(file.Entries, playlistfile.Entry{
+			Location: entryLocation(c, query.Location, track.ID, track.Link, track.HasAudio),
+			Artist:   track.Artist,
+			Title:    track.Track,
+			Duration: track.Duration,
+		})
+	}
+
+	return h.writePlaylistFile(c, query, file)
+}
+
+// Import godoc
+// @Summary      Import playlist
+// @Description  Creating playlist from M3U8 or XSPF file. Entries are matched to tracks by artist and title ignoring case, unmatched entries are skipped and listed in the report. Requires role: editor, moderator, admin; createMissing also requires the tracks:create permission and counts against the track creation rate limit.
+// @Tags         Playlists
+// @Accept       audio/x-mpegurl
+// @Accept       application/xspf+xml
+// @Produce			 json
+// @Param				 format query string false "File format, detected by Content-Type and content if empty." Enums(m3u8, xspf)
+// @Param				 name query string false "Playlist name, taken from the file or 'Imported playlist' if empty."
+// @Param				 createMissing query bool false "Create tracks that are not found in the library."
+// @Param				 Idempotency-Key header string false "Repeated request with the same key and body returns the stored response."
+// @Success      201  {object}  v1.PlaylistImportResponse "Created playlist and match report"
+// @Failure      400  {object}  v1.HTTPError "Bad request or invalid file"
+// @Failure      413  {object}  v1.HTTPError "File too large"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      429  {object}  v1.HTTPError "Too many requests, see Retry-After"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /playlists/import [post]
+func (h *PlaylistFilesHandlers) Import(c echo.Context) (err error) {
+	var query PlaylistImportQuery
+
+	if err = (&echo.DefaultBinder{}).BindQueryParams(c, &query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if c.Request().ContentLength > playlistImportMaxSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, HTTPError{Message: fmt.Sprintf("file must not exceed %d bytes", playlistImportMaxSize)})
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, playlistImportMaxSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.JSON(http.StatusRequestEntityTooLarge, HTTPError{Message: fmt.Sprintf("file must not exceed %d bytes", playlistImportMaxSize)})
+		}
+		h.logger.Err(err).Msg("failed to io.ReadAll")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	var format playlistfile.Format
+	if query.Format != "" {
+		format, err = playlistfile.ParseFormat(query.Format)
+	} else {
+		format, err = playlistfile.DetectFormat(c.Request().Header.Get(echo.HeaderContentType), data)
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "unknown playlist format, set format query param"})
+	}
+
+	file, err := playlistfile.Decode(bytes.NewReader(data), format)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to playlistfile.Decode")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	imp := entities.PlaylistImport{
+		Name:          query.Name,
+		Entries:       make([]entities.TrackCreate, 0, len(file.Entries)),
+		CreateMissing: query.CreateMissing,
+	}
+	if imp.Name == "" {
+		imp.Name = truncate(file.Title, 255)
+	}
+	if imp.Name == "" {
+		imp.Name = playlistImportDefaultName
+	}
+	for _, entry := range file.Entries {
+		imp.Entries = append(imp.Entries, entities.TrackCreate{Artist: entry.Artist, Title: entry.Title})
+	}
+
+	report, err := h.playlistsService.Import(c.Request().Context(), imp)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to playlistsService.Import")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	res := PlaylistImportResponse{
+		PlaylistID: report.PlaylistID,
+		Matched:    report.Matched,
+		Created:    report.Created,
+		Missing:    report.Missing,
+		Failed:     report.Failed,
+		Entries:    make([]PlaylistImportEntryResponse, 0, len(report.Entries)),
+	}
+	for _, entry := range report.Entries {
+		res.Entries = append(res.Entries, PlaylistImportEntryResponse{
+			Index:   entry.Index,
+			Artist:  entry.Artist,
+			Title:   entry.Title,
+			Status:  string(entry.Status),
+			TrackID: entry.TrackID,
+			Error:   entry.Error,
+		})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (h *PlaylistFilesHandlers) writePlaylistFile(c echo.Context, query PlaylistExportQuery, file playlistfile.Playlist) error {
+	format := playlistfile.FormatM3U8
+	if query.Format != "" {
+		format, _ = playlistfile.ParseFormat(query.Format)
+	}
+
+	var buf bytes.Buffer
+	if err := playlistfile.Encode(&buf, format, file); err != nil {
+		h.logger.Err(err).Msg("failed to playlistfile.Encode")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", playlistFileName(file.Title, format)))
+
+	return c.Blob(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
+func (h *PlaylistFilesHandlers) playlistError(c echo.Context, err error, msg string) error {
+	for _, notFound := range []error{domain.ErrPlaylistNotFound, domain.ErrSmartPlaylistNotFound} {
+		if errors.Is(err, notFound) {
+			return c.JSON(http.StatusNotFound, HTTPError{Message: notFound.Error()})
+		}
+	}
+
+	h.logger.Err(err).Msg(msg)
+	return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+}
+
+// entryLocation адрес записи в файле плейлиста. Если выбранного адреса нет, используется карточка трека в API.
+func entryLocation(c echo.Context, mode string, trackID int, link string, hasAudio bool) string {
+	base := c.Scheme() + "://" + c.Request().Host
+	audio := fmt.Sprintf("%s/api/v1/tracks/%d/audio", base, trackID)
+
+	switch {
+	case mode == "link" && link != "":
+		return link
+	case mode == "audio" && hasAudio:
+		return audio
+	case (mode == "" || mode == "auto") && hasAudio:
+		return audio
+	case (mode == "" || mode == "auto") && link != "":
+		return link
+	}
+
+	return fmt.Sprintf("%s/api/v1/tracks/%d", base, trackID)
+}
+
+// playlistFileName безопасное имя файла для Content-Disposition.
+func playlistFileName(title string, format playlistfile.Format) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r == ' ':
+			return '_'
+		}
+		return -1
+	}, title)
+	if name == "" {
+		name = "playlist"
+	}
+
+	return name + "." + string(format)
+}
+
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+
+	return s
+}
@@ -0,0 +1,468 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type PlaylistsService interface {
+	Create(ctx context.Context, playlist entities.PlaylistCreate) (int, error)
+	GetList(ctx context.Context, filters entities.PlaylistGetListFilters) ([]entities.Playlist, error)
+	GetByID(ctx context.Context, id int) (entities.Playlist, error)
+	Update(ctx context.Context, playlist entities.PlaylistUpdate) error
+	Delete(ctx context.Context, id int) error
+	AddEntry(ctx context.Context, entry entities.PlaylistEntryAdd) (int, error)
+	MoveEntry(ctx context.Context, move entities.PlaylistEntryMove) error
+	DeleteEntry(ctx context.Context, playlistID int, entryID int) error
+}
+
+type PlaylistsHandlers struct {
+	playlistsService PlaylistsService
+	logger           *zerolog.Logger
+}
+
+func NewPlaylistsHandlers(g *echo.Group, ps PlaylistsService) *PlaylistsHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "playlists").Logger()
+
+	h := &PlaylistsHandlers{
+		playlistsService: ps,
+		logger:           &logger,
+	}
+
+	edit := RequirePermission(entities.PermissionPlaylistsEdit)
+
+	g.POST("/", h.Create, edit)
+	g.GET("/", h.List)
+	g.GET("/:id/", h.Retrieve)
+	g.PATCH("/:id/", h.Update, edit)
+	g.DELETE("/:id/", h.Delete, edit)
+	g.POST("/:id/tracks/", h.AddEntry, edit)
+	g.PATCH("/:id/tracks/:entryID/", h.MoveEntry, edit)
+	g.DELETE("/:id/tracks/:entryID/", h.DeleteEntry, edit)
+
+	return h
+}
+
+type PlaylistPathParam struct {
+	ID int `json:"-" param:"id" validate:"required,gt=0"`
+}
+
+type PlaylistEntryPathParam struct {
+	ID      int `json:"-" param:"id" validate:"required,gt=0"`
+	EntryID int `json:"-" param:"entryID" validate:"required,gt=0"`
+}
+
+type PlaylistCreateRequest struct {
+	Name        string `json:"name" validate:"required,max=255" example:"Road trip"`
+	Description string `json:"description" validate:"max=2048" example:"Songs for a long drive"`
+}
+
+type PlaylistCreateResponse struct {
+	PlaylistID int `json:"playlistID"`
+}
+
+type PlaylistUpdateRequest struct {
+	PlaylistPathParam
+	Name        *string `json:"name" validate:"omitempty,min=1,max=255" example:"Road trip"`
+	Description *string `json:"description" validate:"omitempty,max=2048" example:"Songs for a long drive"`
+}
+
+type PlaylistsListQuery struct {
+	Limit  int `query:"limit" validate:"gte=0"`
+	Offset int `query:"offset" validate:"gte=0"`
+}
+
+type PlaylistResponse struct {
+	PlaylistID  int       `json:"playlistID"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	TracksCount int       `json:"tracksCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type PlaylistRetrieveResponse struct {
+	PlaylistResponse
+	Entries []PlaylistEntryResponse `json:"entries"`
+}
+
+type PlaylistEntryResponse struct {
+	EntryID int       `json:"entryID"`
+	TrackID int       `json:"trackID"`
+	Artist  string    `json:"artist"`
+	Track   string    `json:"track"`
+	Index   int       `json:"index"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+type PlaylistEntryAddRequest struct {
+	PlaylistPathParam
+	TrackID int `json:"trackID" validate:"required,gt=0" example:"1"`
+	// Index место новой записи, начиная с 0. Если не задан - в конец плейлиста.
+	Index *int `json:"index" validate:"omitempty,gte=0" example:"0"`
+}
+
+type PlaylistEntryAddResponse struct {
+	EntryID int `json:"entryID"`
+}
+
+type PlaylistEntryMoveRequest struct {
+	PlaylistEntryPathParam
+	// Index новое место записи, начиная с 0. Значение больше длины плейлиста - в конец.
+	Index *int `json:"index" validate:"required,gte=0" example:"0"`
+}
+
+// Create godoc
+// @Summary      Create playlist
+// @Description  Creating empty playlist. Requires role: editor, moderator, admin.
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.PlaylistCreateRequest true "Playlist name and description."
+// @Param				 Idempotency-Key header string false "Repeated request with the same key and body returns the stored response."
+// @Success      201  {object}  v1.PlaylistCreateResponse "Created playlist"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /playlists/ [post]
+func (h *PlaylistsHandlers) Create(c echo.Context) (err error) {
+	var request PlaylistCreateRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	id, err := h.playlistsService.Create(c.Request().Context(), entities.PlaylistCreate{
+		Name:        request.Name,
+		Description: request.Description,
+	})
+	if err != nil {
+		h.logger.Err(err).Msg("failed to playlistsService.Create")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	return c.JSON(http.StatusCreated, PlaylistCreateResponse{PlaylistID: id})
+}
+
+// List godoc
+// @Summary      List of playlists
+// @Description  List of playlists without entries
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 limit query int false "Limit result."
+// @Param				 offset query int false "Offset result."
+// @Success      200  {array}  v1.PlaylistResponse "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /playlists/ [get]
+func (h *PlaylistsHandlers) List(c echo.Context) (err error) {
+	var query PlaylistsListQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	playlists, err := h.playlistsService.GetList(c.Request().Context(), entities.PlaylistGetListFilters{
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		h.logger.Err(err).Msg("failed to playlistsService.GetList")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	res := []PlaylistResponse{}
+	for _, playlist := range playlists {
+		res = append(res, playlistResponse(playlist))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// Retrieve godoc
+// @Summary      Retrieve playlist
+// @Description  Retrieving playlist with its entries in order
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "playlist id"
+// @Success      200  {object}  v1.PlaylistRetrieveResponse "Playlist"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Playlist not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /playlists/{id}/ [get]
+func (h *PlaylistsHandlers) Retrieve(c echo.Context) (err error) {
+	var pathParam PlaylistPathParam
+
+	if err = c.Bind(&pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	playlist, err := h.playlistsService.GetByID(c.Request().Context(), pathParam.ID)
+	if err != nil {
+		return h.playlistError(c, err, "failed to playlistsService.GetByID")
+	}
+
+	res := PlaylistRetrieveResponse{
+		PlaylistResponse: playlistResponse(playlist),
+		Entries:          make([]PlaylistEntryResponse, 0, len(playlist.Entries)),
+	}
+	for _, entry := range playlist.Entries {
+		res.Entries = append(res.Entries, PlaylistEntryResponse{
+			EntryID: entry.EntryID,
+			TrackID: entry.TrackID,
+			Artist:  entry.Artist,
+			Track:   entry.Track,
+			Index:   entry.Index,
+			AddedAt: entry.AddedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// Update godoc
+// @Summary      Update playlist
+// @Description  Updating playlist name and/or description, omitted fields are not changed. Requires role: editor, moderator, admin.
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "playlist id"
+// @Param				 input body v1.PlaylistUpdateRequest true "Playlist fields"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Playlist not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /playlists/{id}/ [patch]
+func (h *PlaylistsHandlers) Update(c echo.Context) (err error) {
+	var request PlaylistUpdateRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.playlistsService.Update(c.Request().Context(), entities.PlaylistUpdate{
+		PlaylistID:  request.ID,
+		Name:        request.Name,
+		Description: request.Description,
+	}); err != nil {
+		return h.playlistError(c, err, "failed to playlistsService.Update")
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+// Delete godoc
+// @Summary      Delete playlist
+// @Description  Deleting playlist with all its entries. Requires role: editor, moderator, admin.
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "playlist id"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Playlist not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /playlists/{id}/ [delete]
+func (h *PlaylistsHandlers) Delete(c echo.Context) (err error) {
+	var pathParam PlaylistPathParam
+
+	if err = c.Bind(&pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.playlistsService.Delete(c.Request().Context(), pathParam.ID); err != nil {
+		return h.playlistError(c, err, "failed to playlistsService.Delete")
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+// AddEntry godoc
+// @Summary      Add track to playlist
+// @Description  Inserting track at the given index (0-based) or at the end of playlist. The same track may be added several times. Requires role: editor, moderator, admin.
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "playlist id"
+// @Param				 input body v1.PlaylistEntryAddRequest true "Track and its place"
+// @Param				 Idempotency-Key header string false "Repeated request with the same key and body returns the stored response."
+// @Success      201  {object}  v1.PlaylistEntryAddResponse "Created entry"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Playlist or track not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /playlists/{id}/tracks/ [post]
+func (h *PlaylistsHandlers) AddEntry(c echo.Context) (err error) {
+	var request PlaylistEntryAddRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	entryID, err := h.playlistsService.AddEntry(c.Request().Context(), entities.PlaylistEntryAdd{
+		PlaylistID: request.ID,
+		TrackID:    request.TrackID,
+		Index:      request.Index,
+	})
+	if err != nil {
+		return h.playlistError(c, err, "failed to playlistsService.AddEntry")
+	}
+
+	return c.JSON(http.StatusCreated, PlaylistEntryAddResponse{EntryID: entryID})
+}
+
+// MoveEntry godoc
+// @Summary      Move playlist entry
+// @Description  Moving entry to the given index (0-based), other entries keep their relative order. Requires role: editor, moderator, admin.
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "playlist id"
+// @Param				 entryID path int true "entry id"
+// @Param				 input body v1.PlaylistEntryMoveRequest true "New place"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Playlist or entry not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /playlists/{id}/tracks/{entryID}/ [patch]
+func (h *PlaylistsHandlers) MoveEntry(c echo.Context) (err error) {
+	var request PlaylistEntryMoveRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.playlistsService.MoveEntry(c.Request().Context(), entities.PlaylistEntryMove{
+		PlaylistID: request.ID,
+		EntryID:    request.EntryID,
+		Index:      *request.Index,
+	}); err != nil {
+		return h.playlistError(c, err, "failed to playlistsService.MoveEntry")
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+// DeleteEntry godoc
+// @Summary      Remove track from playlist
+// @Description  Removing playlist entry. Requires role: editor, moderator, admin.
+// @Tags         Playlists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "playlist id"
+// @Param				 entryID path int true "entry id"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Playlist or entry not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /playlists/{id}/tracks/{entryID}/ [delete]
+func (h *PlaylistsHandlers) DeleteEntry(c echo.Context) (err error) {
+	var pathParam PlaylistEntryPathParam
+
+	if err = c.Bind(&pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.playlistsService.DeleteEntry(c.Request().Context(), pathParam.ID, pathParam.EntryID); err != nil {
+		return h.playlistError(c, err, "failed to playlistsService.DeleteEntry")
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+// playlistError переводит ошибки сервиса плейлистов в ответ, неизвестные ошибки логируются.
+func (h *PlaylistsHandlers) playlistError(c echo.Context, err error, msg string) error {
+	for _, notFound := range []error{domain.ErrPlaylistNotFound, domain.ErrPlaylistEntryNotFound, domain.ErrTrackNotFound} {
+		if errors.Is(err, notFound) {
+			return c.JSON(http.StatusNotFound, HTTPError{Message: notFound.Error()})
+		}
+	}
+
+	h.logger.Err(err).Msg(msg)
+	return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+}
+
+func playlistResponse(playlist entities.Playlist) PlaylistResponse {
+	return PlaylistResponse{
+		PlaylistID:  playlist.ID,
+		Name:        playlist.Name,
+		Description: playlist.Description,
+		TracksCount: playlist.TracksCount,
+		CreatedAt:   playlist.CreatedAt,
+		UpdatedAt:   playlist.UpdatedAt,
+	}
+}
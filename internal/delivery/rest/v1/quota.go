@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/neyrzx/youmusic/pkg/ratelimit"
+	"github.com/rs/zerolog"
+)
+
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	// HeaderRateLimitReset время открытия следующего окна, unix секунды.
+	HeaderRateLimitReset = "X-RateLimit-Reset"
+)
+
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (ratelimit.Result, error)
+	AllowN(ctx context.Context, key string, n, limit int, window time.Duration) (ratelimit.Result, error)
+}
+
+// Quota middleware квоты запросов API ключа. Ставится после Auth.Middleware, запросы без ключа пропускает.
+//
+// Состояние квоты отдаётся в заголовках X-RateLimit-*, сверх квоты - 429 с Retry-After.
+// Если хранилище счётчиков недоступно, запрос пропускается: квота не должна ронять API.
+type Quota struct {
+	limiter RateLimiter
+	window  time.Duration
+	logger  *zerolog.Logger
+}
+
+func NewQuota(limiter RateLimiter, window time.Duration) *Quota {
+	logger := logger.DefaultLogger().With().Str(packageKey, "quota").Logger()
+
+	return &Quota{limiter: limiter, window: window, logger: &logger}
+}
+
+func (m *Quota) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		user, ok := entities.UserFromContext(c.Request().Context())
+		if !ok || user.APIKey == nil {
+			return next(c)
+		}
+
+		key := "apikey:" + strconv.Itoa(user.APIKey.ID)
+		result, err := m.limiter.Allow(c.Request().Context(), key, user.APIKey.Quota, m.window)
+		if err != nil {
+			m.logger.Err(err).Str("key", key).Msg("failed to limiter.Allow")
+			return next(c)
+		}
+
+		return limitResponse(c, result, next)
+	}
+}
+
+// limitResponse выставляет заголовки X-RateLimit-* и пропускает запрос либо отвечает 429.
+// Если на запрос действует несколько лимитов, в заголовках остаётся тот, у которого меньше запас.
+func limitResponse(c echo.Context, result ratelimit.Result, next echo.HandlerFunc) error {
+	header := c.Response().Header()
+	remaining, err := strconv.Atoi(header.Get(HeaderRateLimitRemaining))
+	if err != nil || result.Remaining <= remaining {
+		header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+		header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+		header.Set(HeaderRateLimitReset, strconv.FormatInt(result.ResetAt.Unix(), 10))
+	}
+
+	if !result.Allowed {
+		// Округление вверх: повтор раньше начала окна снова получит 429.
+		retryAfter := (result.RetryAfter(time.Now()) + time.Second - 1) / time.Second
+		header.Set(echo.HeaderRetryAfter, strconv.FormatInt(int64(max(retryAfter, 1)), 10))
+		return c.JSON(http.StatusTooManyRequests, HTTPError{Message: "rate limit exceeded"})
+	}
+
+	return next(c)
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// RateLimit ограничивает частоту запросов одного клиента. Клиент - API ключ, пользователь или IP
+// для анонимных запросов, поэтому middleware Limit ставится после Auth.Middleware. LimitIP считает
+// запросы по IP и ставится до аутентификации.
+//
+// Лимиты с одним именем группы делят счётчик: "default" на всех группах маршрутов даёт клиенту
+// общий бюджет запросов, более строгие лимиты отдельных маршрутов считаются отдельно.
+// Если хранилище счётчиков недоступно, запрос пропускается.
+type RateLimit struct {
+	limiter RateLimiter
+	cfg     config.RateLimit
+	logger  *zerolog.Logger
+}
+
+func NewRateLimit(limiter RateLimiter, cfg config.RateLimit) *RateLimit {
+	logger := logger.DefaultLogger().With().Str(packageKey, "rate_limit").Logger()
+
+	return &RateLimit{limiter: limiter, cfg: cfg, logger: &logger}
+}
+
+// Limit middleware лимита limit запросов за окно для группы group. Лимит 0 ничего не ограничивает.
+func (m *RateLimit) Limit(group string, limit int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !m.cfg.Enabled || limit <= 0 {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			return m.allow(c, next, "rl:"+group+":"+rateLimitClient(c), 1, limit)
+		}
+	}
+}
+
+// LimitCost как Limit, но запрос расходует cost(c) единиц лимита, например по одной на элемент пакета.
+// Счётчик общий с Limit той же группы. Запрос дороже всего лимита не пройдёт ни в одном окне,
+// поэтому сразу отклоняется с 400 и не учитывается.
+func (m *RateLimit) LimitCost(group string, limit int, cost func(c echo.Context) int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !m.cfg.Enabled || limit <= 0 {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			n := max(cost(c), 1)
+			if n > limit {
+				return c.JSON(http.StatusBadRequest, HTTPError{
+					Message: fmt.Sprintf("request costs %d, rate limit is %d per %s", n, limit, m.cfg.Window),
+				})
+			}
+
+			return m.allow(c, next, "rl:"+group+":"+rateLimitClient(c), n, limit)
+		}
+	}
+}
+
+// LimitIP middleware лимита limit запросов за окно с одного IP для группы group, не зависит от
+// аутентификации. Лимит 0 ничего не ограничивает.
+func (m *RateLimit) LimitIP(group string, limit int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !m.cfg.Enabled || limit <= 0 {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			return m.allow(c, next, "rl:"+group+":ip:"+c.RealIP(), 1, limit)
+		}
+	}
+}
+
+func (m *RateLimit) allow(c echo.Context, next echo.HandlerFunc, key string, n, limit int) error {
+	result, err := m.limiter.AllowN(c.Request().Context(), key, n, limit, m.cfg.Window)
+	if err != nil {
+		m.logger.Err(err).Str("key", key).Msg("failed to limiter.AllowN")
+		return next(c)
+	}
+
+	return limitResponse(c, result, next)
+}
+
+func rateLimitClient(c echo.Context) string {
+	if user, ok := entities.UserFromContext(c.Request().Context()); ok {
+		if user.APIKey != nil {
+			return "key:" + strconv.Itoa(user.APIKey.ID)
+		}
+		return "user:" + strconv.Itoa(user.ID)
+	}
+
+	return "ip:" + c.RealIP()
+}
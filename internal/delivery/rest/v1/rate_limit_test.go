@@ -0,0 +1,85 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
+	v1 "github.com/neyrzx/youmusic/internal/delivery/rest/v1"
+	"github.com/neyrzx/youmusic/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitBatchCost(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		path           string
+		body           string
+		expectedStatus int
+	}
+
+	tests := []struct {
+		name     string
+		requests []request
+	}{
+		{
+			"case: every item counts",
+			[]request{
+				{"/batch", `{"items":[{},{}]}`, http.StatusOK},
+				{"/", `{}`, http.StatusOK},
+				{"/", `{}`, http.StatusTooManyRequests},
+			},
+		},
+		{
+			"case: batch above remaining",
+			[]request{
+				{"/", `{}`, http.StatusOK},
+				{"/batch", `{"items":[{},{},{}]}`, http.StatusTooManyRequests},
+			},
+		},
+		{
+			"case: batch above limit",
+			[]request{
+				{"/batch", `{"items":[{},{},{},{}]}`, http.StatusBadRequest},
+				{"/batch", `{"items":[{},{},{}]}`, http.StatusOK},
+			},
+		},
+		{
+			"case: malformed body counts once",
+			[]request{
+				{"/batch", `{"items":`, http.StatusOK},
+				{"/batch", `{"items":[{},{}]}`, http.StatusOK},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+			rateLimit := v1.NewRateLimit(limiter, config.RateLimit{Enabled: true, Window: time.Minute})
+
+			e := echo.New()
+			handler := func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}
+			e.POST("/", handler, rateLimit.Limit("create", 3))
+			e.POST("/batch", handler, rateLimit.LimitCost("create", 3, v1.TracksBatchCost))
+
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(http.MethodPost, r.path, strings.NewReader(r.body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+
+				assert.Equal(t, r.expectedStatus, rec.Code, "request %d", i)
+			}
+		})
+	}
+}
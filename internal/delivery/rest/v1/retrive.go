@@ -19,11 +19,24 @@ type TracksRetrieveResponse struct {
 	Lyric    []string  `json:"lyric"`
 	Link     string    `json:"link"`
 	Released time.Time `json:"released"`
+	// DurationMs длительность загруженного аудиофайла, 0 если файла нет.
+	DurationMs int64 `json:"durationMs"`
+	// CoverURL адрес обложки трека, пустой если обложки нет.
+	CoverURL string   `json:"coverURL,omitempty"`
+	Tags     []string `json:"tags"`
+	// Language язык текста (ISO 639-1), LanguageConfidence уверенность определения от 0 до 1.
+	Language           string  `json:"language,omitempty" example:"ru"`
+	LanguageConfidence float64 `json:"languageConfidence,omitempty" example:"0.98"`
+	// CreatedBy и UpdatedBy ID пользователей, отсутствуют для треков из сканера и импорта.
+	CreatedBy *int `json:"createdBy,omitempty"`
+	UpdatedBy *int `json:"updatedBy,omitempty"`
+	// IsFavorite есть ли трек в избранном, только для аутентифицированных запросов.
+	IsFavorite *bool `json:"isFavorite,omitempty"`
 }
 
 // Retrieve godoc
 // @Summary      Retrive track
-// @Description  Retriving track
+// @Description  Retriving track. Authenticated requests get isFavorite flag. Language of the lyric is omitted while not detected.
 // @Tags         Tracks
 // @Accept       json
 // @Produce			 json
@@ -56,10 +69,18 @@ func (h *TracksHandlers) Retrieve(c echo.Context) (err error) {
 	}
 
 	return c.JSON(http.StatusOK, TracksRetrieveResponse{
-		Artist:   track.Artist,
-		Track:    track.Track,
-		Lyric:    track.Lyric,
-		Link:     track.Link,
-		Released: track.Released,
+		Artist:             track.Artist,
+		Track:              track.Track,
+		Lyric:              track.Lyric,
+		Link:               track.Link,
+		Released:           track.Released,
+		DurationMs:         track.Duration.Milliseconds(),
+		CoverURL:           trackCoverURL(track),
+		Tags:               track.Tags,
+		Language:           track.Language,
+		LanguageConfidence: track.LanguageConfidence,
+		CreatedBy:          track.CreatedBy,
+		UpdatedBy:          track.UpdatedBy,
+		IsFavorite:         track.IsFavorite,
 	})
 }
@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// scrobblesMaxBodySize ограничение тела POST /scrobbles.
+const scrobblesMaxBodySize = 1 << 20
+
+type ScrobblesService interface {
+	Submit(ctx context.Context, userID int, events []entities.ScrobbleSubmit) ([]entities.ScrobbleResult, error)
+	History(ctx context.Context, filters entities.HistoryFilters) (entities.HistoryPage, error)
+}
+
+type ScrobblesHandlers struct {
+	scrobblesService ScrobblesService
+	logger           *zerolog.Logger
+}
+
+// NewScrobblesHandlers регистрирует приём прослушиваний в группе /scrobbles и историю в группе /me.
+func NewScrobblesHandlers(scrobbles *echo.Group, me *echo.Group, ss ScrobblesService) *ScrobblesHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "scrobbles").Logger()
+
+	h := &ScrobblesHandlers{
+		scrobblesService: ss,
+		logger:           &logger,
+	}
+
+	scrobbles.POST("", h.Submit, RequirePermission(entities.PermissionScrobblesCreate))
+	me.GET("/history", h.History, RequireUser)
+
+	return h
+}
+
+type ScrobbleRequest struct {
+	TrackID int    `json:"trackID" validate:"required_without=Artist,gte=0" example:"42"`
+	Artist  string `json:"artist" validate:"required_without=TrackID,max=255" example:"Muse"`
+	Track   string `json:"track" validate:"required_with=Artist,max=255" example:"Supermassive Black Hole"`
+	// PlayedAt время начала прослушивания.
+	PlayedAt         time.Time `json:"playedAt" validate:"required"`
+	DurationPlayedMs int64     `json:"durationPlayedMs" validate:"gte=0" example:"180000"`
+}
+
+type scrobbleBatchRequest struct {
+	Items []ScrobbleRequest `validate:"required,min=1,dive"`
+}
+
+type ScrobbleResultResponse struct {
+	Index   int    `json:"index"`
+	Status  string `json:"status" example:"accepted"`
+	TrackID int    `json:"trackID,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ScrobblesResponse struct {
+	Accepted int                      `json:"accepted"`
+	Results  []ScrobbleResultResponse `json:"results"`
+}
+
+type HistoryQuery struct {
+	// From и To границы полуинтервала [from, to) в RFC 3339.
+	From   string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To     string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Limit  int    `query:"limit" validate:"gte=0,lte=500"`
+	Cursor string `query:"cursor" validate:"max=64"`
+}
+
+type HistoryItemResponse struct {
+	TrackID          int       `json:"trackID"`
+	Artist           string    `json:"artist"`
+	Track            string    `json:"track"`
+	PlayedAt         time.Time `json:"playedAt"`
+	DurationPlayedMs int64     `json:"durationPlayedMs"`
+}
+
+type HistoryResponse struct {
+	Items []HistoryItemResponse `json:"items"`
+	// NextCursor передаётся в ?cursor= за следующей страницей, пустой на последней странице.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Submit godoc
+// @Summary      Submit scrobbles
+// @Description  Accepting a single play event or an array of them. The track is identified by trackID or by artist and track. A play counts if the track is longer than 30 seconds and was played for half of its length or 4 minutes. Repeats of the same track within the dedup window are reported as duplicate. API keys need the scrobbles:create scope.
+// @Tags         Scrobbles
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.ScrobbleRequest true "Play event or array of play events."
+// @Success      200  {object}  v1.ScrobblesResponse "Result for every event"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "API key has no scope"
+// @Failure      413  {object}  v1.HTTPError "Too many events"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /scrobbles [post]
+func (h *ScrobblesHandlers) Submit(c echo.Context) (err error) {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, scrobblesMaxBodySize+1))
+	if err != nil || len(body) > scrobblesMaxBodySize {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	var request scrobbleBatchRequest
+	if body = bytes.TrimSpace(body); bytes.HasPrefix(body, []byte("[")) {
+		err = json.Unmarshal(body, &request.Items)
+	} else {
+		request.Items = make([]ScrobbleRequest, 1)
+		err = json.Unmarshal(body, &request.Items[0])
+	}
+	if err != nil {
+		h.logger.Err(err).Msg("failed to json.Unmarshal")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	events := make([]entities.ScrobbleSubmit, 0, len(request.Items))
+	for _, item := range request.Items {
+		events = append(events, entities.ScrobbleSubmit{
+			TrackID:        item.TrackID,
+			Artist:         strings.TrimSpace(item.Artist),
+			Title:          strings.TrimSpace(item.Track),
+			PlayedAt:       item.PlayedAt,
+			DurationPlayed: time.Duration(item.DurationPlayedMs) * time.Millisecond,
+		})
+	}
+
+	user, _ := entities.UserFromContext(c.Request().Context())
+
+	results, err := h.scrobblesService.Submit(c.Request().Context(), user.ID, events)
+	if err != nil {
+		if errors.Is(err, domain.ErrScrobbleBatchTooLarge) {
+			return c.JSON(http.StatusRequestEntityTooLarge, HTTPError{Message: err.Error()})
+		}
+		h.logger.Err(err).Msg("failed to scrobblesService.Submit")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := ScrobblesResponse{Results: make([]ScrobbleResultResponse, 0, len(results))}
+	for _, result := range results {
+		if result.Status == entities.ScrobbleStatusAccepted {
+			response.Accepted++
+		}
+		response.Results = append(response.Results, ScrobbleResultResponse{
+			Index:   result.Index,
+			Status:  string(result.Status),
+			TrackID: result.TrackID,
+			Reason:  result.Reason,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// History godoc
+// @Summary      Listening history
+// @Description  Listing plays of the current user from newest to oldest within [from, to)
+// @Tags         Scrobbles
+// @Accept       json
+// @Produce			 json
+// @Param				 from query string false "Range start, RFC 3339."
+// @Param				 to query string false "Range end (exclusive), RFC 3339."
+// @Param				 limit query int false "Page size, 50 by default."
+// @Param				 cursor query string false "nextCursor from the previous page."
+// @Success      200  {object}  v1.HistoryResponse "Plays"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /me/history [get]
+func (h *ScrobblesHandlers) History(c echo.Context) (err error) {
+	var query HistoryQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	user, _ := entities.UserFromContext(c.Request().Context())
+	filters := entities.HistoryFilters{UserID: user.ID, Limit: query.Limit}
+
+	// Формат уже проверен валидатором.
+	if query.From != "" {
+		filters.From, _ = time.Parse(time.RFC3339, query.From)
+	}
+	if query.To != "" {
+		filters.To, _ = time.Parse(time.RFC3339, query.To)
+	}
+	if query.Cursor != "" {
+		if filters.After, err = decodeHistoryCursor(query.Cursor); err != nil {
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: domain.ErrScrobbleHistoryInvalid.Error()})
+		}
+	}
+
+	page, err := h.scrobblesService.History(c.Request().Context(), filters)
+	if err != nil {
+		if errors.Is(err, domain.ErrScrobbleHistoryInvalid) {
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+		}
+		h.logger.Err(err).Msg("failed to scrobblesService.History")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := HistoryResponse{Items: make([]HistoryItemResponse, 0, len(page.Scrobbles))}
+	for _, scrobble := range page.Scrobbles {
+		response.Items = append(response.Items, HistoryItemResponse{
+			TrackID:          scrobble.TrackID,
+			Artist:           scrobble.Artist,
+			Track:            scrobble.Title,
+			PlayedAt:         scrobble.PlayedAt,
+			DurationPlayedMs: scrobble.DurationPlayed.Milliseconds(),
+		})
+	}
+	if page.Next != nil {
+		response.NextCursor = encodeHistoryCursor(*page.Next)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// encodeHistoryCursor непрозрачный для клиента курсор "<played_at unix nano>:<scrobble id>".
+func encodeHistoryCursor(cursor entities.HistoryCursor) string {
+	raw := strconv.FormatInt(cursor.PlayedAt.UnixNano(), 10) + ":" + strconv.FormatInt(cursor.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeHistoryCursor(encoded string) (*entities.HistoryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to base64.DecodeString: %w", err)
+	}
+
+	playedAt, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, errors.New("cursor has no separator")
+	}
+
+	nanos, err := strconv.ParseInt(playedAt, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to strconv.ParseInt: %w", err)
+	}
+	cursor := entities.HistoryCursor{PlayedAt: time.Unix(0, nanos).UTC()}
+	if cursor.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, fmt.Errorf("failed to strconv.ParseInt: %w", err)
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type SimilarTracksService interface {
+	Similar(ctx context.Context, filters entities.SimilarTracksFilters) ([]entities.SimilarTrack, error)
+}
+
+type SimilarTracksHandlers struct {
+	similarService SimilarTracksService
+	logger         *zerolog.Logger
+}
+
+// NewSimilarTracksHandlers регистрирует поиск похожих треков в группе /tracks.
+func NewSimilarTracksHandlers(g *echo.Group, sts SimilarTracksService) *SimilarTracksHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "similar").Logger()
+
+	h := &SimilarTracksHandlers{
+		similarService: sts,
+		logger:         &logger,
+	}
+
+	g.GET("/:id/similar", h.Similar)
+
+	return h
+}
+
+type SimilarTracksQuery struct {
+	ID        int     `param:"id" validate:"required,gt=0"`
+	Limit     int     `query:"limit" validate:"gte=0,lte=100"`
+	Diversity float64 `query:"diversity" validate:"gte=0,lte=1"`
+}
+
+type SimilarTrackResponse struct {
+	TrackID    int     `json:"trackID"`
+	Artist     string  `json:"artist"`
+	Track      string  `json:"track"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Similar godoc
+// @Summary      Similar tracks
+// @Description  Tracks with similar lyrics (MinHash estimate of Jaccard similarity of word pairs). Empty for tracks without lyrics.
+// @Tags         Tracks
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "track id"
+// @Param				 limit query int false "Limit result, 10 by default."
+// @Param				 diversity query number false "0..1, how much each next track of the same artist is demoted. 0 by default."
+// @Success      200  {array}   v1.SimilarTrackResponse "Similar tracks, most similar first"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Track not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /tracks/{id}/similar [get]
+func (h *SimilarTracksHandlers) Similar(c echo.Context) (err error) {
+	var query SimilarTracksQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id or query is invalid"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	tracks, err := h.similarService.Similar(c.Request().Context(), entities.SimilarTracksFilters{
+		TrackID:   query.ID,
+		Limit:     query.Limit,
+		Diversity: query.Diversity,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrTrackNotFound) {
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrTrackNotFound.Error()})
+		}
+		h.logger.Err(err).Int("trackID", query.ID).Msg("failed to similarService.Similar")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]SimilarTrackResponse, 0, len(tracks))
+	for _, track := range tracks {
+		response = append(response, SimilarTrackResponse{
+			TrackID:    track.TrackID,
+			Artist:     track.Artist,
+			Track:      track.Title,
+			Similarity: track.Similarity,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
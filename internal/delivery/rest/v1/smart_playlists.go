@@ -0,0 +1,405 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type SmartPlaylistsService interface {
+	Create(ctx context.Context, playlist entities.SmartPlaylistCreate) (int, error)
+	GetList(ctx context.Context, limit int, offset int) ([]entities.SmartPlaylist, error)
+	GetByID(ctx context.Context, id int) (entities.SmartPlaylist, error)
+	Update(ctx context.Context, playlist entities.SmartPlaylistUpdate) error
+	Delete(ctx context.Context, id int) error
+	Tracks(ctx context.Context, id int) ([]entities.Track, error)
+	Preview(ctx context.Context, query entities.SmartPlaylistQuery) ([]entities.Track, error)
+}
+
+type SmartPlaylistsHandlers struct {
+	smartPlaylistsService SmartPlaylistsService
+	logger                *zerolog.Logger
+}
+
+func NewSmartPlaylistsHandlers(g *echo.Group, sps SmartPlaylistsService) *SmartPlaylistsHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "smart_playlists").Logger()
+
+	h := &SmartPlaylistsHandlers{
+		smartPlaylistsService: sps,
+		logger:                &logger,
+	}
+
+	edit := RequirePermission(entities.PermissionPlaylistsEdit)
+
+	g.POST("/", h.Create, edit)
+	g.GET("/", h.List)
+	g.POST("/preview", h.Preview)
+	g.GET("/:id/", h.Retrieve)
+	g.PUT("/:id/", h.Update, edit)
+	g.DELETE("/:id/", h.Delete, edit)
+	g.GET("/:id/tracks/", h.Tracks)
+
+	return h
+}
+
+type SmartPlaylistPathParam struct {
+	ID int `json:"-" param:"id" validate:"required,gt=0"`
+}
+
+// SmartPlaylistQueryRequest правила отбора, порядок и лимит.
+//
+// Rules - дерево правил: группа {"and": [...]} или {"or": [...]}, либо условие
+// {"field": "artist|title", "op": "eq|contains", "value": "..."}, {"field": "year", "from": 2000, "to": 2010},
+// {"field": "tag", "value": "..."}, {"field": "lyric", "value": "..."}.
+type SmartPlaylistQueryRequest struct {
+	Rules json.RawMessage `json:"rules" validate:"required" swaggertype:"object"`
+	// Sort id, title, artist, released, с префиксом "-" по убыванию.
+	Sort  string `json:"sort" example:"-released"`
+	Limit int    `json:"limit" example:"100"`
+}
+
+type SmartPlaylistCreateRequest struct {
+	Name string `json:"name" validate:"required,max=255" example:"Muse 2000-2010"`
+	SmartPlaylistQueryRequest
+}
+
+type SmartPlaylistUpdateRequest struct {
+	SmartPlaylistPathParam
+	SmartPlaylistCreateRequest
+}
+
+type SmartPlaylistCreateResponse struct {
+	SmartPlaylistID int `json:"smartPlaylistID"`
+}
+
+type SmartPlaylistResponse struct {
+	SmartPlaylistID int                `json:"smartPlaylistID"`
+	Name            string             `json:"name"`
+	Rules           entities.TrackRule `json:"rules"`
+	Sort            string             `json:"sort"`
+	Limit           int                `json:"limit"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	UpdatedAt       time.Time          `json:"updatedAt"`
+}
+
+// Create godoc
+// @Summary      Create smart playlist
+// @Description  Creating smart playlist from rules. Its tracks are evaluated on every read. Requires role: editor, moderator, admin.
+// @Tags         SmartPlaylists
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.SmartPlaylistCreateRequest true "Name, rules, sort and limit."
+// @Param				 Idempotency-Key header string false "Repeated request with the same key and body returns the stored response."
+// @Success      201  {object}  v1.SmartPlaylistCreateResponse "Created smart playlist"
+// @Failure      400  {object}  v1.HTTPError "Bad request or invalid rules"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /smart-playlists/ [post]
+func (h *SmartPlaylistsHandlers) Create(c echo.Context) (err error) {
+	var request SmartPlaylistCreateRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	query, err := request.SmartPlaylistQueryRequest.query()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	id, err := h.smartPlaylistsService.Create(c.Request().Context(), entities.SmartPlaylistCreate{
+		Name:               request.Name,
+		SmartPlaylistQuery: query,
+	})
+	if err != nil {
+		return h.smartPlaylistError(c, err, "failed to smartPlaylistsService.Create")
+	}
+
+	return c.JSON(http.StatusCreated, SmartPlaylistCreateResponse{SmartPlaylistID: id})
+}
+
+// List godoc
+// @Summary      List of smart playlists
+// @Description  List of smart playlists with their rules
+// @Tags         SmartPlaylists
+// @Accept       json
+// @Produce			 json
+// @Param				 limit query int false "Limit result."
+// @Param				 offset query int false "Offset result."
+// @Success      200  {array}  v1.SmartPlaylistResponse "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /smart-playlists/ [get]
+func (h *SmartPlaylistsHandlers) List(c echo.Context) (err error) {
+	var query PlaylistsListQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	playlists, err := h.smartPlaylistsService.GetList(c.Request().Context(), query.Limit, query.Offset)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to smartPlaylistsService.GetList")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	res := []SmartPlaylistResponse{}
+	for _, playlist := range playlists {
+		res = append(res, smartPlaylistResponse(playlist))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// Retrieve godoc
+// @Summary      Retrieve smart playlist
+// @Description  Retrieving smart playlist rules
+// @Tags         SmartPlaylists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "smart playlist id"
+// @Success      200  {object}  v1.SmartPlaylistResponse "Smart playlist"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Smart playlist not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /smart-playlists/{id}/ [get]
+func (h *SmartPlaylistsHandlers) Retrieve(c echo.Context) (err error) {
+	var pathParam SmartPlaylistPathParam
+
+	if err = c.Bind(&pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	playlist, err := h.smartPlaylistsService.GetByID(c.Request().Context(), pathParam.ID)
+	if err != nil {
+		return h.smartPlaylistError(c, err, "failed to smartPlaylistsService.GetByID")
+	}
+
+	return c.JSON(http.StatusOK, smartPlaylistResponse(playlist))
+}
+
+// Update godoc
+// @Summary      Update smart playlist
+// @Description  Replacing smart playlist name, rules, sort and limit. Requires role: editor, moderator, admin.
+// @Tags         SmartPlaylists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "smart playlist id"
+// @Param				 input body v1.SmartPlaylistCreateRequest true "Name, rules, sort and limit."
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request or invalid rules"
+// @Failure      404  {object}  v1.HTTPError "Smart playlist not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /smart-playlists/{id}/ [put]
+func (h *SmartPlaylistsHandlers) Update(c echo.Context) (err error) {
+	var request SmartPlaylistUpdateRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	query, err := request.SmartPlaylistQueryRequest.query()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	if err = h.smartPlaylistsService.Update(c.Request().Context(), entities.SmartPlaylistUpdate{
+		SmartPlaylistID:    request.ID,
+		Name:               request.Name,
+		SmartPlaylistQuery: query,
+	}); err != nil {
+		return h.smartPlaylistError(c, err, "failed to smartPlaylistsService.Update")
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+// Delete godoc
+// @Summary      Delete smart playlist
+// @Description  Deleting smart playlist. Requires role: editor, moderator, admin.
+// @Tags         SmartPlaylists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "smart playlist id"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Smart playlist not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /smart-playlists/{id}/ [delete]
+func (h *SmartPlaylistsHandlers) Delete(c echo.Context) (err error) {
+	var pathParam SmartPlaylistPathParam
+
+	if err = c.Bind(&pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.smartPlaylistsService.Delete(c.Request().Context(), pathParam.ID); err != nil {
+		return h.smartPlaylistError(c, err, "failed to smartPlaylistsService.Delete")
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
+
+// Tracks godoc
+// @Summary      Smart playlist tracks
+// @Description  Evaluating smart playlist rules against the current library
+// @Tags         SmartPlaylists
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "smart playlist id"
+// @Success      200  {array}  v1.TracksResponse "Tracks in playlist order"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Smart playlist not found"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /smart-playlists/{id}/tracks/ [get]
+func (h *SmartPlaylistsHandlers) Tracks(c echo.Context) (err error) {
+	var pathParam SmartPlaylistPathParam
+
+	if err = c.Bind(&pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "id param is invalid"})
+	}
+
+	if err = c.Validate(pathParam); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	tracks, err := h.smartPlaylistsService.Tracks(c.Request().Context(), pathParam.ID)
+	if err != nil {
+		return h.smartPlaylistError(c, err, "failed to smartPlaylistsService.Tracks")
+	}
+
+	return c.JSON(http.StatusOK, tracksResponse(tracks))
+}
+
+// Preview godoc
+// @Summary      Preview smart playlist
+// @Description  Validating rules and evaluating them without saving the playlist
+// @Tags         SmartPlaylists
+// @Accept       json
+// @Produce			 json
+// @Param				 input body v1.SmartPlaylistQueryRequest true "Rules, sort and limit."
+// @Success      200  {array}  v1.TracksResponse "Tracks in playlist order"
+// @Failure      400  {object}  v1.HTTPError "Bad request or invalid rules"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /smart-playlists/preview [post]
+func (h *SmartPlaylistsHandlers) Preview(c echo.Context) (err error) {
+	var request SmartPlaylistQueryRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	query, err := request.query()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	tracks, err := h.smartPlaylistsService.Preview(c.Request().Context(), query)
+	if err != nil {
+		return h.smartPlaylistError(c, err, "failed to smartPlaylistsService.Preview")
+	}
+
+	return c.JSON(http.StatusOK, tracksResponse(tracks))
+}
+
+// query разбирает правила строго: неизвестные поля в правилах - ошибка, а не молча пропущенное условие.
+func (r SmartPlaylistQueryRequest) query() (entities.SmartPlaylistQuery, error) {
+	decoder := json.NewDecoder(bytes.NewReader(r.Rules))
+	decoder.DisallowUnknownFields()
+
+	var rule entities.TrackRule
+	if err := decoder.Decode(&rule); err != nil {
+		return entities.SmartPlaylistQuery{}, fmt.Errorf("%w: %w", domain.ErrSmartPlaylistInvalidRules, err)
+	}
+
+	return entities.SmartPlaylistQuery{
+		Rule:  rule,
+		Sort:  entities.TrackSort(r.Sort),
+		Limit: r.Limit,
+	}, nil
+}
+
+// smartPlaylistError переводит ошибки сервиса смарт-плейлистов в ответ, неизвестные ошибки логируются.
+func (h *SmartPlaylistsHandlers) smartPlaylistError(c echo.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrSmartPlaylistNotFound):
+		return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrSmartPlaylistNotFound.Error()})
+	case errors.Is(err, domain.ErrSmartPlaylistInvalidRules),
+		errors.Is(err, domain.ErrSmartPlaylistInvalidSort),
+		errors.Is(err, domain.ErrSmartPlaylistInvalidLimit):
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	h.logger.Err(err).Msg(msg)
+	return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+}
+
+func smartPlaylistResponse(playlist entities.SmartPlaylist) SmartPlaylistResponse {
+	return SmartPlaylistResponse{
+		SmartPlaylistID: playlist.ID,
+		Name:            playlist.Name,
+		Rules:           playlist.Rule,
+		Sort:            string(playlist.Sort),
+		Limit:           playlist.Limit,
+		CreatedAt:       playlist.CreatedAt,
+		UpdatedAt:       playlist.UpdatedAt,
+	}
+}
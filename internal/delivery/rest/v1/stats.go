@@ -0,0 +1,339 @@
+package v1
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const statsFormatCSV = "csv"
+
+type StatsService interface {
+	TracksPerYear(ctx context.Context) ([]entities.YearStat, error)
+	TopArtists(ctx context.Context, limit int) ([]entities.ArtistStat, error)
+	TopTracks(ctx context.Context, days int, limit int) ([]entities.TrackPlaysStat, error)
+	Lyrics(ctx context.Context) (entities.LyricsStat, error)
+	Newest(ctx context.Context, limit int) ([]entities.NewestTrack, error)
+}
+
+type StatsHandlers struct {
+	statsService StatsService
+	logger       *zerolog.Logger
+}
+
+func NewStatsHandlers(g *echo.Group, ss StatsService) *StatsHandlers {
+	logger := logger.DefaultLogger().With().Str(packageKey, "stats").Logger()
+
+	h := &StatsHandlers{
+		statsService: ss,
+		logger:       &logger,
+	}
+
+	g.GET("/years", h.Years)
+	g.GET("/artists", h.Artists)
+	g.GET("/tracks/top", h.TopTracks)
+	g.GET("/lyrics", h.Lyrics)
+	g.GET("/newest", h.Newest)
+
+	return h
+}
+
+type StatsQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=json csv"`
+}
+
+type StatsLimitQuery struct {
+	StatsQuery
+
+	Limit int `query:"limit" validate:"gte=0,lte=500"`
+}
+
+type StatsTopTracksQuery struct {
+	StatsLimitQuery
+
+	Days int `query:"days" validate:"gte=0,lte=365"`
+}
+
+type YearStatResponse struct {
+	Year   int `json:"year"`
+	Tracks int `json:"tracks"`
+}
+
+type ArtistStatResponse struct {
+	ArtistID int    `json:"artistID"`
+	Artist   string `json:"artist"`
+	Tracks   int    `json:"tracks"`
+}
+
+type TrackPlaysStatResponse struct {
+	TrackID int    `json:"trackID"`
+	Artist  string `json:"artist"`
+	Track   string `json:"track"`
+	Plays   int    `json:"plays"`
+}
+
+type LyricsStatResponse struct {
+	Tracks            int     `json:"tracks"`
+	TracksWithLyrics  int     `json:"tracksWithLyrics"`
+	Verses            int     `json:"verses"`
+	AvgVersesPerTrack float64 `json:"avgVersesPerTrack"`
+}
+
+type NewestTrackResponse struct {
+	TrackID  int       `json:"trackID"`
+	Artist   string    `json:"artist"`
+	Track    string    `json:"track"`
+	Released time.Time `json:"released"`
+	AddedAt  time.Time `json:"addedAt"`
+}
+
+// Years godoc
+// @Summary      Tracks per release year
+// @Description  Number of tracks per release year. Data is refreshed in background and may lag behind the catalog.
+// @Tags         Stats
+// @Produce			 json
+// @Produce			 text/csv
+// @Param				 format query string false "Output format." Enums(json, csv) default(json)
+// @Success      200  {array}   v1.YearStatResponse "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /stats/years [get]
+func (h *StatsHandlers) Years(c echo.Context) (err error) {
+	var query StatsQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	stats, err := h.statsService.TracksPerYear(c.Request().Context())
+	if err != nil {
+		h.logger.Err(err).Msg("failed to statsService.TracksPerYear")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]YearStatResponse, 0, len(stats))
+	rows := [][]string{{"year", "tracks"}}
+	for _, stat := range stats {
+		response = append(response, YearStatResponse{Year: stat.Year, Tracks: stat.Tracks})
+		rows = append(rows, []string{strconv.Itoa(stat.Year), strconv.Itoa(stat.Tracks)})
+	}
+
+	return writeStats(c, query.Format, "years", response, rows)
+}
+
+// Artists godoc
+// @Summary      Top artists
+// @Description  Artists with the most tracks. Data is refreshed in background and may lag behind the catalog.
+// @Tags         Stats
+// @Produce			 json
+// @Produce			 text/csv
+// @Param				 format query string false "Output format." Enums(json, csv) default(json)
+// @Param				 limit query int false "Limit result, 10 by default."
+// @Success      200  {array}   v1.ArtistStatResponse "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /stats/artists [get]
+func (h *StatsHandlers) Artists(c echo.Context) (err error) {
+	var query StatsLimitQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	stats, err := h.statsService.TopArtists(c.Request().Context(), query.Limit)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to statsService.TopArtists")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]ArtistStatResponse, 0, len(stats))
+	rows := [][]string{{"artistID", "artist", "tracks"}}
+	for _, stat := range stats {
+		response = append(response, ArtistStatResponse{ArtistID: stat.ArtistID, Artist: stat.Artist, Tracks: stat.Tracks})
+		rows = append(rows, []string{strconv.Itoa(stat.ArtistID), stat.Artist, strconv.Itoa(stat.Tracks)})
+	}
+
+	return writeStats(c, query.Format, "artists", response, rows)
+}
+
+// TopTracks godoc
+// @Summary      Most played tracks
+// @Description  Tracks with the most scrobbles over the last days. Data is refreshed in background and may lag behind.
+// @Tags         Stats
+// @Produce			 json
+// @Produce			 text/csv
+// @Param				 format query string false "Output format." Enums(json, csv) default(json)
+// @Param				 days query int false "Window in days including today, 30 by default."
+// @Param				 limit query int false "Limit result, 10 by default."
+// @Success      200  {array}   v1.TrackPlaysStatResponse "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /stats/tracks/top [get]
+func (h *StatsHandlers) TopTracks(c echo.Context) (err error) {
+	var query StatsTopTracksQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	stats, err := h.statsService.TopTracks(c.Request().Context(), query.Days, query.Limit)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to statsService.TopTracks")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]TrackPlaysStatResponse, 0, len(stats))
+	rows := [][]string{{"trackID", "artist", "track", "plays"}}
+	for _, stat := range stats {
+		response = append(response, TrackPlaysStatResponse{TrackID: stat.TrackID, Artist: stat.Artist, Track: stat.Title, Plays: stat.Plays})
+		rows = append(rows, []string{strconv.Itoa(stat.TrackID), stat.Artist, stat.Title, strconv.Itoa(stat.Plays)})
+	}
+
+	return writeStats(c, query.Format, "top-tracks", response, rows)
+}
+
+// Lyrics godoc
+// @Summary      Lyrics stats
+// @Description  Number of tracks, tracks with lyrics and average verses per track (tracks without lyrics count as zero).
+// @Tags         Stats
+// @Produce			 json
+// @Produce			 text/csv
+// @Param				 format query string false "Output format." Enums(json, csv) default(json)
+// @Success      200  {object}  v1.LyricsStatResponse "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /stats/lyrics [get]
+func (h *StatsHandlers) Lyrics(c echo.Context) (err error) {
+	var query StatsQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	stat, err := h.statsService.Lyrics(c.Request().Context())
+	if err != nil {
+		h.logger.Err(err).Msg("failed to statsService.Lyrics")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := LyricsStatResponse{
+		Tracks:            stat.Tracks,
+		TracksWithLyrics:  stat.TracksWithLyrics,
+		Verses:            stat.Verses,
+		AvgVersesPerTrack: stat.AvgVersesPerTrack(),
+	}
+	rows := [][]string{
+		{"tracks", "tracksWithLyrics", "verses", "avgVersesPerTrack"},
+		{
+			strconv.Itoa(response.Tracks),
+			strconv.Itoa(response.TracksWithLyrics),
+			strconv.Itoa(response.Verses),
+			strconv.FormatFloat(response.AvgVersesPerTrack, 'f', 2, 64),
+		},
+	}
+
+	return writeStats(c, query.Format, "lyrics", response, rows)
+}
+
+// Newest godoc
+// @Summary      Newest additions
+// @Description  Tracks recently added to the catalog. Data is refreshed in background and may lag behind the catalog.
+// @Tags         Stats
+// @Produce			 json
+// @Produce			 text/csv
+// @Param				 format query string false "Output format." Enums(json, csv) default(json)
+// @Param				 limit query int false "Limit result, 10 by default."
+// @Success      200  {array}   v1.NewestTrackResponse "Success response"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Router       /stats/newest [get]
+func (h *StatsHandlers) Newest(c echo.Context) (err error) {
+	var query StatsLimitQuery
+
+	if err = c.Bind(&query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "query malformed"})
+	}
+
+	if err = c.Validate(query); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	tracks, err := h.statsService.Newest(c.Request().Context(), query.Limit)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to statsService.Newest")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	response := make([]NewestTrackResponse, 0, len(tracks))
+	rows := [][]string{{"trackID", "artist", "track", "released", "addedAt"}}
+	for _, track := range tracks {
+		response = append(response, NewestTrackResponse{
+			TrackID:  track.TrackID,
+			Artist:   track.Artist,
+			Track:    track.Title,
+			Released: track.Released,
+			AddedAt:  track.AddedAt,
+		})
+		rows = append(rows, []string{
+			strconv.Itoa(track.TrackID),
+			track.Artist,
+			track.Title,
+			track.Released.Format(time.DateOnly),
+			track.AddedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return writeStats(c, query.Format, "newest", response, rows)
+}
+
+// writeStats отдаёт response в JSON или rows (первая строка заголовок) в CSV.
+func writeStats(c echo.Context, format string, name string, response any, rows [][]string) error {
+	if format != statsFormatCSV {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+name+`.csv"`)
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return nil
+}
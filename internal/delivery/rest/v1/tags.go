@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+)
+
+type TrackTagsRequest struct {
+	ID   int      `json:"-" param:"id" validate:"required,gt=0"`
+	Tags []string `json:"tags" validate:"required" example:"rock,alternative"`
+}
+
+// SetTags godoc
+// @Summary      Set track tags
+// @Description  Replacing track tags. Tags are stored lower-cased, duplicates are dropped. Empty list removes all tags. Requires role: editor, moderator, admin.
+// @Tags         Tracks
+// @Accept       json
+// @Produce			 json
+// @Param				 id path int true "track id"
+// @Param				 input body v1.TrackTagsRequest true "Tags"
+// @Success      204  {string}  string "OK"
+// @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      404  {object}  v1.HTTPError "Track not found"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
+// @Router       /tracks/{id}/tags [put]
+func (h *TracksHandlers) SetTags(c echo.Context) (err error) {
+	var request TrackTagsRequest
+
+	if err = c.Bind(&request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Bind")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	if err = c.Validate(request); err != nil {
+		h.logger.Err(err).Msg("failed to c.Validate")
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err = h.trackService.SetTags(c.Request().Context(), request.ID, request.Tags); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTrackNotFound):
+			return c.JSON(http.StatusNotFound, HTTPError{Message: domain.ErrTrackNotFound.Error()})
+		case errors.Is(err, domain.ErrTrackTagsInvalid):
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+		}
+		h.logger.Err(err).Msg("failed to trackService.SetTags")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
+	}
+
+	return c.JSON(http.StatusNoContent, "OK")
+}
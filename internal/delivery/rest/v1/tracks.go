@@ -16,11 +16,14 @@ const (
 
 type TracksService interface {
 	Create(ctx context.Context, track entities.TrackCreate) error
+	CreateBatch(ctx context.Context, tracks []entities.TrackCreate) ([]entities.TrackBatchResult, error)
 	GetByID(ctx context.Context, ID int) (entities.Track, error)
 	GetList(ctx context.Context, filters entities.TrackGetListFilters) ([]entities.Track, error)
 	Update(ctx context.Context, track entities.TrackUpdate) error
 	Delete(ctx context.Context, trackID int) error
 	GetLyric(ctx context.Context, trackID int, offset int) (entities.TrackVerse, error)
+	SetTags(ctx context.Context, trackID int, tags []string) error
+	Export(ctx context.Context, filters entities.TrackGetListFilters, fn func(track entities.Track) error) error
 }
 
 type TracksHandlers struct {
@@ -28,7 +31,9 @@ type TracksHandlers struct {
 	logger       *zerolog.Logger
 }
 
-func NewTracksHandlers(g *echo.Group, ts TracksService) *TracksHandlers {
+// NewTracksHandlers регистрирует маршруты треков. createLimit ставится на создание трека,
+// batchLimit на пакет и учитывает каждый его трек: все они обращаются к music-info.
+func NewTracksHandlers(g *echo.Group, ts TracksService, createLimit, batchLimit echo.MiddlewareFunc) *TracksHandlers {
 	logger := logger.DefaultLogger().With().Str(packageKey, packageName).Logger()
 
 	h := &TracksHandlers{
@@ -36,12 +41,14 @@ func NewTracksHandlers(g *echo.Group, ts TracksService) *TracksHandlers {
 		logger:       &logger,
 	}
 
-	g.POST("/", h.Create)
+	g.POST("/", h.Create, RequirePermission(entities.PermissionTracksCreate), createLimit)
+	g.POST("/batch", h.CreateBatch, RequirePermission(entities.PermissionTracksCreate), batchLimit)
 	g.GET("/", h.List)
 	g.GET("/:id/", h.Retrieve)
-	g.PATCH("/:id/", h.Update)
-	g.DELETE("/:id/", h.Delete)
+	g.PATCH("/:id/", h.Update, RequirePermission(entities.PermissionTracksUpdate))
+	g.DELETE("/:id/", h.Delete, RequirePermission(entities.PermissionTracksDelete))
 	g.GET("/:id/lyric/", h.LyricRetrieve)
+	g.PUT("/:id/tags", h.SetTags, RequirePermission(entities.PermissionTracksUpdate))
 
 	return h
 }
@@ -1,11 +1,13 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
 	"github.com/neyrzx/youmusic/pkg/utils"
 )
 
@@ -20,7 +22,7 @@ type TrackUpdateRequest struct {
 
 // Update godoc
 // @Summary      Update the tracks
-// @Description  Updating the track
+// @Description  Updating the track. Requires role: editor, moderator, admin.
 // @Tags         Tracks
 // @Accept       json
 // @Produce			 json
@@ -28,7 +30,11 @@ type TrackUpdateRequest struct {
 // @Param				 input body v1.TrackUpdateRequest true "track id"
 // @Success      204  {string}  string "OK"
 // @Failure      400  {object}  v1.HTTPError "Bad request"
+// @Failure      401  {object}  v1.HTTPError "Authentication required"
+// @Failure      403  {object}  v1.HTTPError "Role has no permission"
+// @Failure      409  {object}  v1.HTTPError "Artist or track with the same name already exists"
 // @Failure      500  {object}  v1.HTTPError "Internal server error"
+// @Security     BearerAuth
 // @Router       /tracks/{id}/ [patch]
 func (h *TracksHandlers) Update(c echo.Context) (err error) {
 	var request TrackUpdateRequest
@@ -53,6 +59,9 @@ func (h *TracksHandlers) Update(c echo.Context) (err error) {
 	})
 	if err != nil {
 		h.logger.Err(err).Msg("failed to trackService.Update")
+		if errors.Is(err, domain.ErrTrackAlreadyExists) || errors.Is(err, domain.ErrArtistAlreadyExists) {
+			return c.JSON(http.StatusConflict, HTTPError{Message: err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "something went wrong"})
 	}
 
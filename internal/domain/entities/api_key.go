@@ -0,0 +1,43 @@
+package entities
+
+import "time"
+
+// APIKey ключ машинного клиента. Действует от имени владельца, но только в пределах Scopes.
+type APIKey struct {
+	ID     int
+	UserID int
+	Name   string
+	// Prefix начало ключа, по нему владелец отличает ключи в списке. Сам ключ не хранится.
+	Prefix string
+	Scopes []Permission
+	// Quota сколько запросов разрешено ключу за окно квоты.
+	Quota      int
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (k APIKey) HasScope(permission Permission) bool {
+	for _, scope := range k.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+type APIKeyCreate struct {
+	Name   string
+	Scopes []Permission
+	// Quota 0 - квота по умолчанию из конфигурации.
+	Quota     int
+	ExpiresAt *time.Time
+}
+
+// APIKeyCreated созданный ключ. Key показывается только один раз.
+type APIKeyCreated struct {
+	APIKey
+	Key string
+}
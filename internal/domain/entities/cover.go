@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+type CoverOwnerKind string
+
+const (
+	CoverOwnerTrack CoverOwnerKind = "track"
+	CoverOwnerAlbum CoverOwnerKind = "album"
+)
+
+// CoverOwner трек или альбом, которому принадлежит обложка.
+type CoverOwner struct {
+	Kind CoverOwnerKind
+	ID   int
+}
+
+type CoverUpload struct {
+	Owner CoverOwner
+	Data  []byte
+}
+
+// CoverRequest запрос обложки: Size 0 - исходный размер, Accept - допустимые MIME типы в порядке
+// предпочтения (пустой список - любой).
+type CoverRequest struct {
+	Owner  CoverOwner
+	Size   int
+	Accept []string
+}
+
+// CoverImage метаданные отдаваемого изображения (оригинала или превью).
+type CoverImage struct {
+	ContentType string
+	ETag        string
+	Width       int
+	Height      int
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	DuplicatesDefaultLimit = 50
+	DuplicatesMaxLimit     = 500
+	// DuplicatesMaxMerge сколько треков можно слить за раз вместе с целевым.
+	DuplicatesMaxMerge = 50
+)
+
+var (
+	// titleBracketSuffix скобки в конце названия: "Song (Remastered 2011)", "Song [Live]".
+	titleBracketSuffix = regexp.MustCompile(`\s*[(\[{][^()\[\]{}]*[)\]}]\s*$`)
+	// titleVersionSuffix суффикс через тире с указанием версии: "Song - Live", "Song - 2011 Remaster".
+	titleVersionSuffix = regexp.MustCompile(
+		`(?i)\s+[-–—]\s+[^-–—]*\b(live|remaster(ed)?|remix(ed)?|mix|edit|version|acoustic|demo|mono|stereo|instrumental|unplugged|bonus|mtv|session)\b[^-–—]*$`,
+	)
+)
+
+// DuplicateTrack трек-кандидат в дубликаты.
+type DuplicateTrack struct {
+	TrackID  int
+	ArtistID int
+	Artist   string
+	Title    string
+	// Similarity сходство текста с каноническим треком кластера, nil если тексты не сравнивались
+	// или у одного из треков нет текста.
+	Similarity *float64
+}
+
+// DuplicateCluster треки одного исполнителя с одинаковым ключом названия (DuplicateTitleKey).
+type DuplicateCluster struct {
+	Key      string
+	ArtistID int
+	Artist   string
+	// CanonicalTrackID трек, в который предлагается слить остальные: без суффикса версии, самый старый.
+	CanonicalTrackID int
+	Tracks           []DuplicateTrack
+	// Confidence от 0 до 1, насколько вероятно, что все треки кластера - один и тот же трек.
+	Confidence float64
+}
+
+type DuplicateFilters struct {
+	// Lyrics сравнивать тексты треков, а не только названия.
+	Lyrics        bool
+	MinConfidence float64
+	Limit         int
+	Offset        int
+}
+
+// DuplicateMerge слияние треков TrackIDs в TargetTrackID.
+type DuplicateMerge struct {
+	TargetTrackID int
+	TrackIDs      []int
+}
+
+// FoldTitle приводит название к виду для сравнения: NFKC, свёртка регистра, схлопнутые пробелы.
+func FoldTitle(title string) string {
+	return strings.Join(strings.Fields(cases.Fold().String(norm.NFKC.String(title))), " ")
+}
+
+// DuplicateTitleKey ключ названия для поиска дубликатов: FoldTitle без суффиксов в скобках
+// и суффиксов версии через тире. Если после удаления суффиксов ничего не осталось, ключ - FoldTitle.
+func DuplicateTitleKey(title string) string {
+	key := norm.NFKC.String(title)
+	for {
+		stripped := titleVersionSuffix.ReplaceAllString(titleBracketSuffix.ReplaceAllString(key, ""), "")
+		if stripped == key {
+			break
+		}
+		key = stripped
+	}
+
+	if key = FoldTitle(key); key == "" {
+		return FoldTitle(title)
+	}
+
+	return key
+}
@@ -0,0 +1,36 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateTitleKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		title    string
+		expected string
+	}{
+		{"case: plain", "Song", "song"},
+		{"case: parenthetical suffix", "Song (Remastered)", "song"},
+		{"case: several suffixes", "Song - 2011 Remaster [Live]", "song"},
+		{"case: dash live", "Song - Live", "song"},
+		{"case: dash not a version", "Song - Part Two", "song - part two"},
+		{"case: parentheses in the middle", "(I Can't Get No) Satisfaction", "(i can't get no) satisfaction"},
+		{"case: only parentheses", "(Intro)", "(intro)"},
+		{"case: fullwidth and spaces", "Ｓｏｎｇ   （Live）", "song"},
+		{"case: cyrillic", "Группа крови (Live)", "группа крови"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, entities.DuplicateTitleKey(tt.title))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// TrackSortFavorited порядок избранного по времени добавления. Доступен только для избранного.
+const TrackSortFavorited TrackSort = "favorited"
+
+const (
+	FavoritesDefaultLimit = 50
+	FavoritesMaxLimit     = 500
+)
+
+// Favorite трек в избранном пользователя.
+type Favorite struct {
+	Track       Track
+	FavoritedAt time.Time
+}
+
+type FavoriteGetListFilters struct {
+	UserID int
+	Limit  int
+	Offset int
+	// Sort порядок: favorited (по умолчанию -favorited, новые первыми), title, artist или released.
+	Sort TrackSort
+}
+
+// ValidFavoriteSort пустой порядок означает сортировку по времени добавления, новые первыми.
+func ValidFavoriteSort(sort TrackSort) bool {
+	if field, _ := sort.Field(); field == TrackSortFavorited {
+		return true
+	}
+
+	return sort.Valid()
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName приводит название трека или имя исполнителя к виду, в котором оно хранится:
+// NFC, без пробелов по краям, пробельные символы внутри схлопнуты в один пробел.
+//
+// Уникальность в базе проверяется по name_key (миграция 000015), который дополнительно
+// сворачивает регистр и совместимые символы (NFKC).
+func NormalizeName(name string) string {
+	return norm.NFC.String(strings.Join(strings.Fields(name), " "))
+}
+
+// NameKey ключ сравнения имён, повторяет SQL-функцию name_key: NFKC, нижний регистр,
+// пробельные символы схлопнуты.
+func NameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(norm.NFKC.String(name)), " "))
+}
@@ -0,0 +1,57 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"case: already normalized", "Muse", "Muse"},
+		{"case: spaces", "  The \t Beatles \n", "The Beatles"},
+		{"case: no-break space", "Daft\u00a0Punk", "Daft Punk"},
+		{"case: decomposed", "Beyonce\u0301", "Beyonc\u00e9"},
+		{"case: case is kept", "ДДТ", "ДДТ"},
+		{"case: compatibility forms are kept", "\uff33ong", "\uff33ong"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, entities.NormalizeName(tt.value))
+		})
+	}
+}
+
+func TestNameKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"case: lower", "The Beatles", "the beatles"},
+		{"case: spaces", " Daft\u00a0 Punk ", "daft punk"},
+		{"case: cyrillic", "Кино", "кино"},
+		{"case: compatibility forms", "\uff33ong", "song"},
+		{"case: decomposed equals composed", "Beyonce\u0301", entities.NameKey("Beyonc\u00e9")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, entities.NameKey(tt.value))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package entities
+
+import "time"
+
+type Playlist struct {
+	ID          int
+	Name        string
+	Description string
+	TracksCount int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// Entries записи плейлиста по порядку, заполняется только при получении плейлиста по ID.
+	Entries []PlaylistEntry
+}
+
+type PlaylistEntry struct {
+	EntryID int
+	TrackID int
+	Artist  string
+	Track   string
+	Link    string
+	// Duration длительность загруженного аудиофайла, 0 если файла нет.
+	Duration time.Duration
+	HasAudio bool
+	// Index порядковый номер записи в плейлисте, начиная с 0.
+	Index   int
+	AddedAt time.Time
+}
+
+type PlaylistCreate struct {
+	Name        string
+	Description string
+}
+
+// PlaylistUpdate изменения плейлиста, nil поля не меняются.
+type PlaylistUpdate struct {
+	PlaylistID  int
+	Name        *string
+	Description *string
+}
+
+type PlaylistGetListFilters struct {
+	Limit  int
+	Offset int
+}
+
+// PlaylistEntryAdd добавление трека в плейлист.
+//
+// Index - место новой записи (0 - в начало), nil или значение больше длины плейлиста - в конец.
+type PlaylistEntryAdd struct {
+	PlaylistID int
+	TrackID    int
+	Index      *int
+}
+
+// PlaylistEntryMove перемещение записи на место Index (значение больше длины плейлиста - в конец).
+type PlaylistEntryMove struct {
+	PlaylistID int
+	EntryID    int
+	Index      int
+}
+
+// PlaylistImport создание плейлиста из файла (M3U8, XSPF).
+//
+// Записи сопоставляются с треками по исполнителю и названию без учёта регистра.
+// Если CreateMissing, недостающие треки создаются через TracksService.Create.
+type PlaylistImport struct {
+	Name          string
+	Description   string
+	Entries       []TrackCreate
+	CreateMissing bool
+}
+
+type PlaylistImportStatus string
+
+const (
+	PlaylistImportStatusMatched PlaylistImportStatus = "matched"
+	PlaylistImportStatusCreated PlaylistImportStatus = "created"
+	PlaylistImportStatusMissing PlaylistImportStatus = "missing"
+	PlaylistImportStatusFailed  PlaylistImportStatus = "failed"
+)
+
+// PlaylistImportResult результат сопоставления одной записи файла, TrackID = 0 если трек не найден.
+type PlaylistImportResult struct {
+	Index   int
+	Artist  string
+	Title   string
+	Status  PlaylistImportStatus
+	TrackID int
+	Error   string
+}
+
+type PlaylistImportReport struct {
+	PlaylistID int
+	Matched    int
+	Created    int
+	Missing    int
+	Failed     int
+	Entries    []PlaylistImportResult
+}
@@ -0,0 +1,78 @@
+package entities
+
+// Role роль пользователя, определяет что он может менять в каталоге.
+// Читать каталог может кто угодно, в том числе анонимно.
+type Role string
+
+const (
+	RoleViewer    Role = "viewer"
+	RoleEditor    Role = "editor"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// DefaultRole роль новых пользователей.
+const DefaultRole = RoleViewer
+
+type Permission string
+
+const (
+	PermissionTracksCreate Permission = "tracks:create"
+	PermissionTracksUpdate Permission = "tracks:update"
+	PermissionTracksDelete Permission = "tracks:delete"
+	PermissionTracksMerge  Permission = "tracks:merge"
+	// PermissionTracksReview просмотр служебных отчётов по каталогу (дубликаты и т.п.).
+	PermissionTracksReview Permission = "tracks:review"
+	// PermissionPlaylistsEdit создание, изменение и удаление плейлистов и смарт-плейлистов.
+	// Плейлисты общие для всех пользователей, поэтому зрителю доступно только чтение.
+	PermissionPlaylistsEdit Permission = "playlists:edit"
+	// PermissionFavoritesEdit добавление и удаление треков в избранном текущего пользователя.
+	PermissionFavoritesEdit Permission = "favorites:edit"
+	// PermissionScrobblesCreate отправка прослушиваний от имени текущего пользователя.
+	PermissionScrobblesCreate Permission = "scrobbles:create"
+)
+
+// personalPermissions права на собственные данные пользователя, есть у всех ролей.
+// Нужны, чтобы API ключ без этих scopes не мог их менять.
+var personalPermissions = []Permission{PermissionFavoritesEdit, PermissionScrobblesCreate}
+
+// rolePermissions матрица прав. Удаление и слияние треков необратимы, поэтому доступны только админу.
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: personalPermissions,
+	RoleEditor: append([]Permission{
+		PermissionTracksCreate, PermissionTracksUpdate, PermissionPlaylistsEdit,
+	}, personalPermissions...),
+	RoleModerator: append([]Permission{
+		PermissionTracksCreate, PermissionTracksUpdate, PermissionTracksReview, PermissionPlaylistsEdit,
+	}, personalPermissions...),
+	RoleAdmin: append([]Permission{
+		PermissionTracksCreate, PermissionTracksUpdate, PermissionTracksReview, PermissionPlaylistsEdit,
+		PermissionTracksDelete, PermissionTracksMerge,
+	}, personalPermissions...),
+}
+
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RolesWith роли, которым доступно право, в порядке возрастания.
+func RolesWith(permission Permission) (roles []Role) {
+	for _, role := range []Role{RoleViewer, RoleEditor, RoleModerator, RoleAdmin} {
+		if role.Can(permission) {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
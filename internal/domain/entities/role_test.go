@@ -0,0 +1,73 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleCan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		role       entities.Role
+		permission entities.Permission
+		expected   bool
+	}{
+		{"case: viewer cannot create", entities.RoleViewer, entities.PermissionTracksCreate, false},
+		{"case: viewer cannot update", entities.RoleViewer, entities.PermissionTracksUpdate, false},
+		{"case: editor creates", entities.RoleEditor, entities.PermissionTracksCreate, true},
+		{"case: editor updates", entities.RoleEditor, entities.PermissionTracksUpdate, true},
+		{"case: viewer cannot edit playlists", entities.RoleViewer, entities.PermissionPlaylistsEdit, false},
+		{"case: editor edits playlists", entities.RoleEditor, entities.PermissionPlaylistsEdit, true},
+		{"case: editor cannot delete", entities.RoleEditor, entities.PermissionTracksDelete, false},
+		{"case: moderator reviews", entities.RoleModerator, entities.PermissionTracksReview, true},
+		{"case: moderator cannot delete", entities.RoleModerator, entities.PermissionTracksDelete, false},
+		{"case: moderator cannot merge", entities.RoleModerator, entities.PermissionTracksMerge, false},
+		{"case: admin deletes", entities.RoleAdmin, entities.PermissionTracksDelete, true},
+		{"case: admin merges", entities.RoleAdmin, entities.PermissionTracksMerge, true},
+		{"case: viewer edits favorites", entities.RoleViewer, entities.PermissionFavoritesEdit, true},
+		{"case: viewer scrobbles", entities.RoleViewer, entities.PermissionScrobblesCreate, true},
+		{"case: admin scrobbles", entities.RoleAdmin, entities.PermissionScrobblesCreate, true},
+		{"case: unknown role", entities.Role("root"), entities.PermissionTracksCreate, false},
+		{"case: empty role", entities.Role(""), entities.PermissionTracksCreate, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.role.Can(tt.permission))
+		})
+	}
+}
+
+func TestUserCan(t *testing.T) {
+	t.Parallel()
+
+	key := &entities.APIKey{Scopes: []entities.Permission{entities.PermissionTracksCreate}}
+
+	tests := []struct {
+		name       string
+		user       entities.User
+		permission entities.Permission
+		expected   bool
+	}{
+		{"case: access token uses role", entities.User{Role: entities.RoleEditor}, entities.PermissionTracksUpdate, true},
+		{"case: api key within scope", entities.User{Role: entities.RoleEditor, APIKey: key}, entities.PermissionTracksCreate, true},
+		{"case: api key out of scope", entities.User{Role: entities.RoleEditor, APIKey: key}, entities.PermissionTracksUpdate, false},
+		{"case: api key without favorites scope", entities.User{Role: entities.RoleViewer, APIKey: key}, entities.PermissionFavoritesEdit, false},
+		{"case: api key without scrobbles scope", entities.User{Role: entities.RoleEditor, APIKey: key}, entities.PermissionScrobblesCreate, false},
+		{"case: scope above current role", entities.User{Role: entities.RoleViewer, APIKey: key}, entities.PermissionTracksCreate, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.user.Can(tt.permission))
+		})
+	}
+}
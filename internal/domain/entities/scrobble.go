@@ -0,0 +1,69 @@
+package entities
+
+import "time"
+
+// ScrobbleSubmit событие прослушивания от клиента. Трек задаётся TrackID либо парой Artist/Title.
+type ScrobbleSubmit struct {
+	TrackID        int
+	Artist         string
+	Title          string
+	PlayedAt       time.Time
+	DurationPlayed time.Duration
+}
+
+type ScrobbleStatus string
+
+const (
+	// ScrobbleStatusAccepted прослушивание засчитано.
+	ScrobbleStatusAccepted ScrobbleStatus = "accepted"
+	// ScrobbleStatusDuplicate такое прослушивание уже есть в окне дедупликации.
+	ScrobbleStatusDuplicate ScrobbleStatus = "duplicate"
+	// ScrobbleStatusIgnored трек прослушан меньше порога.
+	ScrobbleStatusIgnored ScrobbleStatus = "ignored"
+	// ScrobbleStatusRejected неизвестный трек или недопустимое время.
+	ScrobbleStatusRejected ScrobbleStatus = "rejected"
+)
+
+// ScrobbleResult результат обработки события с индексом Index в запросе.
+type ScrobbleResult struct {
+	Index   int
+	Status  ScrobbleStatus
+	TrackID int
+	Reason  string
+}
+
+// Scrobble засчитанное прослушивание.
+type Scrobble struct {
+	ID             int64
+	TrackID        int
+	Artist         string
+	Title          string
+	PlayedAt       time.Time
+	DurationPlayed time.Duration
+}
+
+const (
+	HistoryDefaultLimit = 50
+	HistoryMaxLimit     = 500
+)
+
+// HistoryCursor позиция, после которой продолжается история (прослушивания идут от новых к старым).
+type HistoryCursor struct {
+	PlayedAt time.Time
+	ID       int64
+}
+
+// HistoryFilters прослушивания пользователя в полуинтервале [From, To), нулевые границы не ограничивают.
+type HistoryFilters struct {
+	UserID int
+	From   time.Time
+	To     time.Time
+	Limit  int
+	After  *HistoryCursor
+}
+
+// HistoryPage страница истории. Next nil, если дальше прослушиваний нет.
+type HistoryPage struct {
+	Scrobbles []Scrobble
+	Next      *HistoryCursor
+}
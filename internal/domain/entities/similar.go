@@ -0,0 +1,24 @@
+package entities
+
+const (
+	SimilarTracksDefaultLimit = 10
+	SimilarTracksMaxLimit     = 100
+)
+
+// SimilarTrack трек, похожий на заданный по тексту.
+type SimilarTrack struct {
+	TrackID  int
+	ArtistID int
+	Artist   string
+	Title    string
+	// Similarity оценка коэффициента Жаккара шинглов текстов, от 0 до 1.
+	Similarity float64
+}
+
+type SimilarTracksFilters struct {
+	TrackID int
+	Limit   int
+	// Diversity от 0 до 1: насколько понижается каждый следующий трек того же исполнителя
+	// (включая исполнителя исходного трека). 0 - ранжирование только по сходству.
+	Diversity float64
+}
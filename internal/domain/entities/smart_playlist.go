@@ -0,0 +1,229 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TrackRuleField поле трека, по которому строится условие правила.
+type TrackRuleField string
+
+const (
+	TrackRuleFieldArtist TrackRuleField = "artist"
+	TrackRuleFieldTitle  TrackRuleField = "title"
+	TrackRuleFieldYear   TrackRuleField = "year"
+	TrackRuleFieldTag    TrackRuleField = "tag"
+	TrackRuleFieldLyric  TrackRuleField = "lyric"
+)
+
+// TrackRuleOp способ сравнения строковых полей.
+type TrackRuleOp string
+
+const (
+	TrackRuleOpEq       TrackRuleOp = "eq"
+	TrackRuleOpContains TrackRuleOp = "contains"
+)
+
+const (
+	// TrackRuleMaxDepth максимальная вложенность групп правил.
+	TrackRuleMaxDepth = 5
+	// TrackRuleMaxConditions максимальное число условий в наборе правил.
+	TrackRuleMaxConditions = 50
+)
+
+// TrackRule набор правил отбора треков: либо группа (And или Or), либо одно условие (Field).
+//
+// Правила хранятся в БД в JSON, поэтому json теги - часть формата хранения.
+//
+//	{"and": [
+//		{"field": "artist", "op": "eq", "value": "Muse"},
+//		{"field": "year", "from": 2000, "to": 2010},
+//		{"or": [{"field": "tag", "value": "rock"}, {"field": "lyric", "value": "love"}]}
+//	]}
+type TrackRule struct {
+	And []TrackRule `json:"and,omitempty"`
+	Or  []TrackRule `json:"or,omitempty"`
+
+	Field TrackRuleField `json:"field,omitempty"`
+	// Op для artist и title: eq (по умолчанию) или contains. Tag сравнивается на равенство, lyric - на вхождение.
+	Op    TrackRuleOp `json:"op,omitempty"`
+	Value string      `json:"value,omitempty"`
+	// From и To границы года включительно для year, 0 - без ограничения.
+	From int `json:"from,omitempty"`
+	To   int `json:"to,omitempty"`
+}
+
+// TrackRuleError ошибка валидации правила, Path указывает на правило, например "and[1].or[0]".
+type TrackRuleError struct {
+	Path    string
+	Message string
+}
+
+func (e *TrackRuleError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return e.Path + ": " + e.Message
+}
+
+// Validate проверяет структуру правил, ограничения вложенности и числа условий.
+func (r TrackRule) Validate() error {
+	conditions := 0
+
+	return r.validate("", 1, &conditions)
+}
+
+func (r TrackRule) validate(path string, depth int, conditions *int) error {
+	fail := func(format string, args ...any) error {
+		return &TrackRuleError{Path: path, Message: fmt.Sprintf(format, args...)}
+	}
+
+	kinds := 0
+	for _, set := range []bool{r.And != nil, r.Or != nil, r.Field != ""} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return fail("rule must have exactly one of and, or, field")
+	}
+
+	if r.Field == "" {
+		if depth > TrackRuleMaxDepth {
+			return fail("rules are nested deeper than %d levels", TrackRuleMaxDepth)
+		}
+		if r.Op != "" || r.Value != "" || r.From != 0 || r.To != 0 {
+			return fail("group must not have op, value, from or to")
+		}
+
+		name, rules := "and", r.And
+		if r.Or != nil {
+			name, rules = "or", r.Or
+		}
+		if len(rules) == 0 {
+			return fail("%s must not be empty", name)
+		}
+		for i, rule := range rules {
+			if err := rule.validate(joinRulePath(path, fmt.Sprintf("%s[%d]", name, i)), depth+1, conditions); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if *conditions++; *conditions > TrackRuleMaxConditions {
+		return fail("too many conditions, max %d", TrackRuleMaxConditions)
+	}
+
+	switch r.Field {
+	case TrackRuleFieldArtist, TrackRuleFieldTitle:
+		if r.Op != "" && r.Op != TrackRuleOpEq && r.Op != TrackRuleOpContains {
+			return fail("op must be %q or %q", TrackRuleOpEq, TrackRuleOpContains)
+		}
+	case TrackRuleFieldTag, TrackRuleFieldLyric:
+		if r.Op != "" {
+			return fail("op is not supported for %s", r.Field)
+		}
+	case TrackRuleFieldYear:
+		if r.Op != "" || r.Value != "" {
+			return fail("year supports only from and to")
+		}
+		if r.From < 0 || r.To < 0 {
+			return fail("from and to must not be negative")
+		}
+		if r.From == 0 && r.To == 0 {
+			return fail("year requires from or to")
+		}
+		if r.To != 0 && r.From > r.To {
+			return fail("from must not be greater than to")
+		}
+		return nil
+	default:
+		return fail("unknown field %q", r.Field)
+	}
+
+	if r.From != 0 || r.To != 0 {
+		return fail("from and to are supported only for year")
+	}
+	if strings.TrimSpace(r.Value) == "" {
+		return fail("value must not be empty")
+	}
+
+	return nil
+}
+
+func joinRulePath(path, elem string) string {
+	if path == "" {
+		return elem
+	}
+
+	return path + "." + elem
+}
+
+// TrackSort порядок треков: имя поля, с префиксом "-" - по убыванию.
+type TrackSort string
+
+const (
+	TrackSortID       TrackSort = "id"
+	TrackSortTitle    TrackSort = "title"
+	TrackSortArtist   TrackSort = "artist"
+	TrackSortReleased TrackSort = "released"
+)
+
+// Field поле сортировки и направление.
+func (s TrackSort) Field() (field TrackSort, desc bool) {
+	if strings.HasPrefix(string(s), "-") {
+		return s[1:], true
+	}
+
+	return s, false
+}
+
+// Valid пустой порядок означает сортировку по id.
+func (s TrackSort) Valid() bool {
+	field, _ := s.Field()
+
+	switch field {
+	case "", TrackSortID, TrackSortTitle, TrackSortArtist, TrackSortReleased:
+		return true
+	}
+
+	return false
+}
+
+const (
+	SmartPlaylistDefaultLimit = 100
+	SmartPlaylistMaxLimit     = 1000
+)
+
+// SmartPlaylist плейлист, треки которого вычисляются по правилам при каждом чтении.
+type SmartPlaylist struct {
+	ID        int
+	Name      string
+	Rule      TrackRule
+	Sort      TrackSort
+	Limit     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SmartPlaylistQuery правила, порядок и лимит для вычисления (или предпросмотра) смарт-плейлиста.
+type SmartPlaylistQuery struct {
+	Rule  TrackRule
+	Sort  TrackSort
+	Limit int
+}
+
+type SmartPlaylistCreate struct {
+	Name string
+	SmartPlaylistQuery
+}
+
+type SmartPlaylistUpdate struct {
+	SmartPlaylistID int
+	Name            string
+	SmartPlaylistQuery
+}
@@ -0,0 +1,97 @@
+package entities_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		rules       string
+		expectedErr string
+	}{
+		{
+			"case: artist and year range",
+			`{"and": [{"field": "artist", "value": "Muse"}, {"field": "year", "from": 2000, "to": 2010}]}`,
+			"",
+		},
+		{
+			"case: nested or",
+			`{"and": [{"field": "title", "op": "contains", "value": "love"}, {"or": [{"field": "tag", "value": "rock"}, {"field": "lyric", "value": "sun"}]}]}`,
+			"",
+		},
+		{
+			"case: open year range",
+			`{"field": "year", "from": 2000}`,
+			"",
+		},
+		{
+			"case: empty rule",
+			`{}`,
+			"rule must have exactly one of and, or, field",
+		},
+		{
+			"case: group and field",
+			`{"field": "artist", "value": "Muse", "or": [{"field": "tag", "value": "rock"}]}`,
+			"rule must have exactly one of and, or, field",
+		},
+		{
+			"case: empty group",
+			`{"or": []}`,
+			"or must not be empty",
+		},
+		{
+			"case: unknown field",
+			`{"and": [{"field": "artist", "value": "Muse"}, {"field": "genre", "value": "rock"}]}`,
+			`and[1]: unknown field "genre"`,
+		},
+		{
+			"case: empty value",
+			`{"or": [{"and": [{"field": "title", "value": " "}]}]}`,
+			"or[0].and[0]: value must not be empty",
+		},
+		{
+			"case: unsupported op",
+			`{"field": "lyric", "op": "eq", "value": "sun"}`,
+			"op is not supported for lyric",
+		},
+		{
+			"case: inverted year range",
+			`{"field": "year", "from": 2010, "to": 2000}`,
+			"from must not be greater than to",
+		},
+		{
+			"case: year without bounds",
+			`{"field": "year"}`,
+			"year requires from or to",
+		},
+		{
+			"case: too deep",
+			`{"and": [{"and": [{"and": [{"and": [{"and": [{"and": [{"field": "tag", "value": "rock"}]}]}]}]}]}]}`,
+			"and[0].and[0].and[0].and[0].and[0]: rules are nested deeper than 5 levels",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var rule entities.TrackRule
+			require.NoError(t, json.Unmarshal([]byte(test.rules), &rule))
+
+			err := rule.Validate()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package entities
+
+import "time"
+
+const (
+	StatsDefaultLimit = 10
+	// StatsMaxLimit совпадает с размером витрины новых треков.
+	StatsMaxLimit = 500
+
+	// StatsDefaultDays окно топа прослушиваний по умолчанию.
+	StatsDefaultDays = 30
+	StatsMaxDays     = 365
+)
+
+type YearStat struct {
+	Year   int
+	Tracks int
+}
+
+type ArtistStat struct {
+	ArtistID int
+	Artist   string
+	Tracks   int
+}
+
+type TrackPlaysStat struct {
+	TrackID int
+	Artist  string
+	Title   string
+	Plays   int
+}
+
+type LyricsStat struct {
+	Tracks           int
+	TracksWithLyrics int
+	Verses           int
+}
+
+// AvgVersesPerTrack среднее количество куплетов на трек, треки без текста тоже учитываются.
+func (s LyricsStat) AvgVersesPerTrack() float64 {
+	if s.Tracks == 0 {
+		return 0
+	}
+
+	return float64(s.Verses) / float64(s.Tracks)
+}
+
+type NewestTrack struct {
+	TrackID  int
+	Artist   string
+	Title    string
+	Released time.Time
+	AddedAt  time.Time
+}
@@ -1,6 +1,10 @@
 package entities
 
-import "time"
+import (
+	"io"
+	"strings"
+	"time"
+)
 
 type Track struct {
 	ID       int
@@ -9,6 +13,21 @@ type Track struct {
 	Lyric    []string
 	Link     string
 	Released time.Time
+	// Duration длительность загруженного аудиофайла, 0 если файла нет.
+	Duration time.Duration
+	HasCover bool
+	HasAudio bool
+	// Language язык текста (ISO 639-1) и уверенность определения, пустой если не определён.
+	// Заполняются только в списке треков.
+	Language           string
+	LanguageConfidence float64
+	// Tags теги трека, заполняются только при получении трека по ID.
+	Tags []string
+	// CreatedBy и UpdatedBy ID пользователей, nil для треков из сканера и импорта. Заполняются только при получении трека по ID.
+	CreatedBy *int
+	UpdatedBy *int
+	// IsFavorite есть ли трек в избранном пользователя, сделавшего запрос; nil для анонимных запросов.
+	IsFavorite *bool
 }
 
 type TrackVerse struct {
@@ -30,6 +49,22 @@ type TrackUpdate struct {
 	Released time.Time
 }
 
+// TrackUpsert данные трека из локального источника (например, тегов аудиофайла).
+//
+// Трек ищется по TrackID, если он задан и трек существует, иначе по названию и исполнителю:
+// если найден - обновляется, иначе создаётся. Пустые Album, Lyric и нулевая Released
+// не перезаписывают существующие значения.
+type TrackUpsert struct {
+	// TrackID трек, с которым источник уже связан. Позволяет обновить трек, у которого в источнике
+	// изменились название или исполнитель, а не создавать новый.
+	TrackID  int
+	Title    string
+	Artist   string
+	Album    string
+	Lyric    string
+	Released time.Time
+}
+
 type TrackGetListFilters struct {
 	Limit        int
 	Offset       int
@@ -37,6 +72,12 @@ type TrackGetListFilters struct {
 	Track        string
 	ReleasedYear string
 	Link         string
+	// Lang язык текста (ISO 639-1).
+	Lang string
+	// Rule правила смарт-плейлиста, объединяются с остальными фильтрами через AND.
+	Rule *TrackRule
+	// Sort порядок треков, по умолчанию по id.
+	Sort TrackSort
 }
 
 type TrackInfoResult struct {
@@ -49,3 +90,41 @@ type TrackInfo struct {
 	Group string
 	Song  string
 }
+
+type TrackBatchStatus string
+
+const (
+	TrackBatchStatusCreated TrackBatchStatus = "created"
+	TrackBatchStatusExists  TrackBatchStatus = "exists"
+	TrackBatchStatusFailed  TrackBatchStatus = "failed"
+)
+
+// TrackBatchResult результат создания одного трека из пакета.
+type TrackBatchResult struct {
+	TrackID int
+	Title   string
+	Artist  string
+	Status  TrackBatchStatus
+	Reason  string
+}
+
+// TrackAudio метаданные загруженного аудиофайла трека.
+type TrackAudio struct {
+	TrackID     int
+	ContentType string
+	Size        int64
+	ETag        string
+	Duration    time.Duration
+	UpdatedAt   time.Time
+}
+
+type TrackAudioUpload struct {
+	TrackID     int
+	ContentType string
+	Body        io.Reader
+}
+
+// NormalizeTrackTag приводит тег трека к виду, в котором он хранится: без пробелов по краям, в нижнем регистре.
+func NormalizeTrackTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+type User struct {
+	ID        int
+	Email     string
+	Role      Role
+	CreatedAt time.Time
+	// APIKey ключ, которым аутентифицирован запрос, nil для access токена.
+	APIKey *APIKey
+}
+
+// Can проверяет право роли, а для запросов по API ключу ещё и scope ключа.
+func (u User) Can(permission Permission) bool {
+	if !u.Role.Can(permission) {
+		return false
+	}
+
+	return u.APIKey == nil || u.APIKey.HasScope(permission)
+}
+
+type UserRegister struct {
+	Email    string
+	Password string
+}
+
+type UserLogin struct {
+	Email    string
+	Password string
+}
+
+// AuthTokens пара токенов, выдаваемая при входе и обновлении.
+type AuthTokens struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+type userContextKey struct{}
+
+// ContextWithUser возвращает контекст с аутентифицированным пользователем.
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext возвращает пользователя, положенного в контекст middleware аутентификации.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(User)
+	return user, ok
+}
+
+// UserIDFromContext возвращает ID пользователя из контекста или nil для анонимных запросов и фоновых задач.
+func UserIDFromContext(ctx context.Context) *int {
+	if user, ok := UserFromContext(ctx); ok {
+		return &user.ID
+	}
+
+	return nil
+}
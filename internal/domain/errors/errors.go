@@ -8,4 +8,49 @@ var (
 	ErrTrackFailedCreateTrack = errors.New("failed to save the tack into DB")
 	ErrTrackNotFound          = errors.New("track not found")
 	ErrTrackLyricNotFound     = errors.New("track lyric not found")
+	ErrTrackBatchTooLarge     = errors.New("too many tracks in batch")
+	ErrTrackBatchDuplicate    = errors.New("duplicate track in batch")
+	ErrTrackAudioNotFound     = errors.New("track audio not found")
+	ErrTrackAudioInvalid      = errors.New("track audio is not a valid MP3 or FLAC file")
+	ErrTrackTagsInvalid       = errors.New("track tags are invalid")
+
+	ErrArtistAlreadyExists = errors.New("artist already exists")
+
+	ErrAlbumNotFound      = errors.New("album not found")
+	ErrCoverNotFound      = errors.New("cover not found")
+	ErrCoverInvalid       = errors.New("cover is not a valid JPEG or PNG image")
+	ErrCoverInvalidSize   = errors.New("cover size is not supported")
+	ErrCoverNotAcceptable = errors.New("cover can be served only as image/jpeg or image/png")
+
+	ErrPlaylistNotFound      = errors.New("playlist not found")
+	ErrPlaylistEntryNotFound = errors.New("playlist entry not found")
+
+	ErrSmartPlaylistNotFound     = errors.New("smart playlist not found")
+	ErrSmartPlaylistInvalidRules = errors.New("smart playlist rules are invalid")
+	ErrSmartPlaylistInvalidSort  = errors.New("smart playlist sort is invalid")
+	ErrSmartPlaylistInvalidLimit = errors.New("smart playlist limit is invalid")
+
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserRoleInvalid      = errors.New("user role is invalid")
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrInvalidAccessToken   = errors.New("access token is invalid or expired")
+	ErrInvalidRefreshToken  = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrAPIKeyScopesInvalid = errors.New("api key scopes are invalid or exceed the role permissions")
+	ErrAPIKeyExpiryInvalid = errors.New("api key expiry must be in the future")
+	ErrAPIKeyLimitReached  = errors.New("too many active api keys")
+	ErrInvalidAPIKey       = errors.New("api key is invalid, revoked or expired")
+
+	ErrFavoriteNotFound    = errors.New("track is not in favorites")
+	ErrFavoriteInvalidSort = errors.New("favorites sort is invalid")
+
+	ErrScrobbleBatchTooLarge  = errors.New("too many scrobbles in batch")
+	ErrScrobbleHistoryInvalid = errors.New("history range or cursor is invalid")
+
+	ErrDuplicateMergeInvalid = errors.New("tracks are not duplicates of the target track")
+
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
 )
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type APIKeysRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKeysRepository(db *pgxpool.Pool) *APIKeysRepository {
+	return &APIKeysRepository{db: db}
+}
+
+// CreateAPIKey сохраняет ключ, если у пользователя меньше maxActive действующих ключей,
+// иначе возвращает domain.ErrAPIKeyLimitReached.
+func (r *APIKeysRepository) CreateAPIKey(ctx context.Context, key dao.APIKey, maxActive int) (created dao.APIKey, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Блокировка строки пользователя сериализует параллельные создания ключей одного пользователя.
+		sql := `SELECT user_id FROM users WHERE user_id = $1 FOR UPDATE;`
+		if err = tx.QueryRow(ctx, sql, key.UserID).Scan(&key.UserID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrUserNotFound
+			}
+			return fmt.Errorf("failed to select users: %w", err)
+		}
+
+		var active int
+		sql = `
+			SELECT COUNT(*) FROM api_keys
+			WHERE user_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW());`
+		if err = tx.QueryRow(ctx, sql, key.UserID).Scan(&active); err != nil {
+			return fmt.Errorf("failed to count api_keys: %w", err)
+		}
+		if active >= maxActive {
+			return domain.ErrAPIKeyLimitReached
+		}
+
+		sql = `
+			INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, quota, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING key_id, created_at;`
+		if err = tx.QueryRow(ctx, sql,
+			key.UserID, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.Quota, key.ExpiresAt,
+		).Scan(&key.KeyID, &key.CreatedAt); err != nil {
+			return fmt.Errorf("failed to insert api_keys: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dao.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// GetAPIKeysByUser возвращает все ключи пользователя, в том числе отозванные, новые первыми.
+func (r *APIKeysRepository) GetAPIKeysByUser(ctx context.Context, userID int) (keys []dao.APIKey, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT key_id, user_id, name, prefix, scopes, quota, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys WHERE user_id = $1
+		ORDER BY key_id DESC;`
+
+	rows, err := r.db.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	keys = make([]dao.APIKey, 0)
+	for rows.Next() {
+		var key dao.APIKey
+		if err = rows.Scan(
+			&key.KeyID, &key.UserID, &key.Name, &key.Prefix, &key.Scopes, &key.Quota,
+			&key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey отзывает ключ пользователя. Чужой или уже отозванный ключ - domain.ErrAPIKeyNotFound.
+func (r *APIKeysRepository) RevokeAPIKey(ctx context.Context, userID int, keyID int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `UPDATE api_keys SET revoked_at = NOW() WHERE key_id = $1 AND user_id = $2 AND revoked_at IS NULL;`
+
+	tag, err := r.db.Exec(ctx, sql, keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// GetAPIKeyByHash возвращает действующий ключ вместе с владельцем и отмечает время использования.
+// Отозванный, истёкший или неизвестный ключ - domain.ErrAPIKeyNotFound.
+func (r *APIKeysRepository) GetAPIKeyByHash(ctx context.Context, keyHash []byte) (key dao.APIKey, user dao.User, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	// last_used_at обновляется не чаще раза в минуту, чтобы активный клиент не писал в таблицу на каждый запрос.
+	sql := `
+		WITH used AS (
+			UPDATE api_keys SET last_used_at = NOW()
+			WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+				AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+		)
+		SELECT api_keys.key_id, api_keys.user_id, api_keys.name, api_keys.prefix, api_keys.scopes, api_keys.quota,
+			api_keys.expires_at, api_keys.last_used_at, api_keys.created_at,
+			users.email, users.role, users.created_at
+		FROM api_keys JOIN users ON users.user_id = api_keys.user_id
+		WHERE api_keys.key_hash = $1 AND api_keys.revoked_at IS NULL
+			AND (api_keys.expires_at IS NULL OR api_keys.expires_at > NOW());`
+
+	if err = r.db.QueryRow(ctx, sql, keyHash).Scan(
+		&key.KeyID, &key.UserID, &key.Name, &key.Prefix, &key.Scopes, &key.Quota,
+		&key.ExpiresAt, &key.LastUsedAt, &key.CreatedAt,
+		&user.Email, &user.Role, &user.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.APIKey{}, dao.User{}, domain.ErrAPIKeyNotFound
+		}
+		return dao.APIKey{}, dao.User{}, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+	user.UserID = key.UserID
+
+	return key, user, nil
+}
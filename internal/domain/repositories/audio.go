@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type AudioRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAudioRepository(db *pgxpool.Pool) *AudioRepository {
+	return &AudioRepository{db: db}
+}
+
+func (r *AudioRepository) IsTrackExistsByID(ctx context.Context, trackID int) (exists bool, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT EXISTS(SELECT 1 FROM tracks WHERE track_id = $1);`
+
+	if err = r.db.QueryRow(ctx, sql, trackID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return exists, nil
+}
+
+// SaveTrackAudio сохраняет метаданные аудиофайла и его длительность в треке.
+//
+// Возвращает ключ предыдущего файла (пустой, если его не было), чтобы вызывающий удалил его из хранилища.
+func (r *AudioRepository) SaveTrackAudio(ctx context.Context, audio dao.TrackAudio) (previousKey string, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		sql := `SELECT blob_key FROM track_audio WHERE track_id = $1 FOR UPDATE;`
+		if err = tx.QueryRow(ctx, sql, audio.TrackID).Scan(&previousKey); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to select previous blob_key: %w", err)
+		}
+
+		sql = `
+			INSERT INTO track_audio (track_id, blob_key, content_type, size, etag)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (track_id) DO UPDATE SET
+				blob_key = EXCLUDED.blob_key,
+				content_type = EXCLUDED.content_type,
+				size = EXCLUDED.size,
+				etag = EXCLUDED.etag,
+				updated_at = NOW();`
+		if _, err = tx.Exec(ctx, sql, audio.TrackID, audio.BlobKey, audio.ContentType, audio.Size, audio.ETag); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.ConstraintName == "track_audio_track_id_fkey" {
+				return domain.ErrTrackNotFound
+			}
+			return fmt.Errorf("failed to upsert track_audio: %w", err)
+		}
+
+		sql = `UPDATE tracks SET duration_ms = $2 WHERE track_id = $1;`
+		if _, err = tx.Exec(ctx, sql, audio.TrackID, audio.DurationMs); err != nil {
+			return fmt.Errorf("failed to update tracks.duration_ms: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return previousKey, nil
+}
+
+func (r *AudioRepository) GetTrackAudio(ctx context.Context, trackID int) (audio dao.TrackAudio, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT
+			track_audio.track_id, track_audio.blob_key, track_audio.content_type, track_audio.size,
+			track_audio.etag, COALESCE(tracks.duration_ms, 0), track_audio.created_at, track_audio.updated_at
+		FROM
+			track_audio JOIN tracks
+				ON track_audio.track_id = tracks.track_id
+		WHERE
+			track_audio.track_id = $1;`
+
+	if err = r.db.QueryRow(ctx, sql, trackID).Scan(
+		&audio.TrackID,
+		&audio.BlobKey,
+		&audio.ContentType,
+		&audio.Size,
+		&audio.ETag,
+		&audio.DurationMs,
+		&audio.CreatedAt,
+		&audio.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.TrackAudio{}, domain.ErrTrackAudioNotFound
+		}
+		return dao.TrackAudio{}, fmt.Errorf("failed to db.QueryRow(%d): %w", trackID, err)
+	}
+
+	return audio, nil
+}
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type CoversRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCoversRepository(db *pgxpool.Pool) *CoversRepository {
+	return &CoversRepository{db: db}
+}
+
+// coverOwnerColumn колонка covers и таблица владельца по типу владельца.
+func coverOwnerColumn(kind entities.CoverOwnerKind) (column string, table string, err error) {
+	switch kind {
+	case entities.CoverOwnerTrack:
+		return "track_id", "tracks", nil
+	case entities.CoverOwnerAlbum:
+		return "album_id", "albums", nil
+	default:
+		return "", "", fmt.Errorf("unknown cover owner kind %q", kind)
+	}
+}
+
+func coverOwnerNotFound(kind entities.CoverOwnerKind) error {
+	if kind == entities.CoverOwnerAlbum {
+		return domain.ErrAlbumNotFound
+	}
+
+	return domain.ErrTrackNotFound
+}
+
+func (r *CoversRepository) IsOwnerExists(ctx context.Context, owner entities.CoverOwner) (exists bool, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	column, table, err := coverOwnerColumn(owner.Kind)
+	if err != nil {
+		return false, err
+	}
+
+	sql := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1);`, table, column)
+
+	if err = r.db.QueryRow(ctx, sql, owner.ID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return exists, nil
+}
+
+// SaveCover сохраняет (или заменяет) обложку владельца.
+//
+// Возвращает ключ предыдущей обложки (пустой, если её не было), чтобы вызывающий удалил её из хранилища.
+func (r *CoversRepository) SaveCover(ctx context.Context, owner entities.CoverOwner, cover dao.Cover) (previousKey string, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	column, _, err := coverOwnerColumn(owner.Kind)
+	if err != nil {
+		return "", err
+	}
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		sql := fmt.Sprintf(`SELECT blob_key FROM covers WHERE %s = $1 FOR UPDATE;`, column)
+		if err = tx.QueryRow(ctx, sql, owner.ID).Scan(&previousKey); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to select previous blob_key: %w", err)
+		}
+
+		sql = fmt.Sprintf(`
+			INSERT INTO covers (%[1]s, blob_key, content_type, etag, width, height)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (%[1]s) DO UPDATE SET
+				blob_key = EXCLUDED.blob_key,
+				content_type = EXCLUDED.content_type,
+				etag = EXCLUDED.etag,
+				width = EXCLUDED.width,
+				height = EXCLUDED.height,
+				updated_at = NOW();`, column)
+		if _, err = tx.Exec(ctx, sql, owner.ID, cover.BlobKey, cover.ContentType, cover.ETag, cover.Width, cover.Height); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && (pgErr.ConstraintName == "covers_track_id_fkey" || pgErr.ConstraintName == "covers_album_id_fkey") {
+				return coverOwnerNotFound(owner.Kind)
+			}
+			return fmt.Errorf("failed to upsert covers: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return previousKey, nil
+}
+
+func (r *CoversRepository) GetCover(ctx context.Context, owner entities.CoverOwner) (cover dao.Cover, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	column, _, err := coverOwnerColumn(owner.Kind)
+	if err != nil {
+		return dao.Cover{}, err
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT
+			cover_id, COALESCE(track_id, 0), COALESCE(album_id, 0), blob_key, content_type, etag, width, height, created_at, updated_at
+		FROM covers
+		WHERE %s = $1;`, column)
+
+	if err = r.db.QueryRow(ctx, sql, owner.ID).Scan(
+		&cover.CoverID,
+		&cover.TrackID,
+		&cover.AlbumID,
+		&cover.BlobKey,
+		&cover.ContentType,
+		&cover.ETag,
+		&cover.Width,
+		&cover.Height,
+		&cover.CreatedAt,
+		&cover.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.Cover{}, domain.ErrCoverNotFound
+		}
+		return dao.Cover{}, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return cover, nil
+}
+
+// GetTrackAlbumID возвращает альбом трека, 0 если трек не привязан к альбому.
+func (r *CoversRepository) GetTrackAlbumID(ctx context.Context, trackID int) (albumID int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT COALESCE(album_id, 0) FROM tracks WHERE track_id = $1;`
+
+	if err = r.db.QueryRow(ctx, sql, trackID).Scan(&albumID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrTrackNotFound
+		}
+		return 0, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return albumID, nil
+}
@@ -15,6 +15,9 @@ type Track struct {
 	Link       string
 	ReleasedAt time.Time
 	CreatedAt  time.Time
+	// CreatedBy и UpdatedBy пользователь, создавший/изменивший трек, nil для сканера и импорта.
+	CreatedBy *int
+	UpdatedBy *int
 }
 
 type Lyric struct {
@@ -23,3 +26,161 @@ type Lyric struct {
 	Verse     string
 	CreatedAt time.Time
 }
+
+// IdempotencyKey сохранённый результат запроса с заголовком Idempotency-Key.
+//
+// ResponseStatus == 0 означает, что запрос ещё обрабатывается.
+type IdempotencyKey struct {
+	Key                 string
+	RequestHash         string
+	ResponseStatus      int
+	ResponseContentType string
+	ResponseBody        []byte
+	CreatedAt           time.Time
+	ExpiresAt           time.Time
+}
+
+type Album struct {
+	AlbumID   int
+	ArtistID  int
+	Title     string
+	CreatedAt time.Time
+}
+
+// LibraryFile отпечаток просканированного аудиофайла.
+type LibraryFile struct {
+	Path       string
+	Size       int64
+	ModifiedAt time.Time
+	TrackID    int
+	ScannedAt  time.Time
+}
+
+type TrackAudio struct {
+	TrackID     int
+	BlobKey     string
+	ContentType string
+	Size        int64
+	ETag        string
+	DurationMs  int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Cover обложка трека или альбома, заполнен ровно один из TrackID и AlbumID.
+type Cover struct {
+	CoverID     int
+	TrackID     int
+	AlbumID     int
+	BlobKey     string
+	ContentType string
+	ETag        string
+	Width       int
+	Height      int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Playlist struct {
+	PlaylistID  int
+	Name        string
+	Description string
+	TracksCount int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PlaylistEntry запись плейлиста вместе с данными трека.
+type PlaylistEntry struct {
+	EntryID    int
+	PlaylistID int
+	TrackID    int
+	Artist     string
+	Title      string
+	Link       string
+	DurationMs int64
+	HasAudio   bool
+	Position   int64
+	CreatedAt  time.Time
+}
+
+// SmartPlaylist смарт-плейлист, Rules - entities.TrackRule в JSON.
+type SmartPlaylist struct {
+	SmartPlaylistID int
+	Name            string
+	Rules           []byte
+	Sort            string
+	Limit           int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// TrackRef идентификатор трека с исполнителем и названием.
+type TrackRef struct {
+	TrackID int
+	Artist  string
+	Title   string
+}
+
+type User struct {
+	UserID       int
+	Email        string
+	PasswordHash string
+	Role         string
+	CreatedAt    time.Time
+}
+
+type RefreshToken struct {
+	TokenID   int
+	UserID    int
+	TokenHash []byte
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+type APIKey struct {
+	KeyID      int
+	UserID     int
+	Name       string
+	Prefix     string
+	KeyHash    []byte
+	Scopes     []string
+	Quota      int
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type Scrobble struct {
+	ScrobbleID       int64
+	UserID           int
+	TrackID          int
+	PlayedAt         time.Time
+	DurationPlayedMs int64
+}
+
+// LyricSignature MinHash сигнатура текста трека и хеши её полос для поиска похожих.
+// У трека без текста Signature и Bands пустые.
+type LyricSignature struct {
+	TrackID   int
+	Signature []uint64
+	Bands     []uint64
+}
+
+// SimilarCandidate трек-кандидат в похожие с его сигнатурой.
+type SimilarCandidate struct {
+	TrackID   int
+	ArtistID  int
+	Artist    string
+	Title     string
+	Signature []uint64
+}
+
+// LyricLanguage язык текста трека (ISO 639-1) и уверенность определения.
+// Language пустой, если у трека нет текста или язык не определён.
+type LyricLanguage struct {
+	TrackID    int
+	Language   string
+	Confidence float64
+}
@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+)
+
+type DuplicatesRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDuplicatesRepository(db *pgxpool.Pool) *DuplicatesRepository {
+	return &DuplicatesRepository{db: db}
+}
+
+// GetTrackTitles передаёт в fn все треки каталога по возрастанию исполнителя и ID, не загружая их в память целиком.
+func (r *DuplicatesRepository) GetTrackTitles(ctx context.Context, fn func(track entities.DuplicateTrack) error) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT tracks.track_id, tracks.artist_id, artists.name, tracks.title
+		FROM tracks JOIN artists ON artists.artist_id = tracks.artist_id
+		ORDER BY tracks.artist_id, tracks.track_id;`
+
+	rows, err := r.db.Query(ctx, sql)
+	if err != nil {
+		return fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var track entities.DuplicateTrack
+		if err = rows.Scan(&track.TrackID, &track.ArtistID, &track.Artist, &track.Title); err != nil {
+			return fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		if err = fn(track); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetDuplicateTracks треки с ID из ids, отсутствующие треки пропускаются.
+func (r *DuplicatesRepository) GetDuplicateTracks(ctx context.Context, ids []int) (tracks []entities.DuplicateTrack, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT tracks.track_id, tracks.artist_id, artists.name, tracks.title
+		FROM tracks JOIN artists ON artists.artist_id = tracks.artist_id
+		WHERE tracks.track_id = ANY($1)
+		ORDER BY tracks.track_id;`
+
+	rows, err := r.db.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var track entities.DuplicateTrack
+		if err = rows.Scan(&track.TrackID, &track.ArtistID, &track.Artist, &track.Title); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, rows.Err()
+}
+
+// GetLyricSignatures непустые сигнатуры текстов треков по ID трека.
+func (r *DuplicatesRepository) GetLyricSignatures(ctx context.Context, ids []int) (signatures map[int][]uint64, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT track_id, signature FROM lyric_signatures WHERE track_id = ANY($1) AND cardinality(signature) > 0;`
+
+	rows, err := r.db.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	signatures = make(map[int][]uint64)
+	for rows.Next() {
+		var (
+			trackID int
+			values  []int64
+		)
+		if err = rows.Scan(&trackID, &values); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		signatures[trackID] = fromInt64s(values)
+	}
+
+	return signatures, rows.Err()
+}
+
+// MergeTracks переносит на target всё, что ссылается на треки sources, и удаляет их.
+//
+// Плейлисты, прослушивания, избранное, теги и файлы библиотеки переносятся всегда. Текст, аудио,
+// обложка и альбом берутся из первого трека sources, у которого они есть, только если их нет у target.
+// Blob объекты удалённых аудио и обложек остаются в хранилище, как и при удалении трека.
+func (r *DuplicatesRepository) MergeTracks(ctx context.Context, target int, sources []int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var locked int
+		sql := `SELECT COUNT(*) FROM (SELECT 1 FROM tracks WHERE track_id = ANY($1) FOR UPDATE) AS locked;`
+		if err = tx.QueryRow(ctx, sql, append([]int{target}, sources...)).Scan(&locked); err != nil {
+			return fmt.Errorf("failed to lock tracks: %w", err)
+		}
+		if locked != len(sources)+1 {
+			return domain.ErrTrackNotFound
+		}
+
+		for _, step := range mergeTracksSteps {
+			if _, err = tx.Exec(ctx, step.sql, target, sources); err != nil {
+				return fmt.Errorf("failed to merge %s: %w", step.name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// mergeTracksSteps запросы слияния, $1 - целевой трек, $2 - сливаемые треки. Порядок важен:
+// удаление треков последним, до него всё нужное должно быть перенесено.
+var mergeTracksSteps = []struct {
+	name string
+	sql  string
+}{
+	// В плейлисте после слияния остаются записи одного трека: целевого, если он там есть, иначе
+	// сливаемого с самой ранней записью. Иначе перенос дал бы в плейлисте несколько копий целевого трека.
+	{"playlist_tracks duplicates", `
+		WITH touched_playlists AS (
+			UPDATE playlists SET updated_at = NOW()
+			WHERE playlist_id IN (SELECT playlist_id FROM playlist_tracks WHERE track_id = ANY($2))
+		), kept AS (
+			SELECT DISTINCT ON (playlist_id) playlist_id, track_id FROM playlist_tracks
+			WHERE track_id = $1 OR track_id = ANY($2)
+			ORDER BY playlist_id, track_id = $1 DESC, position
+		)
+		DELETE FROM playlist_tracks USING kept
+		WHERE playlist_tracks.playlist_id = kept.playlist_id
+			AND playlist_tracks.track_id = ANY($2) AND playlist_tracks.track_id <> kept.track_id;`},
+	{"playlist_tracks", `UPDATE playlist_tracks SET track_id = $1 WHERE track_id = ANY($2);`},
+	// Прослушивание того же пользователя в то же время уже есть у целевого трека или у другого сливаемого.
+	{"scrobbles duplicates", `
+		DELETE FROM scrobbles
+		WHERE track_id = ANY($2) AND EXISTS (
+			SELECT 1 FROM scrobbles AS other
+			WHERE other.user_id = scrobbles.user_id AND other.played_at = scrobbles.played_at
+				AND (other.track_id = $1 OR (other.track_id = ANY($2) AND other.scrobble_id < scrobbles.scrobble_id))
+		);`},
+	{"scrobbles", `UPDATE scrobbles SET track_id = $1 WHERE track_id = ANY($2);`},
+	{"favorites", `
+		INSERT INTO favorites (user_id, track_id, created_at)
+		SELECT user_id, $1::INTEGER, MIN(created_at) FROM favorites WHERE track_id = ANY($2) GROUP BY user_id
+		ON CONFLICT (user_id, track_id) DO UPDATE SET created_at = LEAST(favorites.created_at, EXCLUDED.created_at);`},
+	{"track_tags", `
+		INSERT INTO track_tags (track_id, tag)
+		SELECT DISTINCT $1::INTEGER, tag FROM track_tags WHERE track_id = ANY($2)
+		ON CONFLICT (track_id, tag) DO NOTHING;`},
+	{"library_files", `UPDATE library_files SET track_id = $1 WHERE track_id = ANY($2);`},
+	// Сигнатура целевого трека без текста заменяется сигнатурой перенесённого текста.
+	{"lyric_signatures", `
+		WITH source AS (
+			SELECT track_id FROM lyrics
+			WHERE track_id = ANY($2) AND NOT EXISTS (SELECT 1 FROM lyrics WHERE track_id = $1)
+			LIMIT 1
+		), deleted_bands AS (
+			DELETE FROM lyric_bands WHERE track_id = $1 AND EXISTS (SELECT 1 FROM source)
+		)
+		DELETE FROM lyric_signatures WHERE track_id = $1 AND EXISTS (SELECT 1 FROM source);`},
+	{"lyrics", `
+		WITH source AS (
+			SELECT track_id FROM lyrics
+			WHERE track_id = ANY($2) AND NOT EXISTS (SELECT 1 FROM lyrics WHERE track_id = $1)
+			ORDER BY array_position($2, track_id) LIMIT 1
+		), moved_signature AS (
+			UPDATE lyric_signatures SET track_id = $1, updated_at = NOW() WHERE track_id = (SELECT track_id FROM source)
+		), moved_bands AS (
+			UPDATE lyric_bands SET track_id = $1 WHERE track_id = (SELECT track_id FROM source)
+		)
+		UPDATE lyrics SET track_id = $1 WHERE track_id = (SELECT track_id FROM source);`},
+	{"track_audio", `
+		WITH source AS (
+			SELECT track_id FROM track_audio
+			WHERE track_id = ANY($2) AND NOT EXISTS (SELECT 1 FROM track_audio WHERE track_id = $1)
+			ORDER BY array_position($2, track_id) LIMIT 1
+		), moved AS (
+			UPDATE track_audio SET track_id = $1 WHERE track_id = (SELECT track_id FROM source)
+		)
+		UPDATE tracks SET duration_ms = (SELECT duration_ms FROM tracks WHERE track_id = (SELECT track_id FROM source))
+		WHERE track_id = $1 AND EXISTS (SELECT 1 FROM source);`},
+	{"covers", `
+		UPDATE covers SET track_id = $1, updated_at = NOW()
+		WHERE track_id = (
+			SELECT track_id FROM covers
+			WHERE track_id = ANY($2) AND NOT EXISTS (SELECT 1 FROM covers WHERE track_id = $1)
+			ORDER BY array_position($2, track_id) LIMIT 1
+		);`},
+	{"album", `
+		UPDATE tracks SET album_id = (
+			SELECT album_id FROM tracks
+			WHERE track_id = ANY($2) AND album_id IS NOT NULL
+			ORDER BY array_position($2, track_id) LIMIT 1
+		)
+		WHERE track_id = $1 AND album_id IS NULL;`},
+	{"tracks", `DELETE FROM tracks WHERE track_id = ANY($2);`},
+}
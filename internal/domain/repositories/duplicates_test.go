@@ -0,0 +1,98 @@
+package repositories_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDB подключение к базе с применёнными миграциями из TEST_DATABASE_URL, без неё тест пропускается.
+func testDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	uri := os.Getenv("TEST_DATABASE_URL")
+	if uri == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := pgxpool.New(context.Background(), uri)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	return db
+}
+
+func TestMergeTracksSharedPlaylist(t *testing.T) {
+	t.Parallel()
+
+	db := testDB(t)
+	ctx := context.Background()
+
+	var artistID int
+	require.NoError(t, db.QueryRow(ctx,
+		`INSERT INTO artists (name) VALUES ('merge-shared-playlist ' || gen_random_uuid()) RETURNING artist_id;`,
+	).Scan(&artistID))
+	t.Cleanup(func() {
+		_, _ = db.Exec(context.Background(), `DELETE FROM artists WHERE artist_id = $1;`, artistID)
+	})
+
+	tracks := make([]int, 3)
+	for i, title := range []string{"Song", "Song (Remastered)", "Song - Live"} {
+		require.NoError(t, db.QueryRow(ctx,
+			`INSERT INTO tracks (artist_id, title, link, released_at) VALUES ($1, $2, '', NOW()) RETURNING track_id;`,
+			artistID, title,
+		).Scan(&tracks[i]))
+	}
+	target, sources := tracks[0], tracks[1:]
+
+	tests := []struct {
+		name     string
+		entries  []int
+		expected []int
+	}{
+		{"case: target and source", []int{sources[0], target}, []int{target}},
+		{"case: target and both sources", []int{target, sources[1], sources[0]}, []int{target}},
+		{"case: only sources", []int{sources[1], sources[0], sources[1]}, []int{target, target}},
+		{"case: target repeated", []int{target, sources[0], target}, []int{target, target}},
+		{"case: single source", []int{sources[0]}, []int{target}},
+	}
+
+	playlists := make([]int, len(tests))
+	for i, tt := range tests {
+		require.NoError(t, db.QueryRow(ctx,
+			`INSERT INTO playlists (name) VALUES ($1) RETURNING playlist_id;`, tt.name,
+		).Scan(&playlists[i]))
+		t.Cleanup(func() {
+			_, _ = db.Exec(context.Background(), `DELETE FROM playlists WHERE playlist_id = $1;`, playlists[i])
+		})
+
+		for position, trackID := range tt.entries {
+			_, err := db.Exec(ctx,
+				`INSERT INTO playlist_tracks (playlist_id, track_id, position) VALUES ($1, $2, $3);`,
+				playlists[i], trackID, position+1,
+			)
+			require.NoError(t, err)
+		}
+	}
+
+	require.NoError(t, repositories.NewDuplicatesRepository(db).MergeTracks(ctx, target, sources))
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := db.Query(ctx,
+				`SELECT track_id FROM playlist_tracks WHERE playlist_id = $1 ORDER BY position;`, playlists[i],
+			)
+			require.NoError(t, err)
+
+			entries, err := pgx.CollectRows(rows, pgx.RowTo[int])
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, entries)
+		})
+	}
+}
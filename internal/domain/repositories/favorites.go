@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+)
+
+type FavoritesRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFavoritesRepository(db *pgxpool.Pool) *FavoritesRepository {
+	return &FavoritesRepository{db: db}
+}
+
+// AddFavorite добавляет трек в избранное. Если трек уже там, возвращает время первого добавления и created = false.
+func (r *FavoritesRepository) AddFavorite(ctx context.Context, userID int, trackID int) (favoritedAt time.Time, created bool, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		INSERT INTO favorites (user_id, track_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, track_id) DO NOTHING
+		RETURNING created_at;`
+
+	err = r.db.QueryRow(ctx, sql, userID, trackID).Scan(&favoritedAt)
+	switch {
+	case err == nil:
+		return favoritedAt, true, nil
+	case errors.Is(err, pgx.ErrNoRows):
+	default:
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "favorites_track_id_fkey" {
+			return time.Time{}, false, domain.ErrTrackNotFound
+		}
+		return time.Time{}, false, fmt.Errorf("failed to insert favorites: %w", err)
+	}
+
+	sql = `SELECT created_at FROM favorites WHERE user_id = $1 AND track_id = $2;`
+	if err = r.db.QueryRow(ctx, sql, userID, trackID).Scan(&favoritedAt); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to select favorites: %w", err)
+	}
+
+	return favoritedAt, false, nil
+}
+
+func (r *FavoritesRepository) DeleteFavorite(ctx context.Context, userID int, trackID int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM favorites WHERE user_id = $1 AND track_id = $2;`, userID, trackID)
+	if err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrFavoriteNotFound
+	}
+
+	return nil
+}
+
+// GetFavorites возвращает избранные треки пользователя без текстов в порядке filter.Sort.
+func (r *FavoritesRepository) GetFavorites(ctx context.Context, filter entities.FavoriteGetListFilters) (favorites []entities.Favorite, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT
+			tracks.track_id, artists.name, tracks.title, tracks.released_at, tracks.link,
+			EXISTS(SELECT 1 FROM covers WHERE covers.track_id = tracks.track_id),
+			EXISTS(SELECT 1 FROM track_audio WHERE track_audio.track_id = tracks.track_id),
+			COALESCE(tracks.duration_ms, 0),
+			favorites.created_at
+		FROM favorites
+			JOIN tracks ON tracks.track_id = favorites.track_id
+			JOIN artists ON artists.artist_id = tracks.artist_id
+		WHERE favorites.user_id = $1
+		` + favoritesOrderBy(filter.Sort) + `
+		LIMIT $2 OFFSET $3;`
+
+	rows, err := r.db.Query(ctx, sql, filter.UserID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	favorites = make([]entities.Favorite, 0)
+	for rows.Next() {
+		var (
+			favorite   entities.Favorite
+			durationMs int64
+		)
+		if err = rows.Scan(
+			&favorite.Track.ID,
+			&favorite.Track.Artist,
+			&favorite.Track.Track,
+			&favorite.Track.Released,
+			&favorite.Track.Link,
+			&favorite.Track.HasCover,
+			&favorite.Track.HasAudio,
+			&durationMs,
+			&favorite.FavoritedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		favorite.Track.Duration = time.Duration(durationMs) * time.Millisecond
+		favorites = append(favorites, favorite)
+	}
+
+	return favorites, rows.Err()
+}
+
+// favoritesOrderBy по умолчанию новые первыми, остальные порядки как у списка треков.
+func favoritesOrderBy(sort entities.TrackSort) string {
+	field, desc := sort.Field()
+
+	switch {
+	case field == "":
+		return `ORDER BY favorites.created_at DESC, tracks.track_id DESC `
+	case field == entities.TrackSortFavorited && desc:
+		return `ORDER BY favorites.created_at DESC, tracks.track_id DESC `
+	case field == entities.TrackSortFavorited:
+		return `ORDER BY favorites.created_at ASC, tracks.track_id ASC `
+	default:
+		return tracksOrderBy(sort)
+	}
+}
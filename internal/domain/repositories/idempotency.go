@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type IdempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Acquire резервирует ключ под текущий запрос.
+//
+// Возвращает acquired = true, если ключа не было или он истёк, иначе текущую запись по ключу.
+func (r *IdempotencyRepository) Acquire(ctx context.Context, key string, requestHash string, ttl time.Duration) (record dao.IdempotencyKey, acquired bool, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		INSERT INTO idempotency_keys (key, request_hash, expires_at)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3))
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			response_status = NULL,
+			response_content_type = NULL,
+			response_body = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()
+		RETURNING key;`
+
+	if err = r.db.QueryRow(ctx, sql, key, requestHash, ttl.Seconds()).Scan(&record.Key); err == nil {
+		record.RequestHash = requestHash
+		return record, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return dao.IdempotencyKey{}, false, fmt.Errorf("failed to QueryRow(%s): %w", key, err)
+	}
+
+	if record, err = r.Get(ctx, key); err != nil {
+		return dao.IdempotencyKey{}, false, fmt.Errorf("failed to Get(%s): %w", key, err)
+	}
+
+	return record, false, nil
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, key string) (record dao.IdempotencyKey, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT
+			key,
+			request_hash,
+			COALESCE(response_status, 0),
+			COALESCE(response_content_type, ''),
+			response_body,
+			created_at,
+			expires_at
+		FROM idempotency_keys
+		WHERE key = $1 AND expires_at >= NOW();`
+
+	if err = r.db.QueryRow(ctx, sql, key).Scan(
+		&record.Key,
+		&record.RequestHash,
+		&record.ResponseStatus,
+		&record.ResponseContentType,
+		&record.ResponseBody,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.IdempotencyKey{}, domain.ErrIdempotencyKeyNotFound
+		}
+		return dao.IdempotencyKey{}, fmt.Errorf("failed to QueryRow(%s): %w", key, err)
+	}
+
+	return record, nil
+}
+
+// Complete сохраняет ответ по ключу.
+func (r *IdempotencyRepository) Complete(ctx context.Context, record dao.IdempotencyKey) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		UPDATE idempotency_keys
+		SET response_status = $2, response_content_type = $3, response_body = $4
+		WHERE key = $1;`
+
+	if _, err = r.db.Exec(ctx, sql,
+		record.Key,
+		record.ResponseStatus,
+		record.ResponseContentType,
+		record.ResponseBody,
+	); err != nil {
+		return fmt.Errorf("failed to Exec(%s): %w", record.Key, err)
+	}
+
+	return nil
+}
+
+// Release удаляет ключ, чтобы запрос можно было повторить (например, после 5xx).
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) (err error) {
+	sql := `DELETE FROM idempotency_keys WHERE key = $1;`
+
+	if _, err = r.db.Exec(ctx, sql, key); err != nil {
+		return fmt.Errorf("failed to Exec(%s): %w", key, err)
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет истёкшие ключи, возвращает количество удалённых.
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (deleted int64, err error) {
+	sql := `DELETE FROM idempotency_keys WHERE expires_at < NOW();`
+
+	tag, err := r.db.Exec(ctx, sql)
+	if err != nil {
+		return 0, fmt.Errorf("failed to Exec: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
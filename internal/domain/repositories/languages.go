@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+// SetLyricLanguage сохраняет язык текста трека в транзакции, изменившей текст.
+func (r *TracksRepository) SetLyricLanguage(ctx context.Context, tx pgx.Tx, language dao.LyricLanguage) (err error) {
+	return setLyricLanguage(ctx, tx, language)
+}
+
+type LanguagesRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLanguagesRepository(db *pgxpool.Pool) *LanguagesRepository {
+	return &LanguagesRepository{db: db}
+}
+
+// GetLyrics треки с ID больше afterID и их тексты (Lyric) по возрастанию ID. Если missingOnly,
+// возвращаются только треки без определённого языка.
+func (r *LanguagesRepository) GetLyrics(ctx context.Context, afterID int, limit int, missingOnly bool) (tracks []entities.Track, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT tracks.track_id,
+			COALESCE(array_agg(lyrics.verse_text ORDER BY lyrics.lyric_id) FILTER (WHERE lyrics.lyric_id IS NOT NULL), '{}')
+		FROM tracks LEFT JOIN lyrics ON lyrics.track_id = tracks.track_id
+		WHERE tracks.track_id > $1 AND (NOT $3::BOOLEAN OR tracks.lyric_language IS NULL)
+		GROUP BY tracks.track_id
+		ORDER BY tracks.track_id
+		LIMIT $2;`
+
+	rows, err := r.db.Query(ctx, sql, afterID, limit, missingOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var track entities.Track
+		if err = rows.Scan(&track.ID, &track.Lyric); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, rows.Err()
+}
+
+// SetLyricLanguages сохраняет языки текстов одной транзакцией.
+func (r *LanguagesRepository) SetLyricLanguages(ctx context.Context, languages []dao.LyricLanguage) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, language := range languages {
+			if err = setLyricLanguage(ctx, tx, language); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func setLyricLanguage(ctx context.Context, tx pgx.Tx, language dao.LyricLanguage) error {
+	sql := `
+		UPDATE tracks SET
+			lyric_language = NULLIF($2::TEXT, ''),
+			lyric_language_confidence = CASE WHEN $2::TEXT = '' THEN NULL ELSE $3::REAL END
+		WHERE track_id = $1;`
+
+	if _, err := tx.Exec(ctx, sql, language.TrackID, language.Language, language.Confidence); err != nil {
+		return fmt.Errorf("failed to update tracks.lyric_language: %w", err)
+	}
+
+	return nil
+}
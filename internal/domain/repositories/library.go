@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type LibraryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLibraryRepository(db *pgxpool.Pool) *LibraryRepository {
+	return &LibraryRepository{db: db}
+}
+
+// GetFiles возвращает отпечатки всех файлов, путь которых начинается с root.
+func (r *LibraryRepository) GetFiles(ctx context.Context, root string) (files []dao.LibraryFile, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT path, size, modified_at, COALESCE(track_id, 0), scanned_at
+		FROM library_files
+		WHERE starts_with(path, $1);`
+
+	rows, err := r.db.Query(ctx, sql, root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var file dao.LibraryFile
+	for rows.Next() {
+		if err = rows.Scan(&file.Path, &file.Size, &file.ModifiedAt, &file.TrackID, &file.ScannedAt); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	return files, rows.Err()
+}
+
+func (r *LibraryRepository) SaveFile(ctx context.Context, file dao.LibraryFile) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		INSERT INTO library_files (path, size, modified_at, track_id, scanned_at)
+		VALUES ($1, $2, $3, NULLIF($4, 0), NOW())
+		ON CONFLICT (path) DO UPDATE SET
+			size = EXCLUDED.size,
+			modified_at = EXCLUDED.modified_at,
+			track_id = EXCLUDED.track_id,
+			scanned_at = EXCLUDED.scanned_at;`
+
+	if _, err = r.db.Exec(ctx, sql, file.Path, file.Size, file.ModifiedAt, file.TrackID); err != nil {
+		return fmt.Errorf("failed to db.Exec(%s): %w", file.Path, err)
+	}
+
+	return nil
+}
+
+func (r *LibraryRepository) DeleteFiles(ctx context.Context, paths []string) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `DELETE FROM library_files WHERE path = ANY($1::text[]);`
+
+	if _, err = r.db.Exec(ctx, sql, paths); err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,454 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/translit"
+)
+
+// playlistPositionGap шаг между позициями соседних записей плейлиста.
+//
+// Новая запись встаёт посередине между соседями, поэтому в одно место можно вставить ~16 записей подряд,
+// прежде чем понадобится перенумерация.
+const playlistPositionGap int64 = 1 << 16
+
+type PlaylistsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPlaylistsRepository(db *pgxpool.Pool) *PlaylistsRepository {
+	return &PlaylistsRepository{db: db}
+}
+
+func (r *PlaylistsRepository) CreatePlaylist(ctx context.Context, playlist dao.Playlist) (id int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `INSERT INTO playlists (name, description) VALUES ($1, $2) RETURNING playlist_id;`
+
+	if err = r.db.QueryRow(ctx, sql, playlist.Name, playlist.Description).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetPlaylists возвращает страницу плейлистов, limit = 0 - страница по умолчанию.
+func (r *PlaylistsRepository) GetPlaylists(ctx context.Context, limit int, offset int) (playlists []dao.Playlist, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	const defaultLimit = 10
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	sql := `
+		SELECT
+			playlists.playlist_id, playlists.name, playlists.description,
+			(SELECT COUNT(*) FROM playlist_tracks WHERE playlist_tracks.playlist_id = playlists.playlist_id),
+			playlists.created_at, playlists.updated_at
+		FROM playlists
+		ORDER BY playlists.playlist_id
+		LIMIT $1 OFFSET $2;`
+
+	rows, err := r.db.Query(ctx, sql, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playlist dao.Playlist
+		if playlist, err = scanPlaylist(rows); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		playlists = append(playlists, playlist)
+	}
+
+	return playlists, rows.Err()
+}
+
+func (r *PlaylistsRepository) GetPlaylist(ctx context.Context, id int) (playlist dao.Playlist, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT
+			playlists.playlist_id, playlists.name, playlists.description,
+			(SELECT COUNT(*) FROM playlist_tracks WHERE playlist_tracks.playlist_id = playlists.playlist_id),
+			playlists.created_at, playlists.updated_at
+		FROM playlists
+		WHERE playlists.playlist_id = $1;`
+
+	if playlist, err = scanPlaylist(r.db.QueryRow(ctx, sql, id)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.Playlist{}, domain.ErrPlaylistNotFound
+		}
+		return dao.Playlist{}, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return playlist, nil
+}
+
+func scanPlaylist(row pgx.Row) (playlist dao.Playlist, err error) {
+	err = row.Scan(
+		&playlist.PlaylistID,
+		&playlist.Name,
+		&playlist.Description,
+		&playlist.TracksCount,
+		&playlist.CreatedAt,
+		&playlist.UpdatedAt,
+	)
+
+	return playlist, err
+}
+
+// GetPlaylistEntries возвращает записи плейлиста в порядке воспроизведения.
+func (r *PlaylistsRepository) GetPlaylistEntries(ctx context.Context, playlistID int) (entries []dao.PlaylistEntry, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT
+			playlist_tracks.entry_id, playlist_tracks.playlist_id, playlist_tracks.track_id,
+			artists.name, tracks.title, tracks.link, COALESCE(tracks.duration_ms, 0),
+			EXISTS(SELECT 1 FROM track_audio WHERE track_audio.track_id = tracks.track_id),
+			playlist_tracks.position, playlist_tracks.created_at
+		FROM playlist_tracks
+			JOIN tracks ON playlist_tracks.track_id = tracks.track_id
+			JOIN artists ON tracks.artist_id = artists.artist_id
+		WHERE playlist_tracks.playlist_id = $1
+		ORDER BY playlist_tracks.position;`
+
+	rows, err := r.db.Query(ctx, sql, playlistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var entry dao.PlaylistEntry
+	for rows.Next() {
+		if err = rows.Scan(
+			&entry.EntryID,
+			&entry.PlaylistID,
+			&entry.TrackID,
+			&entry.Artist,
+			&entry.Title,
+			&entry.Link,
+			&entry.DurationMs,
+			&entry.HasAudio,
+			&entry.Position,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// CreatePlaylistWithEntries создаёт плейлист сразу с треками в заданном порядке.
+func (r *PlaylistsRepository) CreatePlaylistWithEntries(ctx context.Context, playlist dao.Playlist, trackIDs []int) (id int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		sql := `INSERT INTO playlists (name, description) VALUES ($1, $2) RETURNING playlist_id;`
+		if err = tx.QueryRow(ctx, sql, playlist.Name, playlist.Description).Scan(&id); err != nil {
+			return fmt.Errorf("failed to insert playlists: %w", err)
+		}
+
+		sql = `
+			INSERT INTO playlist_tracks (playlist_id, track_id, position)
+			SELECT $1, entries.track_id, entries.ord * $3
+			FROM unnest($2::INTEGER[]) WITH ORDINALITY AS entries(track_id, ord);`
+		if _, err = tx.Exec(ctx, sql, id, trackIDs, playlistPositionGap); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.ConstraintName == "playlist_tracks_track_id_fkey" {
+				return domain.ErrTrackNotFound
+			}
+			return fmt.Errorf("failed to insert playlist_tracks: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// FindTracks ищет треки по парам исполнитель/название с точностью до name_key или ключа поиска
+// translit.SearchKey. Artist и Title в результате в нижнем регистре, для каждой пары возвращается
+// трек с наименьшим ID, совпадения по name_key важнее совпадений по ключу поиска. Ключ поиска сравнивается,
+// только если одно из имён в кириллице, см. transliteratedClause.
+func (r *PlaylistsRepository) FindTracks(ctx context.Context, tracks []entities.TrackCreate) (refs []dao.TrackRef, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	artists, titles := make([]string, len(tracks)), make([]string, len(tracks))
+	artistKeys, titleKeys := make([]string, len(tracks)), make([]string, len(tracks))
+	for i, track := range tracks {
+		artists[i], titles[i] = strings.ToLower(track.Artist), strings.ToLower(track.Title)
+		artistKeys[i], titleKeys[i] = translit.SearchKey(track.Artist), translit.SearchKey(track.Title)
+	}
+
+	sql := `
+		SELECT DISTINCT ON (wanted.artist, wanted.title)
+			tracks.track_id, wanted.artist, wanted.title
+		FROM unnest($1::TEXT[], $2::TEXT[], $3::TEXT[], $4::TEXT[]) AS wanted(artist, title, artist_key, title_key)
+			JOIN artists ON artists.name_key = name_key(wanted.artist)
+				OR (artists.search_key = NULLIF(wanted.artist_key, '') AND (wanted.artist ~ $5 OR artists.name ~ $5))
+			JOIN tracks ON tracks.artist_id = artists.artist_id
+				AND (tracks.title_key = name_key(wanted.title)
+					OR (tracks.title_search_key = NULLIF(wanted.title_key, '') AND (wanted.title ~ $5 OR tracks.title ~ $5)))
+		ORDER BY
+			wanted.artist, wanted.title,
+			artists.name_key = name_key(wanted.artist) AND tracks.title_key = name_key(wanted.title) DESC,
+			tracks.track_id;`
+
+	rows, err := r.db.Query(ctx, sql, artists, titles, artistKeys, titleKeys, translit.CyrillicPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var ref dao.TrackRef
+	for rows.Next() {
+		if err = rows.Scan(&ref.TrackID, &ref.Artist, &ref.Title); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, rows.Err()
+}
+
+// UpdatePlaylist меняет название и/или описание плейлиста, nil значения не меняются.
+func (r *PlaylistsRepository) UpdatePlaylist(ctx context.Context, id int, name *string, description *string) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		UPDATE playlists SET
+			name = COALESCE($2, name),
+			description = COALESCE($3, description),
+			updated_at = NOW()
+		WHERE playlist_id = $1;`
+
+	tag, err := r.db.Exec(ctx, sql, id, name, description)
+	if err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrPlaylistNotFound
+	}
+
+	return nil
+}
+
+// DeletePlaylist удаляет плейлист вместе с его записями.
+func (r *PlaylistsRepository) DeletePlaylist(ctx context.Context, id int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM playlists WHERE playlist_id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrPlaylistNotFound
+	}
+
+	return nil
+}
+
+// AddEntry вставляет трек в плейлист перед записью с порядковым номером index (index < 0 - в конец).
+func (r *PlaylistsRepository) AddEntry(ctx context.Context, playlistID int, trackID int, index int) (entryID int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err = touchPlaylist(ctx, tx, playlistID); err != nil {
+			return err
+		}
+
+		position, err := placeEntry(ctx, tx, playlistID, 0, index)
+		if err != nil {
+			return err
+		}
+
+		sql := `INSERT INTO playlist_tracks (playlist_id, track_id, position) VALUES ($1, $2, $3) RETURNING entry_id;`
+		if err = tx.QueryRow(ctx, sql, playlistID, trackID, position).Scan(&entryID); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.ConstraintName == "playlist_tracks_track_id_fkey" {
+				return domain.ErrTrackNotFound
+			}
+			return fmt.Errorf("failed to insert playlist_tracks: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return entryID, nil
+}
+
+// MoveEntry перемещает запись так, чтобы она стала index-ой по счёту (index < 0 - в конец).
+func (r *PlaylistsRepository) MoveEntry(ctx context.Context, playlistID int, entryID int, index int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err = touchPlaylist(ctx, tx, playlistID); err != nil {
+			return err
+		}
+
+		var exists bool
+		sql := `SELECT EXISTS(SELECT 1 FROM playlist_tracks WHERE playlist_id = $1 AND entry_id = $2);`
+		if err = tx.QueryRow(ctx, sql, playlistID, entryID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to select playlist entry: %w", err)
+		}
+		if !exists {
+			return domain.ErrPlaylistEntryNotFound
+		}
+
+		position, err := placeEntry(ctx, tx, playlistID, entryID, index)
+		if err != nil {
+			return err
+		}
+
+		sql = `UPDATE playlist_tracks SET position = $2 WHERE entry_id = $1;`
+		if _, err = tx.Exec(ctx, sql, entryID, position); err != nil {
+			return fmt.Errorf("failed to update playlist_tracks: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *PlaylistsRepository) DeleteEntry(ctx context.Context, playlistID int, entryID int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err = touchPlaylist(ctx, tx, playlistID); err != nil {
+			return err
+		}
+
+		sql := `DELETE FROM playlist_tracks WHERE playlist_id = $1 AND entry_id = $2;`
+		tag, err := tx.Exec(ctx, sql, playlistID, entryID)
+		if err != nil {
+			return fmt.Errorf("failed to delete playlist_tracks: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrPlaylistEntryNotFound
+		}
+
+		return nil
+	})
+}
+
+// touchPlaylist обновляет updated_at плейлиста и блокирует его строку до конца транзакции,
+// чтобы параллельные изменения записей одного плейлиста не выбрали одну и ту же позицию.
+func touchPlaylist(ctx context.Context, tx pgx.Tx, playlistID int) error {
+	tag, err := tx.Exec(ctx, `UPDATE playlists SET updated_at = NOW() WHERE playlist_id = $1;`, playlistID)
+	if err != nil {
+		return fmt.Errorf("failed to update playlists: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrPlaylistNotFound
+	}
+
+	return nil
+}
+
+// placeEntry подбирает позицию для записи, которая должна стать index-ой по счёту среди остальных записей
+// плейлиста (запись excludeEntryID, если она уже в плейлисте, не учитывается).
+//
+// Обычно позиция берётся посередине между соседями. Если места между ними не осталось, записи плейлиста
+// перенумеровываются с шагом playlistPositionGap, запись excludeEntryID сразу получает новую позицию.
+func placeEntry(ctx context.Context, tx pgx.Tx, playlistID int, excludeEntryID int, index int) (position int64, err error) {
+	var count int
+	sql := `SELECT COUNT(*) FROM playlist_tracks WHERE playlist_id = $1 AND entry_id <> $2;`
+	if err = tx.QueryRow(ctx, sql, playlistID, excludeEntryID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count playlist entries: %w", err)
+	}
+	if index < 0 || index > count {
+		index = count
+	}
+	if count == 0 {
+		return playlistPositionGap, nil
+	}
+
+	sql = `
+		SELECT position FROM playlist_tracks
+		WHERE playlist_id = $1 AND entry_id <> $2
+		ORDER BY position
+		OFFSET $3 LIMIT 2;`
+	rows, err := tx.Query(ctx, sql, playlistID, excludeEntryID, max(index-1, 0))
+	if err != nil {
+		return 0, fmt.Errorf("failed to select neighbour positions: %w", err)
+	}
+
+	var neighbours []int64
+	for rows.Next() {
+		if err = rows.Scan(&position); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		neighbours = append(neighbours, position)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to select neighbour positions: %w", err)
+	}
+
+	switch {
+	case index == 0 && neighbours[0] > math.MinInt64+playlistPositionGap:
+		return neighbours[0] - playlistPositionGap, nil
+	case index == count && neighbours[0] < math.MaxInt64-playlistPositionGap:
+		return neighbours[0] + playlistPositionGap, nil
+	case index > 0 && index < count && neighbours[1]-neighbours[0] > 1:
+		return neighbours[0] + (neighbours[1]-neighbours[0])/2, nil
+	}
+
+	// Перенумерация оставляет дырку на месте index. Перемещаемая запись обновляется тем же запросом,
+	// иначе её старая позиция может совпасть с новой позицией соседа.
+	position = int64(index+1) * playlistPositionGap
+	sql = `
+		WITH ranked AS (
+			SELECT entry_id, ROW_NUMBER() OVER (ORDER BY position) AS rn
+			FROM playlist_tracks
+			WHERE playlist_id = $1 AND entry_id <> $2
+			UNION ALL
+			SELECT $2, NULL
+		)
+		UPDATE playlist_tracks SET
+			position = CASE
+				WHEN ranked.rn IS NULL THEN $4
+				WHEN ranked.rn > $3 THEN (ranked.rn + 1) * $5
+				ELSE ranked.rn * $5
+			END
+		FROM ranked
+		WHERE playlist_tracks.entry_id = ranked.entry_id;`
+	if _, err = tx.Exec(ctx, sql, playlistID, excludeEntryID, index, position, playlistPositionGap); err != nil {
+		return 0, fmt.Errorf("failed to renumber playlist entries: %w", err)
+	}
+
+	return position, nil
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RateLimitsRepository хранилище счётчиков pkg/ratelimit в Postgres, общее для всех экземпляров сервиса.
+type RateLimitsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRateLimitsRepository(db *pgxpool.Pool) *RateLimitsRepository {
+	return &RateLimitsRepository{db: db}
+}
+
+// Incr реализует ratelimit.Store. Счётчик прошлого окна сбрасывается в той же строке.
+func (r *RateLimitsRepository) Incr(ctx context.Context, key string, n int64, windowStart time.Time, window time.Duration) (count int64, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		INSERT INTO rate_limit_counters (key, window_start, count, expires_at)
+		VALUES ($1, $2, $4, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN rate_limit_counters.window_start = EXCLUDED.window_start
+				THEN rate_limit_counters.count + EXCLUDED.count ELSE EXCLUDED.count END,
+			window_start = EXCLUDED.window_start,
+			expires_at = EXCLUDED.expires_at
+		RETURNING count;`
+
+	windowStart = windowStart.UTC()
+	if err = r.db.QueryRow(ctx, sql, key, windowStart, windowStart.Add(window), n).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to db.QueryRow(%s): %w", key, err)
+	}
+
+	return count, nil
+}
+
+// DeleteExpired удаляет счётчики закончившихся окон, возвращает количество удалённых.
+func (r *RateLimitsRepository) DeleteExpired(ctx context.Context) (deleted int64, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM rate_limit_counters WHERE expires_at < NOW() AT TIME ZONE 'UTC';`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to db.Exec: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type ScrobblesRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewScrobblesRepository(db *pgxpool.Pool) *ScrobblesRepository {
+	return &ScrobblesRepository{db: db}
+}
+
+// GetTrackDurations возвращает длительность существующих треков из ids, 0 если аудио не загружено.
+func (r *ScrobblesRepository) GetTrackDurations(ctx context.Context, ids []int) (durations map[int]time.Duration, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT track_id, COALESCE(duration_ms, 0) FROM tracks WHERE track_id = ANY($1);`
+
+	rows, err := r.db.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	durations = make(map[int]time.Duration, len(ids))
+	for rows.Next() {
+		var (
+			id         int
+			durationMs int64
+		)
+		if err = rows.Scan(&id, &durationMs); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		durations[id] = time.Duration(durationMs) * time.Millisecond
+	}
+
+	return durations, rows.Err()
+}
+
+// InsertScrobbles сохраняет прослушивания пользователя по порядку. Прослушивание того же трека
+// ближе dedupWindow к уже сохранённому (в том числе из этого же пакета) не сохраняется, inserted[i] = false.
+func (r *ScrobblesRepository) InsertScrobbles(ctx context.Context, userID int, scrobbles []dao.Scrobble, dedupWindow time.Duration) (inserted []bool, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	inserted = make([]bool, len(scrobbles))
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Параллельные пакеты одного пользователя иначе не увидят вставки друг друга при проверке окна.
+		if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('scrobbles'), $1);`, userID); err != nil {
+			return fmt.Errorf("failed to pg_advisory_xact_lock: %w", err)
+		}
+
+		sql := `
+			INSERT INTO scrobbles (user_id, track_id, played_at, duration_played_ms)
+			SELECT $1, $2, $3, $4
+			WHERE NOT EXISTS (
+				SELECT 1 FROM scrobbles
+				WHERE user_id = $1 AND track_id = $2
+					AND played_at > $3::TIMESTAMP - make_interval(secs => $5)
+					AND played_at < $3::TIMESTAMP + make_interval(secs => $5)
+			)
+			ON CONFLICT DO NOTHING;`
+
+		for i, scrobble := range scrobbles {
+			tag, err := tx.Exec(ctx, sql,
+				userID, scrobble.TrackID, scrobble.PlayedAt.UTC(), scrobble.DurationPlayedMs, dedupWindow.Seconds())
+			if err != nil {
+				return fmt.Errorf("failed to insert scrobbles: %w", err)
+			}
+			inserted[i] = tag.RowsAffected() > 0
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inserted, nil
+}
+
+// GetHistory возвращает не больше filter.Limit прослушиваний от новых к старым.
+func (r *ScrobblesRepository) GetHistory(ctx context.Context, filter entities.HistoryFilters) (scrobbles []entities.Scrobble, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	var (
+		sqlBase strings.Builder
+		args    = []any{filter.UserID}
+	)
+
+	sqlBase.WriteString(`
+		SELECT scrobbles.scrobble_id, scrobbles.track_id, artists.name, tracks.title,
+			scrobbles.played_at, scrobbles.duration_played_ms
+		FROM scrobbles
+			JOIN tracks ON tracks.track_id = scrobbles.track_id
+			JOIN artists ON artists.artist_id = tracks.artist_id
+		WHERE scrobbles.user_id = $1`)
+
+	if !filter.From.IsZero() {
+		args = append(args, filter.From.UTC())
+		sqlBase.WriteString(fmt.Sprintf(` AND scrobbles.played_at >= $%d`, len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To.UTC())
+		sqlBase.WriteString(fmt.Sprintf(` AND scrobbles.played_at < $%d`, len(args)))
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.PlayedAt.UTC(), filter.After.ID)
+		sqlBase.WriteString(fmt.Sprintf(` AND (scrobbles.played_at, scrobbles.scrobble_id) < ($%d, $%d)`, len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	sqlBase.WriteString(fmt.Sprintf(`
+		ORDER BY scrobbles.played_at DESC, scrobbles.scrobble_id DESC
+		LIMIT $%d;`, len(args)))
+
+	rows, err := r.db.Query(ctx, sqlBase.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	scrobbles = make([]entities.Scrobble, 0)
+	for rows.Next() {
+		var (
+			scrobble         entities.Scrobble
+			durationPlayedMs int64
+		)
+		if err = rows.Scan(
+			&scrobble.ID, &scrobble.TrackID, &scrobble.Artist, &scrobble.Title, &scrobble.PlayedAt, &durationPlayedMs,
+		); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		scrobble.DurationPlayed = time.Duration(durationPlayedMs) * time.Millisecond
+		scrobbles = append(scrobbles, scrobble)
+	}
+
+	return scrobbles, rows.Err()
+}
+
+// EnsurePartitions создаёт месячные секции scrobbles с месяца from на months месяцев вперёд.
+//
+// Секция не создаётся, если в DEFAULT секции уже есть строки её диапазона: Postgres не даст
+// создать её, не перенося строки. В skipped возвращаются все месяцы, строки которых лежат
+// в DEFAULT, в том числе прошедшие, - их нужно перенести в секции вручную.
+func (r *ScrobblesRepository) EnsurePartitions(ctx context.Context, from time.Time, months int) (skipped []string, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT DISTINCT 'scrobbles_' || to_char(played_at, 'YYYY_MM') FROM scrobbles_default ORDER BY 1;`
+	rows, err := r.db.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check scrobbles_default: %w", err)
+	}
+	if skipped, err = pgx.CollectRows(rows, pgx.RowTo[string]); err != nil {
+		return nil, fmt.Errorf("failed to check scrobbles_default: %w", err)
+	}
+
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i <= months; i++ {
+		lower, upper := start.AddDate(0, i, 0), start.AddDate(0, i+1, 0)
+		name := "scrobbles_" + lower.Format("2006_01")
+		if slices.Contains(skipped, name) {
+			continue
+		}
+
+		// Имя и границы формируются из дат, а не из ввода, DDL не принимает параметры.
+		sql = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF scrobbles FOR VALUES FROM ('%s') TO ('%s');`,
+			pgx.Identifier{name}.Sanitize(), lower.Format(time.DateOnly), upper.Format(time.DateOnly))
+		if _, err = r.db.Exec(ctx, sql); err != nil {
+			return skipped, fmt.Errorf("failed to create %s: %w", name, err)
+		}
+	}
+
+	return skipped, nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/pkg/translit"
+)
+
+// searchKeyColumns таблицы с ключами поиска translit.SearchKey: ID, исходная колонка и колонка ключа.
+var searchKeyColumns = []struct {
+	table, id, source, key string
+}{
+	{"artists", "artist_id", "name", "search_key"},
+	{"tracks", "track_id", "title", "title_search_key"},
+}
+
+type SearchKeysRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSearchKeysRepository(db *pgxpool.Pool) *SearchKeysRepository {
+	return &SearchKeysRepository{db: db}
+}
+
+// FillMissing заполняет ключи поиска у строк, созданных до их появления, пакетами по batch строк.
+// Возвращает количество заполненных ключей.
+func (r *SearchKeysRepository) FillMissing(ctx context.Context, batch int) (filled int, err error) {
+	for _, columns := range searchKeyColumns {
+		for {
+			n, err := r.fillBatch(ctx, columns.table, columns.id, columns.source, columns.key, batch)
+			filled += n
+			if err != nil {
+				return filled, fmt.Errorf("failed to fill %s.%s: %w", columns.table, columns.key, err)
+			}
+			if n < batch {
+				break
+			}
+		}
+	}
+
+	return filled, nil
+}
+
+func (r *SearchKeysRepository) fillBatch(ctx context.Context, table, id, source, key string, limit int) (filled int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := fmt.Sprintf(`SELECT %[2]s, %[3]s FROM %[1]s WHERE %[4]s IS NULL ORDER BY %[2]s LIMIT $1;`, table, id, source, key)
+
+	rows, err := r.db.Query(ctx, sql, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		ids  []int
+		keys []string
+
+		rowID int
+		value string
+	)
+	for rows.Next() {
+		if err = rows.Scan(&rowID, &value); err != nil {
+			return 0, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		ids, keys = append(ids, rowID), append(keys, translit.SearchKey(value))
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to rows.Next: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	sql = fmt.Sprintf(`
+		UPDATE %[1]s SET %[3]s = input.key
+		FROM unnest($1::INTEGER[], $2::TEXT[]) AS input(id, key)
+		WHERE %[1]s.%[2]s = input.id;`, table, id, key)
+
+	if _, err = r.db.Exec(ctx, sql, ids, keys); err != nil {
+		return 0, fmt.Errorf("failed to db.Exec: %w", err)
+	}
+
+	return len(ids), nil
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+// SetLyricSignature заменяет сигнатуру текста трека в транзакции, изменившей текст.
+func (r *TracksRepository) SetLyricSignature(ctx context.Context, tx pgx.Tx, signature dao.LyricSignature) (err error) {
+	return setLyricSignature(ctx, tx, signature)
+}
+
+type SimilarityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSimilarityRepository(db *pgxpool.Pool) *SimilarityRepository {
+	return &SimilarityRepository{db: db}
+}
+
+// GetLyricSignature возвращает сигнатуру трека и его исполнителя. Сигнатура пустая,
+// если у трека нет текста или он ещё не проиндексирован.
+func (r *SimilarityRepository) GetLyricSignature(ctx context.Context, trackID int) (signature dao.LyricSignature, artistID int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT tracks.artist_id, COALESCE(lyric_signatures.signature, '{}')
+		FROM tracks LEFT JOIN lyric_signatures ON lyric_signatures.track_id = tracks.track_id
+		WHERE tracks.track_id = $1;`
+
+	var values []int64
+	if err = r.db.QueryRow(ctx, sql, trackID).Scan(&artistID, &values); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.LyricSignature{}, 0, domain.ErrTrackNotFound
+		}
+		return dao.LyricSignature{}, 0, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return dao.LyricSignature{TrackID: trackID, Signature: fromInt64s(values)}, artistID, nil
+}
+
+// GetSimilarCandidates треки, у которых хотя бы одна полоса совпадает с bands, в порядке
+// убывания количества совпавших полос.
+func (r *SimilarityRepository) GetSimilarCandidates(ctx context.Context, trackID int, bands []uint64, limit int) (candidates []dao.SimilarCandidate, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT shared.track_id, tracks.artist_id, artists.name, tracks.title, lyric_signatures.signature
+		FROM (
+			SELECT lyric_bands.track_id, COUNT(*) AS bands
+			FROM lyric_bands
+				JOIN unnest($2::BIGINT[]) WITH ORDINALITY AS target(hash, band)
+					ON lyric_bands.band = target.band - 1 AND lyric_bands.hash = target.hash
+			WHERE lyric_bands.track_id <> $1
+			GROUP BY lyric_bands.track_id
+			ORDER BY bands DESC, lyric_bands.track_id
+			LIMIT $3
+		) AS shared
+			JOIN lyric_signatures ON lyric_signatures.track_id = shared.track_id
+			JOIN tracks ON tracks.track_id = shared.track_id
+			JOIN artists ON artists.artist_id = tracks.artist_id
+		ORDER BY shared.bands DESC, shared.track_id;`
+
+	rows, err := r.db.Query(ctx, sql, trackID, toInt64s(bands), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			candidate dao.SimilarCandidate
+			values    []int64
+		)
+		if err = rows.Scan(&candidate.TrackID, &candidate.ArtistID, &candidate.Artist, &candidate.Title, &values); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		candidate.Signature = fromInt64s(values)
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// GetUnindexedLyrics треки без сигнатуры с текстами (Lyric), по возрастанию ID.
+func (r *SimilarityRepository) GetUnindexedLyrics(ctx context.Context, limit int) (tracks []entities.Track, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT tracks.track_id,
+			COALESCE(array_agg(lyrics.verse_text ORDER BY lyrics.lyric_id) FILTER (WHERE lyrics.lyric_id IS NOT NULL), '{}')
+		FROM tracks LEFT JOIN lyrics ON lyrics.track_id = tracks.track_id
+		WHERE NOT EXISTS (SELECT 1 FROM lyric_signatures WHERE lyric_signatures.track_id = tracks.track_id)
+		GROUP BY tracks.track_id
+		ORDER BY tracks.track_id
+		LIMIT $1;`
+
+	rows, err := r.db.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var track entities.Track
+		if err = rows.Scan(&track.ID, &track.Lyric); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, rows.Err()
+}
+
+// SetLyricSignatures сохраняет сигнатуры одной транзакцией.
+func (r *SimilarityRepository) SetLyricSignatures(ctx context.Context, signatures []dao.LyricSignature) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, signature := range signatures {
+			if err = setLyricSignature(ctx, tx, signature); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func setLyricSignature(ctx context.Context, tx pgx.Tx, signature dao.LyricSignature) error {
+	sql := `
+		INSERT INTO lyric_signatures (track_id, signature) VALUES ($1, $2)
+		ON CONFLICT (track_id) DO UPDATE SET signature = EXCLUDED.signature, updated_at = NOW();`
+
+	if _, err := tx.Exec(ctx, sql, signature.TrackID, toInt64s(signature.Signature)); err != nil {
+		return fmt.Errorf("failed to upsert lyric_signatures: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM lyric_bands WHERE track_id = $1;`, signature.TrackID); err != nil {
+		return fmt.Errorf("failed to delete lyric_bands: %w", err)
+	}
+
+	sql = `
+		INSERT INTO lyric_bands (track_id, band, hash)
+		SELECT $1, band.ord - 1, band.hash FROM unnest($2::BIGINT[]) WITH ORDINALITY AS band(hash, ord);`
+
+	if _, err := tx.Exec(ctx, sql, signature.TrackID, toInt64s(signature.Bands)); err != nil {
+		return fmt.Errorf("failed to insert lyric_bands: %w", err)
+	}
+
+	return nil
+}
+
+// toInt64s и fromInt64s переводят хеши в BIGINT и обратно без потери битов.
+func toInt64s(values []uint64) []int64 {
+	result := make([]int64, len(values))
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+
+	return result
+}
+
+func fromInt64s(values []int64) []uint64 {
+	result := make([]uint64, len(values))
+	for i, v := range values {
+		result[i] = uint64(v)
+	}
+
+	return result
+}
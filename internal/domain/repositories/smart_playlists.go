@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type SmartPlaylistsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSmartPlaylistsRepository(db *pgxpool.Pool) *SmartPlaylistsRepository {
+	return &SmartPlaylistsRepository{db: db}
+}
+
+func (r *SmartPlaylistsRepository) CreateSmartPlaylist(ctx context.Context, playlist dao.SmartPlaylist) (id int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		INSERT INTO smart_playlists (name, rules, sort, tracks_limit)
+		VALUES ($1, $2, $3, $4)
+		RETURNING smart_playlist_id;`
+
+	if err = r.db.QueryRow(ctx, sql, playlist.Name, playlist.Rules, playlist.Sort, playlist.Limit).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetSmartPlaylists возвращает страницу смарт-плейлистов, limit = 0 - страница по умолчанию.
+func (r *SmartPlaylistsRepository) GetSmartPlaylists(ctx context.Context, limit int, offset int) (playlists []dao.SmartPlaylist, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	const defaultLimit = 10
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	sql := `
+		SELECT smart_playlist_id, name, rules, sort, tracks_limit, created_at, updated_at
+		FROM smart_playlists
+		ORDER BY smart_playlist_id
+		LIMIT $1 OFFSET $2;`
+
+	rows, err := r.db.Query(ctx, sql, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playlist dao.SmartPlaylist
+		if playlist, err = scanSmartPlaylist(rows); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		playlists = append(playlists, playlist)
+	}
+
+	return playlists, rows.Err()
+}
+
+func (r *SmartPlaylistsRepository) GetSmartPlaylist(ctx context.Context, id int) (playlist dao.SmartPlaylist, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT smart_playlist_id, name, rules, sort, tracks_limit, created_at, updated_at
+		FROM smart_playlists
+		WHERE smart_playlist_id = $1;`
+
+	if playlist, err = scanSmartPlaylist(r.db.QueryRow(ctx, sql, id)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.SmartPlaylist{}, domain.ErrSmartPlaylistNotFound
+		}
+		return dao.SmartPlaylist{}, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return playlist, nil
+}
+
+func scanSmartPlaylist(row pgx.Row) (playlist dao.SmartPlaylist, err error) {
+	err = row.Scan(
+		&playlist.SmartPlaylistID,
+		&playlist.Name,
+		&playlist.Rules,
+		&playlist.Sort,
+		&playlist.Limit,
+		&playlist.CreatedAt,
+		&playlist.UpdatedAt,
+	)
+
+	return playlist, err
+}
+
+func (r *SmartPlaylistsRepository) UpdateSmartPlaylist(ctx context.Context, playlist dao.SmartPlaylist) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		UPDATE smart_playlists SET
+			name = $2,
+			rules = $3,
+			sort = $4,
+			tracks_limit = $5,
+			updated_at = NOW()
+		WHERE smart_playlist_id = $1;`
+
+	tag, err := r.db.Exec(ctx, sql, playlist.SmartPlaylistID, playlist.Name, playlist.Rules, playlist.Sort, playlist.Limit)
+	if err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSmartPlaylistNotFound
+	}
+
+	return nil
+}
+
+func (r *SmartPlaylistsRepository) DeleteSmartPlaylist(ctx context.Context, id int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM smart_playlists WHERE smart_playlist_id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSmartPlaylistNotFound
+	}
+
+	return nil
+}
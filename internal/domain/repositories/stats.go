@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+)
+
+// statsViews материализованные представления /stats в порядке обновления.
+var statsViews = []string{
+	"stats_tracks_per_year",
+	"stats_artists",
+	"stats_track_plays_daily",
+	"stats_lyrics",
+	"stats_newest_tracks",
+}
+
+type StatsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStatsRepository(db *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// RefreshViews обновляет витрины по одной. CONCURRENTLY не блокирует чтение во время обновления.
+func (r *StatsRepository) RefreshViews(ctx context.Context) (err error) {
+	for _, view := range statsViews {
+		if err = r.refreshView(ctx, view); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *StatsRepository) refreshView(ctx context.Context, view string) error {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `REFRESH MATERIALIZED VIEW CONCURRENTLY ` + pgx.Identifier{view}.Sanitize() + `;`
+	if _, err := r.db.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("failed to refresh %s: %w", view, err)
+	}
+
+	return nil
+}
+
+func (r *StatsRepository) GetTracksPerYear(ctx context.Context) (stats []entities.YearStat, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	rows, err := r.db.Query(ctx, `SELECT year, tracks FROM stats_tracks_per_year ORDER BY year;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	stats = make([]entities.YearStat, 0)
+	for rows.Next() {
+		var stat entities.YearStat
+		if err = rows.Scan(&stat.Year, &stat.Tracks); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *StatsRepository) GetTopArtists(ctx context.Context, limit int) (stats []entities.ArtistStat, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT artist_id, name, tracks FROM stats_artists ORDER BY tracks DESC, artist_id LIMIT $1;`
+
+	rows, err := r.db.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	stats = make([]entities.ArtistStat, 0)
+	for rows.Next() {
+		var stat entities.ArtistStat
+		if err = rows.Scan(&stat.ArtistID, &stat.Artist, &stat.Tracks); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+// GetTopTracks самые прослушиваемые треки с дня since включительно. Треки, удалённые после
+// обновления витрины, пропускаются.
+func (r *StatsRepository) GetTopTracks(ctx context.Context, since time.Time, limit int) (stats []entities.TrackPlaysStat, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT plays.track_id, artists.name, tracks.title, plays.total
+		FROM (
+			SELECT track_id, SUM(plays) AS total
+			FROM stats_track_plays_daily
+			WHERE day >= $1::DATE
+			GROUP BY track_id
+		) AS plays
+			JOIN tracks ON tracks.track_id = plays.track_id
+			JOIN artists ON artists.artist_id = tracks.artist_id
+		ORDER BY plays.total DESC, plays.track_id
+		LIMIT $2;`
+
+	rows, err := r.db.Query(ctx, sql, since.UTC().Format(time.DateOnly), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	stats = make([]entities.TrackPlaysStat, 0)
+	for rows.Next() {
+		var stat entities.TrackPlaysStat
+		if err = rows.Scan(&stat.TrackID, &stat.Artist, &stat.Title, &stat.Plays); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *StatsRepository) GetLyricsStat(ctx context.Context) (stat entities.LyricsStat, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT tracks, tracks_with_lyrics, verses FROM stats_lyrics;`
+
+	if err = r.db.QueryRow(ctx, sql).Scan(&stat.Tracks, &stat.TracksWithLyrics, &stat.Verses); err != nil {
+		return entities.LyricsStat{}, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return stat, nil
+}
+
+func (r *StatsRepository) GetNewestTracks(ctx context.Context, limit int) (tracks []entities.NewestTrack, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `
+		SELECT track_id, artist, title, released_at, created_at
+		FROM stats_newest_tracks
+		ORDER BY created_at DESC, track_id DESC
+		LIMIT $1;`
+
+	rows, err := r.db.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	tracks = make([]entities.NewestTrack, 0)
+	for rows.Next() {
+		var track entities.NewestTrack
+		if err = rows.Scan(&track.TrackID, &track.Artist, &track.Title, &track.Released, &track.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, rows.Err()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/neyrzx/youmusic/internal/domain/entities"
 	domain "github.com/neyrzx/youmusic/internal/domain/errors"
 	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/translit"
 )
 
 const queryTimeout = 120 * time.Second
@@ -92,11 +93,17 @@ func (r *TracksRepository) CreateLyricFromSlice(ctx context.Context, tx pgx.Tx,
 	return nil
 }
 
+// CreateArtist создаёт исполнителя. Если исполнитель с тем же name_key уже есть (например, создан
+// параллельным запросом или entities.NameKey разошёлся с SQL name_key), возвращает domain.ErrArtistAlreadyExists.
 func (r *TracksRepository) CreateArtist(ctx context.Context, tx pgx.Tx, artist dao.Artist) (id int, err error) {
 	sql := `
-		INSERT INTO artists (name) VALUES ($1) RETURNING artist_id;`
+		INSERT INTO artists (name, search_key) VALUES ($1, $2) RETURNING artist_id;`
 
-	if err = tx.QueryRow(ctx, sql, artist.Name).Scan(&id); err != nil {
+	if err = tx.QueryRow(ctx, sql, artist.Name, translit.SearchKey(artist.Name)).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "artists_name_key_unique" {
+			return 0, domain.ErrArtistAlreadyExists
+		}
 		return 0, fmt.Errorf("failed to rows.Scan: %w", err)
 	}
 
@@ -108,11 +115,13 @@ func (r *TracksRepository) CreateTrack(ctx context.Context, tx pgx.Tx, track dao
 	defer cancelFunc()
 
 	sql := `
-		INSERT INTO tracks (title, artist_id, link, released_at) VALUES ($1, $2, $3, $4) RETURNING track_id;`
+		INSERT INTO tracks (title, title_search_key, artist_id, link, released_at, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING track_id;`
 
-	if err = tx.QueryRow(ctx, sql, track.Title, track.ArtistID, track.Link, track.ReleasedAt).Scan(&id); err != nil {
+	err = tx.QueryRow(ctx, sql, track.Title, translit.SearchKey(track.Title), track.ArtistID, track.Link, track.ReleasedAt, track.CreatedBy).Scan(&id)
+	if err != nil {
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.ConstraintName == "tracks_title_artist_id_unique" {
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "tracks_title_key_artist_id_unique" {
 			return 0, domain.ErrTrackAlreadyExists
 		}
 		return 0, fmt.Errorf("failed to rows.Scan: %w", err)
@@ -132,7 +141,7 @@ func (r *TracksRepository) IsTrackExists(ctx context.Context, track string, arti
 			FROM
 				tracks JOIN artists ON tracks.artist_id = artists.artist_id
 			WHERE
-				tracks.title = $1 AND artists.name = $2
+				tracks.title_key = name_key($1) AND artists.name_key = name_key($2)
 		);`
 
 	if err = r.db.QueryRow(ctx, sql, track, artist).Scan(&exists); err != nil {
@@ -151,7 +160,7 @@ func (r *TracksRepository) IsArtistExists(ctx context.Context, tx pgx.Tx, name s
 	defer cancelFunc()
 
 	sql := `
-		SELECT artist_id FROM artists WHERE name = $1;`
+		SELECT artist_id FROM artists WHERE name_key = name_key($1);`
 
 	if err := tx.QueryRow(ctx, sql, name).Scan(&id); err != nil {
 		return 0, false
@@ -160,6 +169,195 @@ func (r *TracksRepository) IsArtistExists(ctx context.Context, tx pgx.Tx, name s
 	return id, true
 }
 
+// GetExistingTracks возвращает те пары название + исполнитель из tracks, которые уже есть в базе
+// (с точностью до name_key). Пары возвращаются в том виде, в котором переданы.
+func (r *TracksRepository) GetExistingTracks(ctx context.Context, tracks []entities.TrackCreate) (existing []entities.TrackCreate, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	if len(tracks) == 0 {
+		return nil, nil
+	}
+
+	titles := make([]string, len(tracks))
+	artists := make([]string, len(tracks))
+	for i, track := range tracks {
+		titles[i], artists[i] = track.Title, track.Artist
+	}
+
+	sql := `
+		SELECT
+			input.title, input.artist
+		FROM
+			tracks JOIN artists ON tracks.artist_id = artists.artist_id
+			JOIN unnest($1::text[], $2::text[]) AS input(title, artist)
+				ON tracks.title_key = name_key(input.title) AND artists.name_key = name_key(input.artist);`
+
+	rows, err := r.db.Query(ctx, sql, titles, artists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var track entities.TrackCreate
+	for rows.Next() {
+		if err = rows.Scan(&track.Title, &track.Artist); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		existing = append(existing, track)
+	}
+
+	return existing, rows.Err()
+}
+
+// GetOrCreateArtists возвращает идентификаторы исполнителей по именам (ключи - имена из names),
+// создавая недостающих. Имена с одинаковым name_key относятся к одному исполнителю.
+func (r *TracksRepository) GetOrCreateArtists(ctx context.Context, tx pgx.Tx, names []string) (ids map[string]int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = translit.SearchKey(name)
+	}
+
+	sql := `
+		INSERT INTO artists (name, search_key)
+		SELECT DISTINCT ON (name_key(name)) name, search_key FROM unnest($1::text[], $2::text[]) AS input(name, search_key)
+		ON CONFLICT (name_key) DO NOTHING;`
+
+	if _, err = tx.Exec(ctx, sql, names, keys); err != nil {
+		return nil, fmt.Errorf("failed to insert artists: %w", err)
+	}
+
+	sql = `
+		SELECT artists.artist_id, input.name
+		FROM unnest($1::text[]) AS input(name) JOIN artists ON artists.name_key = name_key(input.name);`
+
+	rows, err := tx.Query(ctx, sql, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tx.Query: %w", err)
+	}
+	defer rows.Close()
+
+	ids = make(map[string]int, len(names))
+
+	var (
+		id   int
+		name string
+	)
+	for rows.Next() {
+		if err = rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		ids[name] = id
+	}
+
+	return ids, rows.Err()
+}
+
+// CreateTracks вставляет треки через CopyFrom и возвращает их идентификаторы в порядке tracks.
+//
+// Если хотя бы один трек уже существует, вставка целиком падает с domain.ErrTrackAlreadyExists.
+func (r *TracksRepository) CreateTracks(ctx context.Context, tx pgx.Tx, tracks []dao.Track) (ids []int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"tracks"},
+		[]string{"title", "title_search_key", "artist_id", "link", "released_at", "created_by", "updated_by"},
+		pgx.CopyFromSlice(len(tracks), func(i int) ([]any, error) {
+			return []any{
+				tracks[i].Title, translit.SearchKey(tracks[i].Title), tracks[i].ArtistID, tracks[i].Link, tracks[i].ReleasedAt,
+				tracks[i].CreatedBy, tracks[i].CreatedBy,
+			}, nil
+		}),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "tracks_title_key_artist_id_unique" {
+			return nil, domain.ErrTrackAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to insert tracks: %w", err)
+	}
+
+	titles := make([]string, len(tracks))
+	artistIDs := make([]int, len(tracks))
+	for i, track := range tracks {
+		titles[i], artistIDs[i] = track.Title, track.ArtistID
+	}
+
+	sql := `
+		SELECT tracks.track_id
+		FROM
+			unnest($1::text[], $2::int[]) WITH ORDINALITY AS input(title, artist_id, idx)
+			JOIN tracks ON tracks.title_key = name_key(input.title) AND tracks.artist_id = input.artist_id
+		ORDER BY input.idx;`
+
+	rows, err := tx.Query(ctx, sql, titles, artistIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tx.Query: %w", err)
+	}
+	defer rows.Close()
+
+	ids = make([]int, 0, len(tracks))
+
+	var id int
+	for rows.Next() {
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetTrackID ищет трек по названию (с точностью до name_key) и исполнителю.
+func (r *TracksRepository) GetTrackID(ctx context.Context, tx pgx.Tx, title string, artistID int) (id int, exists bool, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT track_id FROM tracks WHERE title_key = name_key($1) AND artist_id = $2;`
+
+	if err = tx.QueryRow(ctx, sql, title, artistID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to tx.QueryRow: %w", err)
+	}
+
+	return id, true, nil
+}
+
+// GetOrCreateAlbum возвращает идентификатор альбома исполнителя, создавая его при необходимости.
+func (r *TracksRepository) GetOrCreateAlbum(ctx context.Context, tx pgx.Tx, album dao.Album) (id int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул строку и для существующего альбома.
+	sql := `
+		INSERT INTO albums (artist_id, title) VALUES ($1, $2)
+		ON CONFLICT ON CONSTRAINT albums_title_artist_id_unique DO UPDATE SET title = EXCLUDED.title
+		RETURNING album_id;`
+
+	if err = tx.QueryRow(ctx, sql, album.ArtistID, album.Title).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to tx.QueryRow: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *TracksRepository) SetTrackAlbum(ctx context.Context, tx pgx.Tx, trackID int, albumID int) (err error) {
+	sql := `UPDATE tracks SET album_id = $2 WHERE track_id = $1;`
+
+	if _, err = tx.Exec(ctx, sql, trackID, albumID); err != nil {
+		return fmt.Errorf("failed to tx.Exec: %w", err)
+	}
+
+	return nil
+}
+
 func (r *TracksRepository) GetByID(ctx context.Context, id int) (track entities.Track, err error) {
 	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
 	defer cancelFunc()
@@ -204,18 +402,31 @@ func (r *TracksRepository) GetTrack(ctx context.Context, tx pgx.Tx, id int) (tra
 	defer cancelFunc()
 
 	sql := `
-		SELECT artists.name, tracks.title, tracks.link, tracks.released_at
+		SELECT
+			artists.name, tracks.title, tracks.link, tracks.released_at, COALESCE(tracks.duration_ms, 0),
+			EXISTS(SELECT 1 FROM covers WHERE covers.track_id = tracks.track_id),
+			COALESCE((SELECT array_agg(track_tags.tag ORDER BY track_tags.tag) FROM track_tags WHERE track_tags.track_id = tracks.track_id), '{}'),
+			tracks.created_by, tracks.updated_by,
+			COALESCE(tracks.lyric_language, ''), COALESCE(tracks.lyric_language_confidence, 0)
 		FROM
 			tracks JOIN artists
 				ON tracks.artist_id = artists.artist_id
 		WHERE
 			tracks.track_id = $1;`
 
+	var durationMs int64
 	if err = tx.QueryRow(ctx, sql, id).Scan(
 		&track.Artist,
 		&track.Track,
 		&track.Link,
 		&track.Released,
+		&durationMs,
+		&track.HasCover,
+		&track.Tags,
+		&track.CreatedBy,
+		&track.UpdatedBy,
+		&track.Language,
+		&track.LanguageConfidence,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entities.Track{}, domain.ErrTrackNotFound
@@ -223,11 +434,14 @@ func (r *TracksRepository) GetTrack(ctx context.Context, tx pgx.Tx, id int) (tra
 
 		return entities.Track{}, fmt.Errorf("failed to QueryRow(%d): %w", id, err)
 	}
+	track.ID = id
+	track.Duration = time.Duration(durationMs) * time.Millisecond
 
 	return track, nil
 }
 
-func (r *TracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, filter entities.TrackGetListFilters) (tracks map[int]entities.Track, err error) {
+// GetTracksByFilter возвращает треки, подходящие под фильтр, в порядке filter.Sort.
+func (r *TracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, filter entities.TrackGetListFilters) (tracks []entities.Track, err error) {
 	var (
 		sqlBase      strings.Builder
 		clause       []string
@@ -242,34 +456,18 @@ func (r *TracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, fil
 		artists.name,
 		tracks.title,
 		tracks.released_at,
-		tracks.link
+		tracks.link,
+		EXISTS(SELECT 1 FROM covers WHERE covers.track_id = tracks.track_id),
+		EXISTS(SELECT 1 FROM track_audio WHERE track_audio.track_id = tracks.track_id),
+		COALESCE(tracks.duration_ms, 0),
+		COALESCE(tracks.lyric_language, ''),
+		COALESCE(tracks.lyric_language_confidence, 0)
 	FROM
 		tracks JOIN artists ON tracks.artist_id = artists.artist_id
 	`)
 
-	if filter.Artist != "" {
-		clause = append(clause, fmt.Sprintf(`artists.name = $%d`, paramIdx))
-		paramIdx++
-		args = append(args, filter.Artist)
-	}
-
-	if filter.Track != "" {
-		clause = append(clause, fmt.Sprintf(`tracks.title = $%d`, paramIdx))
-		paramIdx++
-		args = append(args, filter.Track)
-	}
-
-	if filter.Link != "" {
-		clause = append(clause, fmt.Sprintf(`tracks.link = $%d`, paramIdx))
-		paramIdx++
-		args = append(args, filter.Link)
-	}
-
-	if filter.ReleasedYear != "" {
-		clause = append(clause, fmt.Sprintf(`EXTRACT(YEAR FROM tracks.released_at) = $%d`, paramIdx))
-		paramIdx++
-		args = append(args, filter.ReleasedYear)
-	}
+	clause, args = tracksFilterClause(filter, args)
+	paramIdx += len(args)
 
 	if len(clause) > 0 {
 		sqlBase.WriteString(`WHERE `)
@@ -277,7 +475,7 @@ func (r *TracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, fil
 		sqlBase.WriteString(` `)
 	}
 
-	sqlBase.WriteString(`ORDER BY tracks.track_id ASC `)
+	sqlBase.WriteString(tracksOrderBy(filter.Sort))
 
 	switch {
 	case filter.Limit == 0 && filter.Offset == 0:
@@ -301,8 +499,10 @@ func (r *TracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, fil
 		return nil, fmt.Errorf("failed to tracks tx.Query: %w", err)
 	}
 
-	tracks = make(map[int]entities.Track)
-	track := entities.Track{}
+	var (
+		track      entities.Track
+		durationMs int64
+	)
 
 	for rows.Next() {
 		if err = rows.Scan(
@@ -311,13 +511,288 @@ func (r *TracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, fil
 			&track.Track,
 			&track.Released,
 			&track.Link,
+			&track.HasCover,
+			&track.HasAudio,
+			&durationMs,
+			&track.Language,
+			&track.LanguageConfidence,
 		); err != nil {
 			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
 		}
-		tracks[track.ID] = track
+		track.Duration = time.Duration(durationMs) * time.Millisecond
+		tracks = append(tracks, track)
 	}
 
-	return tracks, nil
+	return tracks, rows.Err()
+}
+
+// tracksOrderBy ORDER BY для порядка сортировки, track_id добавляется последним для стабильного порядка.
+func tracksOrderBy(sort entities.TrackSort) string {
+	field, desc := sort.Field()
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+
+	switch field {
+	case entities.TrackSortTitle:
+		return fmt.Sprintf(`ORDER BY tracks.title %s, tracks.track_id ASC `, direction)
+	case entities.TrackSortArtist:
+		return fmt.Sprintf(`ORDER BY artists.name %s, tracks.title ASC, tracks.track_id ASC `, direction)
+	case entities.TrackSortReleased:
+		return fmt.Sprintf(`ORDER BY tracks.released_at %s, tracks.track_id ASC `, direction)
+	default:
+		return fmt.Sprintf(`ORDER BY tracks.track_id %s `, direction)
+	}
+}
+
+// GetFavoriteTrackIDs возвращает те из trackIDs, что есть в избранном пользователя, одним запросом.
+func (r *TracksRepository) GetFavoriteTrackIDs(ctx context.Context, userID int, trackIDs []int) (favoriteIDs []int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT track_id FROM favorites WHERE user_id = $1 AND track_id = ANY($2);`
+
+	rows, err := r.db.Query(ctx, sql, userID, trackIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to rows.Scan: %w", err)
+		}
+		favoriteIDs = append(favoriteIDs, id)
+	}
+
+	return favoriteIDs, rows.Err()
+}
+
+// exportFetchSize сколько строк за раз читается из курсора при экспорте.
+const exportFetchSize = 500
+
+// ExportTracks последовательно передаёт в fn все треки (с исполнителем и упорядоченным текстом),
+// подходящие под фильтр, читая их через серверный курсор. Limit и Offset применяются, только если заданы.
+func (r *TracksRepository) ExportTracks(ctx context.Context, filter entities.TrackGetListFilters, fn func(track entities.Track) error) (err error) {
+	var sqlBase strings.Builder
+
+	sqlBase.WriteString(`
+	DECLARE export_tracks NO SCROLL CURSOR FOR
+	SELECT
+		tracks.track_id,
+		artists.name,
+		tracks.title,
+		tracks.released_at,
+		tracks.link,
+		COALESCE(
+			(SELECT array_agg(lyrics.verse_text ORDER BY lyrics.lyric_id) FROM lyrics WHERE lyrics.track_id = tracks.track_id),
+			'{}'
+		)
+	FROM
+		tracks JOIN artists ON tracks.artist_id = artists.artist_id
+	`)
+
+	clause, args := tracksFilterClause(filter, nil)
+	if len(clause) > 0 {
+		sqlBase.WriteString(`WHERE `)
+		sqlBase.WriteString(strings.Join(clause, " AND "))
+		sqlBase.WriteString(` `)
+	}
+
+	sqlBase.WriteString(`ORDER BY tracks.track_id ASC `)
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		sqlBase.WriteString(fmt.Sprintf(` LIMIT $%d `, len(args)))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		sqlBase.WriteString(fmt.Sprintf(` OFFSET $%d `, len(args)))
+	}
+
+	sqlBase.WriteString(`;`)
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly, IsoLevel: pgx.RepeatableRead})
+	if err != nil {
+		return fmt.Errorf("failed to begin trasaction r.db.BeginTx: %w", err)
+	}
+	// Транзакция только на чтение, коммитить нечего.
+	defer func() {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+	}()
+
+	if _, err = tx.Exec(ctx, sqlBase.String(), args...); err != nil {
+		return fmt.Errorf("failed to declare cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf(`FETCH FORWARD %d FROM export_tracks;`, exportFetchSize)
+
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("failed to fetch cursor: %w", err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			fetched++
+
+			var track entities.Track
+			if err = rows.Scan(
+				&track.ID,
+				&track.Artist,
+				&track.Track,
+				&track.Released,
+				&track.Link,
+				&track.Lyric,
+			); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to rows.Scan: %w", err)
+			}
+
+			if err = fn(track); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("failed to fetch cursor: %w", err)
+		}
+
+		if fetched < exportFetchSize {
+			return nil
+		}
+	}
+}
+
+// tracksFilterClause строит условия WHERE по фильтрам списка треков, нумерация параметров продолжает args.
+func tracksFilterClause(filter entities.TrackGetListFilters, args []any) (clause []string, _ []any) {
+	if filter.Artist != "" {
+		var artist string
+		artist, args = nameMatchClause("artists.name", "artists.name_key", "artists.search_key", filter.Artist, args)
+		clause = append(clause, artist)
+	}
+
+	if filter.Track != "" {
+		var track string
+		track, args = nameMatchClause("tracks.title", "tracks.title_key", "tracks.title_search_key", filter.Track, args)
+		clause = append(clause, track)
+	}
+
+	if filter.Link != "" {
+		args = append(args, filter.Link)
+		clause = append(clause, fmt.Sprintf(`tracks.link = $%d`, len(args)))
+	}
+
+	if filter.Lang != "" {
+		args = append(args, filter.Lang)
+		clause = append(clause, fmt.Sprintf(`tracks.lyric_language = $%d`, len(args)))
+	}
+
+	if filter.ReleasedYear != "" {
+		args = append(args, filter.ReleasedYear)
+		clause = append(clause, fmt.Sprintf(`EXTRACT(YEAR FROM tracks.released_at) = $%d`, len(args)))
+	}
+
+	if filter.Rule != nil {
+		var rule string
+		rule, args = trackRuleClause(*filter.Rule, args)
+		clause = append(clause, rule)
+	}
+
+	return clause, args
+}
+
+// trackRuleClause переводит правила смарт-плейлиста в SQL условие над tracks и artists.
+// Правила должны быть проверены через TrackRule.Validate.
+func trackRuleClause(rule entities.TrackRule, args []any) (clause string, _ []any) {
+	if rule.Field == "" {
+		join, rules := " AND ", rule.And
+		if rule.Or != nil {
+			join, rules = " OR ", rule.Or
+		}
+
+		parts := make([]string, 0, len(rules))
+		for _, r := range rules {
+			var part string
+			part, args = trackRuleClause(r, args)
+			parts = append(parts, part)
+		}
+
+		return "(" + strings.Join(parts, join) + ")", args
+	}
+
+	switch rule.Field {
+	case entities.TrackRuleFieldArtist, entities.TrackRuleFieldTitle:
+		column, keyColumn, searchColumn := "artists.name", "artists.name_key", "artists.search_key"
+		if rule.Field == entities.TrackRuleFieldTitle {
+			column, keyColumn, searchColumn = "tracks.title", "tracks.title_key", "tracks.title_search_key"
+		}
+		if rule.Op == entities.TrackRuleOpContains {
+			args = append(args, containsPattern(rule.Value))
+			// Пустой ключ (значение из одних знаков) совпал бы с любым треком.
+			key := translit.SearchKey(rule.Value)
+			if key == "" {
+				return fmt.Sprintf(`%s ILIKE $%d`, column, len(args)), args
+			}
+			args = append(args, containsPattern(key))
+			return fmt.Sprintf(`(%s ILIKE $%d OR (%s LIKE $%d%s))`,
+				column, len(args)-1, searchColumn, len(args), transliteratedClause(column, rule.Value)), args
+		}
+		return nameMatchClause(column, keyColumn, searchColumn, rule.Value, args)
+	case entities.TrackRuleFieldYear:
+		var conds []string
+		if rule.From != 0 {
+			args = append(args, rule.From)
+			conds = append(conds, fmt.Sprintf(`EXTRACT(YEAR FROM tracks.released_at) >= $%d`, len(args)))
+		}
+		if rule.To != 0 {
+			args = append(args, rule.To)
+			conds = append(conds, fmt.Sprintf(`EXTRACT(YEAR FROM tracks.released_at) <= $%d`, len(args)))
+		}
+		return "(" + strings.Join(conds, " AND ") + ")", args
+	case entities.TrackRuleFieldTag:
+		args = append(args, entities.NormalizeTrackTag(rule.Value))
+		return fmt.Sprintf(`EXISTS(SELECT 1 FROM track_tags WHERE track_tags.track_id = tracks.track_id AND track_tags.tag = $%d)`, len(args)), args
+	case entities.TrackRuleFieldLyric:
+		args = append(args, containsPattern(rule.Value))
+		return fmt.Sprintf(`EXISTS(SELECT 1 FROM lyrics WHERE lyrics.track_id = tracks.track_id AND lyrics.verse_text ILIKE $%d)`, len(args)), args
+	default:
+		// Невалидное правило не должно расширять выборку.
+		return "FALSE", args
+	}
+}
+
+// nameMatchClause условие совпадения имени с value по name_key или по ключу поиска translit.SearchKey,
+// чтобы "Кино" находилось и по "Kino".
+func nameMatchClause(column, keyColumn, searchColumn, value string, args []any) (clause string, _ []any) {
+	args = append(args, value)
+	key := translit.SearchKey(value)
+	if key == "" {
+		return fmt.Sprintf(`%s = name_key($%d)`, keyColumn, len(args)), args
+	}
+	args = append(args, key)
+	return fmt.Sprintf(`(%s = name_key($%d) OR (%s = $%d%s))`,
+		keyColumn, len(args)-1, searchColumn, len(args), transliteratedClause(column, value)), args
+}
+
+// transliteratedClause дополнение к совпадению ключей поиска: латинское value совпадает по ключу только
+// с именами в кириллице, иначе латинские Jay и Iai считались бы одним именем.
+func transliteratedClause(column, value string) string {
+	if translit.HasCyrillic(value) {
+		return ""
+	}
+	return fmt.Sprintf(` AND %s ~ '%s'`, column, translit.CyrillicPattern)
+}
+
+// containsPattern шаблон ILIKE для поиска подстроки, спецсимволы LIKE экранируются.
+func containsPattern(value string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value) + "%"
 }
 
 func (r *TracksRepository) GetTrackLyric(ctx context.Context, tx pgx.Tx, trackID int) (lyric []string, err error) {
@@ -378,10 +853,14 @@ func (r *TracksRepository) GetLyricsByTrackIDs(ctx context.Context, tx pgx.Tx, i
 }
 
 func (r *TracksRepository) UpdateArtist(ctx context.Context, tx pgx.Tx, artist dao.Artist) (err error) {
-	sql := `UPDATE artists SET name = $1 WHERE artist_id = $2;`
+	sql := `UPDATE artists SET name = $1, search_key = $2 WHERE artist_id = $3;`
 
-	err = tx.QueryRow(ctx, sql, artist.Name, artist.ArtistID).Scan()
+	err = tx.QueryRow(ctx, sql, artist.Name, translit.SearchKey(artist.Name), artist.ArtistID).Scan()
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "artists_name_key_unique" {
+			return domain.ErrArtistAlreadyExists
+		}
 		return fmt.Errorf("failed to tx.QueryRow: %w", err)
 	}
 
@@ -402,6 +881,14 @@ func (r *TracksRepository) UpdateTrack(ctx context.Context, tx pgx.Tx, track dao
 		phIndex++
 		fields = append(fields, fmt.Sprintf("title = $%d", phIndex))
 		args = append(args, track.Title)
+		phIndex++
+		fields = append(fields, fmt.Sprintf("title_search_key = $%d", phIndex))
+		args = append(args, translit.SearchKey(track.Title))
+	}
+	if track.ArtistID != 0 {
+		phIndex++
+		fields = append(fields, fmt.Sprintf("artist_id = $%d", phIndex))
+		args = append(args, track.ArtistID)
 	}
 	if track.Link != "" {
 		phIndex++
@@ -421,14 +908,66 @@ func (r *TracksRepository) UpdateTrack(ctx context.Context, tx pgx.Tx, track dao
 
 	err = tx.QueryRow(ctx, sql, args...).Scan()
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "tracks_title_key_artist_id_unique" {
+			return domain.ErrTrackAlreadyExists
+		}
 		return fmt.Errorf("failed to tx.QueryRow: %w", err)
 	}
 
 	return nil
 }
 
+// SetTrackUpdatedBy запоминает пользователя, последним изменившего трек.
+func (r *TracksRepository) SetTrackUpdatedBy(ctx context.Context, tx pgx.Tx, trackID int, userID int) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `UPDATE tracks SET updated_by = $2 WHERE track_id = $1;`
+
+	if _, err = tx.Exec(ctx, sql, trackID, userID); err != nil {
+		return fmt.Errorf("failed to tx.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// SetTrackTags заменяет теги трека.
+func (r *TracksRepository) SetTrackTags(ctx context.Context, trackID int, tags []string) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Блокировка трека сериализует параллельные замены тегов.
+		if err = tx.QueryRow(ctx, `SELECT track_id FROM tracks WHERE track_id = $1 FOR UPDATE;`, trackID).Scan(&trackID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrTrackNotFound
+			}
+			return fmt.Errorf("failed to select track: %w", err)
+		}
+
+		if _, err = tx.Exec(ctx, `DELETE FROM track_tags WHERE track_id = $1;`, trackID); err != nil {
+			return fmt.Errorf("failed to delete track_tags: %w", err)
+		}
+
+		sql := `INSERT INTO track_tags (track_id, tag) SELECT $1, unnest($2::VARCHAR[]);`
+		if _, err = tx.Exec(ctx, sql, trackID, tags); err != nil {
+			return fmt.Errorf("failed to insert track_tags: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteTrackByID удаляет трек. Записи плейлистов с треком удаляются каскадом,
+// у затронутых плейлистов обновляется updated_at.
 func (r *TracksRepository) DeleteTrackByID(ctx context.Context, trackID int) (err error) {
-	sql := `DELETE FROM tracks WHERE track_id = $1;`
+	sql := `
+		WITH touched_playlists AS (
+			UPDATE playlists SET updated_at = NOW()
+			WHERE playlist_id IN (SELECT playlist_id FROM playlist_tracks WHERE track_id = $1)
+		)
+		DELETE FROM tracks WHERE track_id = $1;`
 
 	err = r.db.QueryRow(ctx, sql, trackID).Scan()
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
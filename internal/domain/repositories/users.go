@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type UsersRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUsersRepository(db *pgxpool.Pool) *UsersRepository {
+	return &UsersRepository{db: db}
+}
+
+func (r *UsersRepository) CreateUser(ctx context.Context, user dao.User) (id int, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `INSERT INTO users (email, password_hash) VALUES ($1, $2) RETURNING user_id;`
+
+	if err = r.db.QueryRow(ctx, sql, user.Email, user.PasswordHash).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "users_email_unique" {
+			return 0, domain.ErrUserAlreadyExists
+		}
+		return 0, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *UsersRepository) GetUserByEmail(ctx context.Context, email string) (user dao.User, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `SELECT user_id, email, password_hash, role, created_at FROM users WHERE email = $1;`
+
+	if err = r.db.QueryRow(ctx, sql, email).Scan(&user.UserID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dao.User{}, domain.ErrUserNotFound
+		}
+		return dao.User{}, fmt.Errorf("failed to db.QueryRow: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetUserRole меняет роль пользователя. Новая роль попадает в access токен при следующем входе или обновлении.
+func (r *UsersRepository) SetUserRole(ctx context.Context, email string, role string) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `UPDATE users SET role = $2, updated_at = NOW() WHERE email = $1;`
+
+	tag, err := r.db.Exec(ctx, sql, email, role)
+	if err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// CreateRefreshToken сохраняет refresh токен нового семейства.
+func (r *UsersRepository) CreateRefreshToken(ctx context.Context, token dao.RefreshToken) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	sql := `INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3);`
+
+	if _, err = r.db.Exec(ctx, sql, token.UserID, token.TokenHash, token.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to db.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken отзывает токен с хешем oldHash и сохраняет вместо него next в том же семействе.
+//
+// Если токен уже был отозван, значит его украли или повторили: отзывается всё семейство
+// и возвращается domain.ErrInvalidRefreshToken.
+func (r *UsersRepository) RotateRefreshToken(ctx context.Context, oldHash []byte, next dao.RefreshToken) (user dao.User, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	var reused bool
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var (
+			token     dao.RefreshToken
+			expiresAt time.Time
+		)
+
+		sql := `
+			SELECT refresh_tokens.token_id, refresh_tokens.expires_at, refresh_tokens.revoked_at,
+				users.user_id, users.email, users.role, users.created_at
+			FROM refresh_tokens JOIN users ON users.user_id = refresh_tokens.user_id
+			WHERE refresh_tokens.token_hash = $1
+			FOR UPDATE OF refresh_tokens;`
+
+		if err = tx.QueryRow(ctx, sql, oldHash).Scan(
+			&token.TokenID, &expiresAt, &token.RevokedAt,
+			&user.UserID, &user.Email, &user.Role, &user.CreatedAt,
+		); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrRefreshTokenNotFound
+			}
+			return fmt.Errorf("failed to tx.QueryRow: %w", err)
+		}
+
+		if token.RevokedAt != nil {
+			reused = true
+			return revokeRefreshTokenFamily(ctx, tx, token.TokenID)
+		}
+		if !expiresAt.After(time.Now()) {
+			return domain.ErrInvalidRefreshToken
+		}
+
+		sql = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE token_id = $1;`
+		if _, err = tx.Exec(ctx, sql, token.TokenID); err != nil {
+			return fmt.Errorf("failed to update refresh_tokens: %w", err)
+		}
+
+		sql = `
+			INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+			SELECT user_id, family_id, $2, $3 FROM refresh_tokens WHERE token_id = $1;`
+		if _, err = tx.Exec(ctx, sql, token.TokenID, next.TokenHash, next.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to insert refresh_tokens: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dao.User{}, err
+	}
+	if reused {
+		return dao.User{}, domain.ErrInvalidRefreshToken
+	}
+
+	return user, nil
+}
+
+// RevokeRefreshTokenFamily отзывает токен и все токены его семейства (выход из сессии).
+func (r *UsersRepository) RevokeRefreshTokenFamily(ctx context.Context, tokenHash []byte) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var tokenID int
+
+		sql := `SELECT token_id FROM refresh_tokens WHERE token_hash = $1;`
+		if err = tx.QueryRow(ctx, sql, tokenHash).Scan(&tokenID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrRefreshTokenNotFound
+			}
+			return fmt.Errorf("failed to tx.QueryRow: %w", err)
+		}
+
+		return revokeRefreshTokenFamily(ctx, tx, tokenID)
+	})
+}
+
+// DeleteExpiredRefreshTokens удаляет истёкшие токены, возвращает количество удалённых.
+func (r *UsersRepository) DeleteExpiredRefreshTokens(ctx context.Context) (deleted int64, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, queryTimeout)
+	defer cancelFunc()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM refresh_tokens WHERE expires_at < NOW();`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to db.Exec: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func revokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, tokenID int) error {
+	sql := `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_id = $1) AND revoked_at IS NULL;`
+
+	if _, err := tx.Exec(ctx, sql, tokenID); err != nil {
+		return fmt.Errorf("failed to revoke refresh_tokens family: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+const (
+	// apiKeyPrefix отличает API ключи от других секретов (например, при поиске утечек в логах).
+	apiKeyPrefix = "ym_"
+	// apiKeyShownPrefix сколько первых символов ключа хранится открыто.
+	apiKeyShownPrefix = len(apiKeyPrefix) + 8
+)
+
+type APIKeysRepository interface {
+	CreateAPIKey(ctx context.Context, key dao.APIKey, maxActive int) (created dao.APIKey, err error)
+	GetAPIKeysByUser(ctx context.Context, userID int) (keys []dao.APIKey, err error)
+	RevokeAPIKey(ctx context.Context, userID int, keyID int) (err error)
+	GetAPIKeyByHash(ctx context.Context, keyHash []byte) (key dao.APIKey, user dao.User, err error)
+}
+
+type APIKeysService struct {
+	repo APIKeysRepository
+	cfg  config.APIKeys
+	now  func() time.Time
+}
+
+func NewAPIKeysService(repo APIKeysRepository, cfg config.APIKeys) *APIKeysService {
+	return &APIKeysService{repo: repo, cfg: cfg, now: time.Now}
+}
+
+// Create выпускает ключ от имени user. Scopes не могут превышать права роли пользователя.
+func (s *APIKeysService) Create(ctx context.Context, user entities.User, create entities.APIKeyCreate) (created entities.APIKeyCreated, err error) {
+	if len(create.Scopes) == 0 {
+		return entities.APIKeyCreated{}, domain.ErrAPIKeyScopesInvalid
+	}
+	scopes := make([]string, 0, len(create.Scopes))
+	for _, scope := range create.Scopes {
+		if !user.Can(scope) {
+			return entities.APIKeyCreated{}, fmt.Errorf("%w: %q", domain.ErrAPIKeyScopesInvalid, scope)
+		}
+		scopes = append(scopes, string(scope))
+	}
+
+	if create.ExpiresAt != nil && !create.ExpiresAt.After(s.now()) {
+		return entities.APIKeyCreated{}, domain.ErrAPIKeyExpiryInvalid
+	}
+
+	quota := create.Quota
+	if quota == 0 {
+		quota = s.cfg.DefaultQuota
+	}
+
+	key, hash, err := newAPIKey()
+	if err != nil {
+		return entities.APIKeyCreated{}, err
+	}
+
+	stored, err := s.repo.CreateAPIKey(ctx, dao.APIKey{
+		UserID:    user.ID,
+		Name:      strings.TrimSpace(create.Name),
+		Prefix:    key[:apiKeyShownPrefix],
+		KeyHash:   hash,
+		Scopes:    scopes,
+		Quota:     quota,
+		ExpiresAt: create.ExpiresAt,
+	}, s.cfg.MaxPerUser)
+	if err != nil {
+		return entities.APIKeyCreated{}, fmt.Errorf("failed to repo.CreateAPIKey: %w", err)
+	}
+
+	return entities.APIKeyCreated{APIKey: apiKeyFromDAO(stored), Key: key}, nil
+}
+
+func (s *APIKeysService) List(ctx context.Context, userID int) (keys []entities.APIKey, err error) {
+	stored, err := s.repo.GetAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.GetAPIKeysByUser: %w", err)
+	}
+
+	keys = make([]entities.APIKey, 0, len(stored))
+	for _, key := range stored {
+		keys = append(keys, apiKeyFromDAO(key))
+	}
+
+	return keys, nil
+}
+
+func (s *APIKeysService) Revoke(ctx context.Context, userID int, keyID int) (err error) {
+	if err = s.repo.RevokeAPIKey(ctx, userID, keyID); err != nil {
+		return fmt.Errorf("failed to repo.RevokeAPIKey: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate находит действующий ключ и возвращает его владельца с ключом в User.APIKey.
+// Роль берётся текущая, поэтому понижение роли сразу сужает права уже выданных ключей.
+func (s *APIKeysService) Authenticate(ctx context.Context, apiKey string) (entities.User, error) {
+	if !strings.HasPrefix(apiKey, apiKeyPrefix) {
+		return entities.User{}, domain.ErrInvalidAPIKey
+	}
+
+	key, user, err := s.repo.GetAPIKeyByHash(ctx, hashSecret(apiKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return entities.User{}, domain.ErrInvalidAPIKey
+		}
+		return entities.User{}, fmt.Errorf("failed to repo.GetAPIKeyByHash: %w", err)
+	}
+
+	apiKeyEntity := apiKeyFromDAO(key)
+
+	return entities.User{
+		ID:        user.UserID,
+		Email:     user.Email,
+		Role:      entities.Role(user.Role),
+		CreatedAt: user.CreatedAt,
+		APIKey:    &apiKeyEntity,
+	}, nil
+}
+
+// newAPIKey возвращает случайный ключ и его хеш для хранения в базе.
+func newAPIKey() (key string, hash []byte, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("failed to rand.Read: %w", err)
+	}
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return key, hashSecret(key), nil
+}
+
+func apiKeyFromDAO(key dao.APIKey) entities.APIKey {
+	scopes := make([]entities.Permission, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, entities.Permission(scope))
+	}
+
+	return entities.APIKey{
+		ID:         key.KeyID,
+		UserID:     key.UserID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		Quota:      key.Quota,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
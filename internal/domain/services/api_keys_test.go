@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/mocks/internal_/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeysServiceCreate(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.APIKeys{DefaultQuota: 1000, MaxPerUser: 5}
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		role          entities.Role
+		create        entities.APIKeyCreate
+		expectedQuota int
+		expectedErr   error
+	}{
+		{
+			"case: default quota",
+			entities.RoleEditor,
+			entities.APIKeyCreate{Name: "bot", Scopes: []entities.Permission{entities.PermissionTracksCreate}},
+			1000,
+			nil,
+		},
+		{
+			"case: custom quota and expiry",
+			entities.RoleAdmin,
+			entities.APIKeyCreate{Name: "bot", Scopes: []entities.Permission{entities.PermissionTracksDelete}, Quota: 10, ExpiresAt: &future},
+			10,
+			nil,
+		},
+		{
+			"case: scope above role",
+			entities.RoleEditor,
+			entities.APIKeyCreate{Name: "bot", Scopes: []entities.Permission{entities.PermissionTracksDelete}},
+			0,
+			domain.ErrAPIKeyScopesInvalid,
+		},
+		{
+			"case: unknown scope",
+			entities.RoleAdmin,
+			entities.APIKeyCreate{Name: "bot", Scopes: []entities.Permission{"tracks:*"}},
+			0,
+			domain.ErrAPIKeyScopesInvalid,
+		},
+		{
+			"case: no scopes",
+			entities.RoleAdmin,
+			entities.APIKeyCreate{Name: "bot"},
+			0,
+			domain.ErrAPIKeyScopesInvalid,
+		},
+		{
+			"case: expiry in the past",
+			entities.RoleEditor,
+			entities.APIKeyCreate{Name: "bot", Scopes: []entities.Permission{entities.PermissionTracksCreate}, ExpiresAt: &past},
+			0,
+			domain.ErrAPIKeyExpiryInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockAPIKeysRepository(t)
+			if tt.expectedErr == nil {
+				repo.EXPECT().CreateAPIKey(mock.Anything, mock.Anything, cfg.MaxPerUser).RunAndReturn(
+					func(_ context.Context, key dao.APIKey, _ int) (dao.APIKey, error) {
+						key.KeyID = 1
+						return key, nil
+					})
+			}
+
+			created, err := services.NewAPIKeysService(repo, cfg).Create(
+				context.Background(), entities.User{ID: 7, Role: tt.role}, tt.create)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+			assert.Equal(t, tt.expectedQuota, created.Quota)
+			assert.Equal(t, 7, created.UserID)
+		})
+	}
+}
+
+func TestAPIKeysServiceAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewMockAPIKeysRepository(t)
+	service := services.NewAPIKeysService(repo, config.APIKeys{DefaultQuota: 100, MaxPerUser: 5})
+
+	var stored dao.APIKey
+	repo.EXPECT().CreateAPIKey(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, key dao.APIKey, _ int) (dao.APIKey, error) {
+			key.KeyID = 3
+			stored = key
+			return key, nil
+		})
+	repo.EXPECT().GetAPIKeyByHash(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, hash []byte) (dao.APIKey, dao.User, error) {
+			if string(hash) != string(stored.KeyHash) {
+				return dao.APIKey{}, dao.User{}, domain.ErrAPIKeyNotFound
+			}
+			return stored, dao.User{UserID: stored.UserID, Email: "bot@example.com", Role: "editor"}, nil
+		})
+
+	created, err := service.Create(context.Background(), entities.User{ID: 7, Role: entities.RoleEditor}, entities.APIKeyCreate{
+		Name:   "bot",
+		Scopes: []entities.Permission{entities.PermissionTracksCreate},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		key         string
+		expectedErr error
+	}{
+		{"case: valid key", created.Key, nil},
+		{"case: unknown key", created.Key + "x", domain.ErrInvalidAPIKey},
+		{"case: foreign format", "not-an-api-key", domain.ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			user, err := service.Authenticate(context.Background(), tt.key)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, user.APIKey)
+			assert.Equal(t, 7, user.ID)
+			assert.Equal(t, 3, user.APIKey.ID)
+			assert.True(t, user.Can(entities.PermissionTracksCreate))
+			assert.False(t, user.Can(entities.PermissionTracksUpdate))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/audiotag"
+	"github.com/neyrzx/youmusic/pkg/blob"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const (
+	packageKey  = "services"
+	packageName = "audio"
+)
+
+type AudioRepository interface {
+	IsTrackExistsByID(ctx context.Context, trackID int) (exists bool, err error)
+	SaveTrackAudio(ctx context.Context, audio dao.TrackAudio) (previousKey string, err error)
+	GetTrackAudio(ctx context.Context, trackID int) (audio dao.TrackAudio, err error)
+}
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) (blob.Object, error)
+	Open(ctx context.Context, key string) (blob.File, blob.Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AudioService struct {
+	repo   AudioRepository
+	store  BlobStore
+	logger *zerolog.Logger
+}
+
+func NewAudioService(repo AudioRepository, store BlobStore) *AudioService {
+	logger := logger.DefaultLogger().With().Str(packageKey, packageName).Logger()
+
+	return &AudioService{repo: repo, store: store, logger: &logger}
+}
+
+// Upload сохраняет аудиофайл трека и его длительность.
+//
+// Каждая загрузка пишется под новым ключом, предыдущий файл удаляется только после
+// сохранения метаданных, поэтому невалидная загрузка не портит уже загруженный файл.
+func (s *AudioService) Upload(ctx context.Context, upload entities.TrackAudioUpload) (audio entities.TrackAudio, err error) {
+	exists, err := s.repo.IsTrackExistsByID(ctx, upload.TrackID)
+	if err != nil {
+		return entities.TrackAudio{}, fmt.Errorf("failed to repo.IsTrackExistsByID: %w", err)
+	}
+	if !exists {
+		return entities.TrackAudio{}, domain.ErrTrackNotFound
+	}
+
+	key := fmt.Sprintf("tracks/%d/%d", upload.TrackID, time.Now().UnixNano())
+
+	object, err := s.store.Put(ctx, key, upload.Body)
+	if err != nil {
+		return entities.TrackAudio{}, fmt.Errorf("failed to store.Put: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			s.deleteBlob(key)
+		}
+	}()
+
+	duration, err := s.duration(ctx, key)
+	if err != nil {
+		return entities.TrackAudio{}, err
+	}
+
+	previousKey, err := s.repo.SaveTrackAudio(ctx, dao.TrackAudio{
+		TrackID:     upload.TrackID,
+		BlobKey:     key,
+		ContentType: upload.ContentType,
+		Size:        object.Size,
+		ETag:        object.ETag,
+		DurationMs:  duration.Milliseconds(),
+	})
+	if err != nil {
+		return entities.TrackAudio{}, fmt.Errorf("failed to repo.SaveTrackAudio: %w", err)
+	}
+
+	if previousKey != "" {
+		s.deleteBlob(previousKey)
+	}
+
+	return entities.TrackAudio{
+		TrackID:     upload.TrackID,
+		ContentType: upload.ContentType,
+		Size:        object.Size,
+		ETag:        object.ETag,
+		Duration:    duration,
+		UpdatedAt:   object.ModTime,
+	}, nil
+}
+
+// Open возвращает метаданные и открытый файл, вызывающий должен закрыть файл.
+func (s *AudioService) Open(ctx context.Context, trackID int) (audio entities.TrackAudio, file blob.File, err error) {
+	record, err := s.repo.GetTrackAudio(ctx, trackID)
+	if err != nil {
+		return entities.TrackAudio{}, nil, fmt.Errorf("failed to repo.GetTrackAudio: %w", err)
+	}
+
+	file, _, err = s.store.Open(ctx, record.BlobKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return entities.TrackAudio{}, nil, domain.ErrTrackAudioNotFound
+		}
+		return entities.TrackAudio{}, nil, fmt.Errorf("failed to store.Open: %w", err)
+	}
+
+	return entities.TrackAudio{
+		TrackID:     record.TrackID,
+		ContentType: record.ContentType,
+		Size:        record.Size,
+		ETag:        record.ETag,
+		Duration:    time.Duration(record.DurationMs) * time.Millisecond,
+		UpdatedAt:   record.UpdatedAt,
+	}, file, nil
+}
+
+// duration читает длительность из заголовков сохранённого файла, заодно проверяя что это MP3 или FLAC.
+func (s *AudioService) duration(ctx context.Context, key string) (time.Duration, error) {
+	file, _, err := s.store.Open(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to store.Open: %w", err)
+	}
+	defer file.Close()
+
+	duration, err := audiotag.Duration(file)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domain.ErrTrackAudioInvalid, err)
+	}
+
+	return duration, nil
+}
+
+// deleteBlob удаляет файл вне контекста запроса, ошибка только логируется.
+func (s *AudioService) deleteBlob(key string) {
+	if err := s.store.Delete(context.Background(), key); err != nil {
+		s.logger.Err(err).Str("key", key).Msg("failed to store.Delete")
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// accessTokenIssuer значение iss в access токенах.
+const accessTokenIssuer = "youmusic"
+
+type UsersRepository interface {
+	CreateUser(ctx context.Context, user dao.User) (id int, err error)
+	GetUserByEmail(ctx context.Context, email string) (user dao.User, err error)
+	CreateRefreshToken(ctx context.Context, token dao.RefreshToken) (err error)
+	RotateRefreshToken(ctx context.Context, oldHash []byte, next dao.RefreshToken) (user dao.User, err error)
+	RevokeRefreshTokenFamily(ctx context.Context, tokenHash []byte) (err error)
+	SetUserRole(ctx context.Context, email string, role string) (err error)
+}
+
+type AuthService struct {
+	repo UsersRepository
+	cfg  config.Auth
+	// dummyHash сравнивается с паролем, когда пользователь не найден, чтобы время ответа не выдавало существующие email.
+	dummyHash []byte
+	now       func() time.Time
+}
+
+func NewAuthService(repo UsersRepository, cfg config.Auth) (*AuthService, error) {
+	if len(cfg.JWTSecret) < 32 {
+		return nil, errors.New("jwt secret must be at least 32 bytes")
+	}
+
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("dummy password"), cfg.BcryptCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bcrypt.GenerateFromPassword: %w", err)
+	}
+
+	return &AuthService{repo: repo, cfg: cfg, dummyHash: dummyHash, now: time.Now}, nil
+}
+
+type accessTokenClaims struct {
+	jwt.RegisteredClaims
+	Email string        `json:"email"`
+	Role  entities.Role `json:"role"`
+}
+
+func (s *AuthService) Register(ctx context.Context, register entities.UserRegister) (id int, err error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(register.Password), s.cfg.BcryptCost)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bcrypt.GenerateFromPassword: %w", err)
+	}
+
+	if id, err = s.repo.CreateUser(ctx, dao.User{
+		Email:        normalizeEmail(register.Email),
+		PasswordHash: string(hash),
+	}); err != nil {
+		return 0, fmt.Errorf("failed to repo.CreateUser: %w", err)
+	}
+
+	return id, nil
+}
+
+// Login проверяет пароль и открывает новую сессию (семейство refresh токенов).
+func (s *AuthService) Login(ctx context.Context, login entities.UserLogin) (tokens entities.AuthTokens, err error) {
+	user, err := s.repo.GetUserByEmail(ctx, normalizeEmail(login.Email))
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			_ = bcrypt.CompareHashAndPassword(s.dummyHash, []byte(login.Password))
+			return entities.AuthTokens{}, domain.ErrInvalidCredentials
+		}
+		return entities.AuthTokens{}, fmt.Errorf("failed to repo.GetUserByEmail: %w", err)
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(login.Password)); err != nil {
+		return entities.AuthTokens{}, domain.ErrInvalidCredentials
+	}
+
+	refresh, refreshHash, err := newRefreshToken()
+	if err != nil {
+		return entities.AuthTokens{}, err
+	}
+
+	now := s.now()
+	if err = s.repo.CreateRefreshToken(ctx, dao.RefreshToken{
+		UserID:    user.UserID,
+		TokenHash: refreshHash,
+		ExpiresAt: now.Add(s.cfg.RefreshTokenTTL),
+	}); err != nil {
+		return entities.AuthTokens{}, fmt.Errorf("failed to repo.CreateRefreshToken: %w", err)
+	}
+
+	return s.issueTokens(user, refresh, now)
+}
+
+// Refresh обменивает refresh токен на новую пару, старый refresh токен при этом отзывается.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (tokens entities.AuthTokens, err error) {
+	refresh, refreshHash, err := newRefreshToken()
+	if err != nil {
+		return entities.AuthTokens{}, err
+	}
+
+	now := s.now()
+	user, err := s.repo.RotateRefreshToken(ctx, hashSecret(refreshToken), dao.RefreshToken{
+		TokenHash: refreshHash,
+		ExpiresAt: now.Add(s.cfg.RefreshTokenTTL),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenNotFound) || errors.Is(err, domain.ErrInvalidRefreshToken) {
+			return entities.AuthTokens{}, domain.ErrInvalidRefreshToken
+		}
+		return entities.AuthTokens{}, fmt.Errorf("failed to repo.RotateRefreshToken: %w", err)
+	}
+
+	return s.issueTokens(user, refresh, now)
+}
+
+// Logout отзывает все refresh токены сессии. Выданные access токены действуют до истечения срока.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) (err error) {
+	if err = s.repo.RevokeRefreshTokenFamily(ctx, hashSecret(refreshToken)); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenNotFound) {
+			return domain.ErrInvalidRefreshToken
+		}
+		return fmt.Errorf("failed to repo.RevokeRefreshTokenFamily: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate проверяет подпись и срок access токена, в базу не ходит.
+func (s *AuthService) Authenticate(_ context.Context, accessToken string) (entities.User, error) {
+	var claims accessTokenClaims
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(accessTokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if _, err := parser.ParseWithClaims(accessToken, &claims, func(*jwt.Token) (any, error) {
+		return []byte(s.cfg.JWTSecret), nil
+	}); err != nil {
+		return entities.User{}, domain.ErrInvalidAccessToken
+	}
+
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil || id <= 0 || !claims.Role.Valid() {
+		return entities.User{}, domain.ErrInvalidAccessToken
+	}
+
+	return entities.User{ID: id, Email: claims.Email, Role: claims.Role}, nil
+}
+
+// SetRole назначает пользователю роль.
+func (s *AuthService) SetRole(ctx context.Context, email string, role entities.Role) (err error) {
+	if !role.Valid() {
+		return domain.ErrUserRoleInvalid
+	}
+
+	if err = s.repo.SetUserRole(ctx, normalizeEmail(email), string(role)); err != nil {
+		return fmt.Errorf("failed to repo.SetUserRole: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AuthService) issueTokens(user dao.User, refresh string, now time.Time) (entities.AuthTokens, error) {
+	accessExpiresAt := now.Add(s.cfg.AccessTokenTTL)
+
+	access, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(user.UserID),
+			Issuer:    accessTokenIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(accessExpiresAt),
+		},
+		Email: user.Email,
+		Role:  entities.Role(user.Role),
+	}).SignedString([]byte(s.cfg.JWTSecret))
+	if err != nil {
+		return entities.AuthTokens{}, fmt.Errorf("failed to SignedString: %w", err)
+	}
+
+	return entities.AuthTokens{
+		AccessToken:      access,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: now.Add(s.cfg.RefreshTokenTTL),
+	}, nil
+}
+
+// newRefreshToken возвращает случайный токен и его хеш для хранения в базе.
+func newRefreshToken() (token string, hash []byte, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("failed to rand.Read: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+
+	return token, hashSecret(token), nil
+}
+
+// hashSecret хеш случайного секрета (refresh токена, API ключа) для хранения в базе.
+// Секреты высокоэнтропийные, поэтому медленный хеш не нужен.
+func hashSecret(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
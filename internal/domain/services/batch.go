@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/utils"
+	"golang.org/x/sync/errgroup"
+)
+
+// CreateBatch создаёт пакет треков.
+//
+// Информация о треках запрашивается параллельно (не более cfg.BatchConcurrency запросов одновременно),
+// вставка выполняется одной транзакцией через CopyFrom. Если во время вставки часть треков успели
+// создать параллельно, треки сохраняются по одному. Результат возвращается по каждому элементу в порядке tracks.
+func (s *TracksService) CreateBatch(ctx context.Context, tracks []entities.TrackCreate) (results []entities.TrackBatchResult, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, methodTimout)
+	defer cancelFunc()
+
+	if s.cfg.BatchMaxItems > 0 && len(tracks) > s.cfg.BatchMaxItems {
+		return nil, fmt.Errorf("%w: %d > %d", domain.ErrTrackBatchTooLarge, len(tracks), s.cfg.BatchMaxItems)
+	}
+
+	tracks = normalizeTracks(tracks)
+
+	results = make([]entities.TrackBatchResult, len(tracks))
+	seen := make(map[entities.TrackCreate]struct{}, len(tracks))
+	for i, track := range tracks {
+		results[i] = entities.TrackBatchResult{Title: track.Title, Artist: track.Artist}
+		if _, ok := seen[trackKey(track)]; ok {
+			results[i].Status = entities.TrackBatchStatusFailed
+			results[i].Reason = domain.ErrTrackBatchDuplicate.Error()
+			continue
+		}
+		seen[trackKey(track)] = struct{}{}
+	}
+
+	existing, err := s.repo.GetExistingTracks(ctx, tracks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.GetExistingTracks: %w", err)
+	}
+	for _, track := range existing {
+		delete(seen, trackKey(track))
+	}
+	for i, track := range tracks {
+		if _, ok := seen[trackKey(track)]; !ok && results[i].Status == "" {
+			results[i].Status = entities.TrackBatchStatusExists
+		}
+	}
+
+	infos := s.fetchBatchInfo(ctx, tracks, results)
+
+	var pending []int
+	for i := range results {
+		if results[i].Status == "" {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	err = s.saveBatch(ctx, tracks, infos, results, pending)
+	if errors.Is(err, domain.ErrTrackAlreadyExists) {
+		s.saveBatchOneByOne(ctx, tracks, infos, results, pending)
+		return results, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to saveBatch: %w", err)
+	}
+
+	return results, nil
+}
+
+// normalizeTracks возвращает копию tracks с нормализованными названиями и исполнителями.
+func normalizeTracks(tracks []entities.TrackCreate) []entities.TrackCreate {
+	normalized := make([]entities.TrackCreate, len(tracks))
+	for i, track := range tracks {
+		normalized[i] = entities.TrackCreate{
+			Title:  entities.NormalizeName(track.Title),
+			Artist: entities.NormalizeName(track.Artist),
+		}
+	}
+
+	return normalized
+}
+
+// trackKey ключ трека для поиска повторов, совпадает для названий с одинаковым name_key.
+func trackKey(track entities.TrackCreate) entities.TrackCreate {
+	return entities.TrackCreate{Title: entities.NameKey(track.Title), Artist: entities.NameKey(track.Artist)}
+}
+
+// fetchBatchInfo запрашивает информацию о треках без статуса, ошибки записываются в results.
+func (s *TracksService) fetchBatchInfo(ctx context.Context, tracks []entities.TrackCreate, results []entities.TrackBatchResult) []entities.TrackInfoResult {
+	infos := make([]entities.TrackInfoResult, len(tracks))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(s.cfg.BatchConcurrency, 1))
+
+	for i, track := range tracks {
+		if results[i].Status != "" {
+			continue
+		}
+
+		g.Go(func() error {
+			info, err := s.infoGateway.Info(ctx, entities.TrackInfo{Group: track.Artist, Song: track.Title})
+			if err != nil {
+				results[i].Status = entities.TrackBatchStatusFailed
+				results[i].Reason = fmt.Errorf("failed to infoGateway.Info(): %w", err).Error()
+				return nil
+			}
+
+			infos[i] = info
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	return infos
+}
+
+func (s *TracksService) saveBatch(
+	ctx context.Context,
+	tracks []entities.TrackCreate,
+	infos []entities.TrackInfoResult,
+	results []entities.TrackBatchResult,
+	pending []int,
+) error {
+	var ids []int
+
+	err := s.repo.WithTx(ctx, func(tx pgx.Tx) (err error) {
+		names := make([]string, 0, len(pending))
+		for _, i := range pending {
+			names = append(names, tracks[i].Artist)
+		}
+
+		artistIDs, err := s.repo.GetOrCreateArtists(ctx, tx, names)
+		if err != nil {
+			return fmt.Errorf("failed to repo.GetOrCreateArtists: %w", err)
+		}
+
+		tracksDAO := make([]dao.Track, 0, len(pending))
+		for _, i := range pending {
+			tracksDAO = append(tracksDAO, dao.Track{
+				Title:      tracks[i].Title,
+				ArtistID:   artistIDs[tracks[i].Artist],
+				Link:       infos[i].Link,
+				ReleasedAt: infos[i].ReleaseDate,
+				CreatedBy:  entities.UserIDFromContext(ctx),
+			})
+		}
+
+		if ids, err = s.repo.CreateTracks(ctx, tx, tracksDAO); err != nil {
+			return fmt.Errorf("failed to repo.CreateTracks: %w", err)
+		}
+		if len(ids) != len(pending) {
+			return fmt.Errorf("failed to repo.CreateTracks: got %d ids for %d tracks", len(ids), len(pending))
+		}
+
+		var lyricsDAO []dao.Lyric
+		signatures := make([]dao.LyricSignature, 0, len(pending))
+		languages := make([]dao.LyricLanguage, 0, len(pending))
+		for n, i := range pending {
+			verses := utils.SplitLyricsToVerses(ctx, infos[i].Text)
+			for _, verse := range verses {
+				lyricsDAO = append(lyricsDAO, dao.Lyric{TrackID: ids[n], Verse: verse})
+			}
+			signatures = append(signatures, lyricSignature(ids[n], verses))
+			languages = append(languages, lyricLanguage(ids[n], verses))
+		}
+
+		if err = s.repo.CreateLyric(ctx, tx, lyricsDAO); err != nil {
+			return fmt.Errorf("failed to repo.CreateLyric: %w", err)
+		}
+
+		for _, signature := range signatures {
+			if err = s.repo.SetLyricSignature(ctx, tx, signature); err != nil {
+				return fmt.Errorf("failed to repo.SetLyricSignature: %w", err)
+			}
+		}
+		for _, language := range languages {
+			if err = s.repo.SetLyricLanguage(ctx, tx, language); err != nil {
+				return fmt.Errorf("failed to repo.SetLyricLanguage: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for n, i := range pending {
+		results[i].TrackID = ids[n]
+		results[i].Status = entities.TrackBatchStatusCreated
+	}
+
+	return nil
+}
+
+func (s *TracksService) saveBatchOneByOne(
+	ctx context.Context,
+	tracks []entities.TrackCreate,
+	infos []entities.TrackInfoResult,
+	results []entities.TrackBatchResult,
+	pending []int,
+) {
+	for _, i := range pending {
+		trackID, err := s.saveTrack(ctx, tracks[i], infos[i])
+		switch {
+		case errors.Is(err, domain.ErrTrackAlreadyExists):
+			results[i].Status = entities.TrackBatchStatusExists
+		case err != nil:
+			results[i].Status = entities.TrackBatchStatusFailed
+			results[i].Reason = err.Error()
+		default:
+			results[i].TrackID = trackID
+			results[i].Status = entities.TrackBatchStatusCreated
+		}
+	}
+}
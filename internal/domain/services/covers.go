@@ -0,0 +1,258 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/blob"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/neyrzx/youmusic/pkg/thumbnail"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+)
+
+type CoversRepository interface {
+	IsOwnerExists(ctx context.Context, owner entities.CoverOwner) (exists bool, err error)
+	SaveCover(ctx context.Context, owner entities.CoverOwner, cover dao.Cover) (previousKey string, err error)
+	GetCover(ctx context.Context, owner entities.CoverOwner) (cover dao.Cover, err error)
+	GetTrackAlbumID(ctx context.Context, trackID int) (albumID int, err error)
+}
+
+type CoversStore interface {
+	BlobStore
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// CoversService хранит обложки треков и альбомов и отдаёт их превью.
+//
+// Все файлы одной версии обложки лежат под общим префиксом: оригинал и лениво созданные превью
+// ("<size>.<format>"). Новая загрузка получает новый префикс, старый удаляется целиком, поэтому
+// кэш превью не нужно инвалидировать отдельно.
+type CoversService struct {
+	repo   CoversRepository
+	store  CoversStore
+	cfg    config.Covers
+	group  singleflight.Group
+	logger *zerolog.Logger
+}
+
+func NewCoversService(repo CoversRepository, store CoversStore, cfg config.Covers) *CoversService {
+	logger := logger.DefaultLogger().With().Str(packageKey, "covers").Logger()
+
+	return &CoversService{repo: repo, store: store, cfg: cfg, logger: &logger}
+}
+
+func (s *CoversService) Upload(ctx context.Context, upload entities.CoverUpload) (image entities.CoverImage, err error) {
+	exists, err := s.repo.IsOwnerExists(ctx, upload.Owner)
+	if err != nil {
+		return entities.CoverImage{}, fmt.Errorf("failed to repo.IsOwnerExists: %w", err)
+	}
+	if !exists {
+		if upload.Owner.Kind == entities.CoverOwnerAlbum {
+			return entities.CoverImage{}, domain.ErrAlbumNotFound
+		}
+		return entities.CoverImage{}, domain.ErrTrackNotFound
+	}
+
+	img, format, err := thumbnail.Decode(upload.Data)
+	if err != nil {
+		return entities.CoverImage{}, fmt.Errorf("%w: %w", domain.ErrCoverInvalid, err)
+	}
+
+	prefix := fmt.Sprintf("covers/%ss/%d/%d", upload.Owner.Kind, upload.Owner.ID, time.Now().UnixNano())
+
+	object, err := s.store.Put(ctx, prefix+"/original", bytes.NewReader(upload.Data))
+	if err != nil {
+		return entities.CoverImage{}, fmt.Errorf("failed to store.Put: %w", err)
+	}
+
+	cover := dao.Cover{
+		BlobKey:     prefix,
+		ContentType: format.ContentType(),
+		ETag:        object.ETag,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}
+
+	previousKey, err := s.repo.SaveCover(ctx, upload.Owner, cover)
+	if err != nil {
+		s.deletePrefix(prefix)
+		return entities.CoverImage{}, fmt.Errorf("failed to repo.SaveCover: %w", err)
+	}
+
+	if previousKey != "" {
+		s.deletePrefix(previousKey)
+	}
+
+	return entities.CoverImage{
+		ContentType: cover.ContentType,
+		ETag:        cover.ETag,
+		Width:       cover.Width,
+		Height:      cover.Height,
+		UpdatedAt:   object.ModTime,
+	}, nil
+}
+
+// SaveEmbedded сохраняет обложку, извлечённую из тегов аудиофайла, для трека,
+// а также для его альбома, если у альбома ещё нет обложки.
+func (s *CoversService) SaveEmbedded(ctx context.Context, trackID int, data []byte) (err error) {
+	track := entities.CoverOwner{Kind: entities.CoverOwnerTrack, ID: trackID}
+	if _, err = s.Upload(ctx, entities.CoverUpload{Owner: track, Data: data}); err != nil {
+		return fmt.Errorf("failed to upload track cover: %w", err)
+	}
+
+	albumID, err := s.repo.GetTrackAlbumID(ctx, trackID)
+	if err != nil {
+		return fmt.Errorf("failed to repo.GetTrackAlbumID: %w", err)
+	}
+	if albumID == 0 {
+		return nil
+	}
+
+	album := entities.CoverOwner{Kind: entities.CoverOwnerAlbum, ID: albumID}
+	if _, err = s.repo.GetCover(ctx, album); !errors.Is(err, domain.ErrCoverNotFound) {
+		return err
+	}
+
+	if _, err = s.Upload(ctx, entities.CoverUpload{Owner: album, Data: data}); err != nil {
+		return fmt.Errorf("failed to upload album cover: %w", err)
+	}
+
+	return nil
+}
+
+// Open возвращает обложку нужного размера в одном из допустимых форматов.
+//
+// Если оригинал подходит по формату и размер не задан, отдаётся сам оригинал,
+// иначе превью (или перекодированный оригинал) создаётся при первом запросе и кэшируется в хранилище.
+func (s *CoversService) Open(ctx context.Context, request entities.CoverRequest) (image entities.CoverImage, file blob.File, err error) {
+	if request.Size != 0 && !slices.Contains(s.cfg.ThumbnailSizes, request.Size) {
+		return entities.CoverImage{}, nil, domain.ErrCoverInvalidSize
+	}
+
+	cover, err := s.repo.GetCover(ctx, request.Owner)
+	if err != nil {
+		return entities.CoverImage{}, nil, fmt.Errorf("failed to repo.GetCover: %w", err)
+	}
+
+	original, err := thumbnail.FormatFromContentType(cover.ContentType)
+	if err != nil {
+		return entities.CoverImage{}, nil, err
+	}
+
+	format, ok := negotiateFormat(original, request.Accept)
+	if !ok {
+		return entities.CoverImage{}, nil, domain.ErrCoverNotAcceptable
+	}
+
+	image = entities.CoverImage{
+		ContentType: format.ContentType(),
+		ETag:        cover.ETag,
+		Width:       cover.Width,
+		Height:      cover.Height,
+		UpdatedAt:   cover.UpdatedAt,
+	}
+
+	if request.Size == 0 && format == original {
+		if file, _, err = s.store.Open(ctx, cover.BlobKey+"/original"); err != nil {
+			return entities.CoverImage{}, nil, s.openError(err)
+		}
+		return image, file, nil
+	}
+
+	name := "full"
+	if request.Size != 0 {
+		name = fmt.Sprint(request.Size)
+	}
+	key := fmt.Sprintf("%s/%s.%s", cover.BlobKey, name, format)
+
+	image.ETag = fmt.Sprintf("%s-%s-%s", cover.ETag, name, format)
+
+	file, _, err = s.store.Open(ctx, key)
+	if errors.Is(err, blob.ErrNotFound) {
+		// Параллельные запросы одного и того же превью генерируют его один раз,
+		// отмена запроса, запустившего генерацию, не должна ломать остальные.
+		_, err, _ = s.group.Do(key, func() (any, error) {
+			return nil, s.generate(context.WithoutCancel(ctx), cover.BlobKey+"/original", key, request.Size, format)
+		})
+		if err == nil {
+			file, _, err = s.store.Open(ctx, key)
+		}
+	}
+	if err != nil {
+		return entities.CoverImage{}, nil, s.openError(err)
+	}
+
+	return image, file, nil
+}
+
+func (s *CoversService) generate(ctx context.Context, originalKey, key string, size int, format thumbnail.Format) error {
+	original, _, err := s.store.Open(ctx, originalKey)
+	if err != nil {
+		return fmt.Errorf("failed to store.Open: %w", err)
+	}
+	defer original.Close()
+
+	data, err := io.ReadAll(original)
+	if err != nil {
+		return fmt.Errorf("failed to read original: %w", err)
+	}
+
+	img, _, err := thumbnail.Decode(data)
+	if err != nil {
+		return fmt.Errorf("failed to thumbnail.Decode: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err = thumbnail.Encode(&buf, thumbnail.Fit(img, size), format, s.cfg.JPEGQuality); err != nil {
+		return fmt.Errorf("failed to thumbnail.Encode: %w", err)
+	}
+
+	if _, err = s.store.Put(ctx, key, &buf); err != nil {
+		return fmt.Errorf("failed to store.Put: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CoversService) openError(err error) error {
+	if errors.Is(err, blob.ErrNotFound) {
+		return domain.ErrCoverNotFound
+	}
+
+	return fmt.Errorf("failed to open cover: %w", err)
+}
+
+// deletePrefix удаляет версию обложки вне контекста запроса, ошибка только логируется.
+func (s *CoversService) deletePrefix(prefix string) {
+	if err := s.store.DeletePrefix(context.Background(), prefix); err != nil {
+		s.logger.Err(err).Str("prefix", prefix).Msg("failed to store.DeletePrefix")
+	}
+}
+
+// negotiateFormat выбирает первый допустимый формат в порядке предпочтения, "*/*" и "image/*" означают формат оригинала.
+func negotiateFormat(original thumbnail.Format, accept []string) (thumbnail.Format, bool) {
+	if len(accept) == 0 {
+		return original, true
+	}
+
+	for _, mediaType := range accept {
+		if mediaType == "*/*" || mediaType == "image/*" {
+			return original, true
+		}
+		if format, err := thumbnail.FormatFromContentType(mediaType); err == nil {
+			return format, true
+		}
+	}
+
+	return "", false
+}
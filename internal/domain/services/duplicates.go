@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/pkg/minhash"
+)
+
+const (
+	// duplicateFoldedConfidence названия отличаются только регистром, шириной символов или пробелами.
+	duplicateFoldedConfidence = 0.95
+	// duplicateSuffixConfidence названия отличаются суффиксом версии: это может быть другая запись (live, remix).
+	duplicateSuffixConfidence = 0.7
+)
+
+type DuplicatesRepository interface {
+	GetTrackTitles(ctx context.Context, fn func(track entities.DuplicateTrack) error) (err error)
+	GetDuplicateTracks(ctx context.Context, ids []int) (tracks []entities.DuplicateTrack, err error)
+	GetLyricSignatures(ctx context.Context, ids []int) (signatures map[int][]uint64, err error)
+	MergeTracks(ctx context.Context, target int, sources []int) (err error)
+}
+
+// DuplicatesService ищет треки одного исполнителя, отличающиеся только оформлением названия.
+type DuplicatesService struct {
+	repo DuplicatesRepository
+}
+
+func NewDuplicatesService(repo DuplicatesRepository) *DuplicatesService {
+	return &DuplicatesService{repo: repo}
+}
+
+// Clusters возвращает кластеры дубликатов по убыванию уверенности.
+func (s *DuplicatesService) Clusters(ctx context.Context, filters entities.DuplicateFilters) (clusters []entities.DuplicateCluster, err error) {
+	if filters.Limit <= 0 {
+		filters.Limit = entities.DuplicatesDefaultLimit
+	}
+	filters.Limit = min(filters.Limit, entities.DuplicatesMaxLimit)
+
+	var (
+		artistID int
+		groups   = make(map[string][]entities.DuplicateTrack)
+		keys     []string
+	)
+	// flush переносит группы текущего исполнителя из двух и более треков в кластеры.
+	flush := func() {
+		for _, key := range keys {
+			if tracks := groups[key]; len(tracks) > 1 {
+				clusters = append(clusters, entities.DuplicateCluster{
+					Key:      key,
+					ArtistID: tracks[0].ArtistID,
+					Artist:   tracks[0].Artist,
+					Tracks:   tracks,
+				})
+			}
+		}
+		clear(groups)
+		keys = keys[:0]
+	}
+
+	if err = s.repo.GetTrackTitles(ctx, func(track entities.DuplicateTrack) error {
+		if track.ArtistID != artistID {
+			flush()
+			artistID = track.ArtistID
+		}
+
+		key := entities.DuplicateTitleKey(track.Title)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], track)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to repo.GetTrackTitles: %w", err)
+	}
+	flush()
+
+	var signatures map[int][]uint64
+	if filters.Lyrics && len(clusters) > 0 {
+		var ids []int
+		for _, cluster := range clusters {
+			for _, track := range cluster.Tracks {
+				ids = append(ids, track.TrackID)
+			}
+		}
+		if signatures, err = s.repo.GetLyricSignatures(ctx, ids); err != nil {
+			return nil, fmt.Errorf("failed to repo.GetLyricSignatures: %w", err)
+		}
+	}
+
+	scored := make([]entities.DuplicateCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		scoreCluster(&cluster, signatures)
+		if cluster.Confidence >= filters.MinConfidence {
+			scored = append(scored, cluster)
+		}
+	}
+
+	slices.SortStableFunc(scored, func(a, b entities.DuplicateCluster) int {
+		if a.Confidence != b.Confidence {
+			if a.Confidence > b.Confidence {
+				return -1
+			}
+			return 1
+		}
+		return a.CanonicalTrackID - b.CanonicalTrackID
+	})
+
+	if filters.Offset >= len(scored) {
+		return []entities.DuplicateCluster{}, nil
+	}
+	scored = scored[filters.Offset:]
+
+	return scored[:min(filters.Limit, len(scored))], nil
+}
+
+// Merge сливает дубликаты в целевой трек. Все треки должны быть одного исполнителя
+// и с тем же ключом названия, что и целевой.
+func (s *DuplicatesService) Merge(ctx context.Context, merge entities.DuplicateMerge) (err error) {
+	sources := make([]int, 0, len(merge.TrackIDs))
+	for _, id := range merge.TrackIDs {
+		if id != merge.TargetTrackID && !slices.Contains(sources, id) {
+			sources = append(sources, id)
+		}
+	}
+	if len(sources) == 0 || len(sources) > entities.DuplicatesMaxMerge {
+		return fmt.Errorf("%w: 1-%d tracks other than the target are required", domain.ErrDuplicateMergeInvalid, entities.DuplicatesMaxMerge)
+	}
+
+	tracks, err := s.repo.GetDuplicateTracks(ctx, append([]int{merge.TargetTrackID}, sources...))
+	if err != nil {
+		return fmt.Errorf("failed to repo.GetDuplicateTracks: %w", err)
+	}
+	if len(tracks) != len(sources)+1 {
+		return domain.ErrTrackNotFound
+	}
+
+	target := tracks[slices.IndexFunc(tracks, func(track entities.DuplicateTrack) bool {
+		return track.TrackID == merge.TargetTrackID
+	})]
+	targetKey := entities.DuplicateTitleKey(target.Title)
+
+	var mismatched []string
+	for _, track := range tracks {
+		if track.ArtistID != target.ArtistID || entities.DuplicateTitleKey(track.Title) != targetKey {
+			mismatched = append(mismatched, strconv.Itoa(track.TrackID))
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateMergeInvalid, strings.Join(mismatched, ", "))
+	}
+
+	if err = s.repo.MergeTracks(ctx, merge.TargetTrackID, sources); err != nil {
+		return fmt.Errorf("failed to repo.MergeTracks: %w", err)
+	}
+
+	return nil
+}
+
+// scoreCluster выбирает канонический трек и считает уверенность кластера: минимум
+// уверенности по парам канонический трек - остальные. При сравнении текстов уверенность
+// пары - среднее уверенности по названию и сходства текстов, если тексты есть у обоих.
+func scoreCluster(cluster *entities.DuplicateCluster, signatures map[int][]uint64) {
+	canonical := 0
+	for i, track := range cluster.Tracks {
+		if entities.FoldTitle(track.Title) == cluster.Key {
+			canonical = i
+			break
+		}
+	}
+	cluster.CanonicalTrackID = cluster.Tracks[canonical].TrackID
+
+	canonicalTitle := entities.FoldTitle(cluster.Tracks[canonical].Title)
+	canonicalSignature := signatures[cluster.CanonicalTrackID]
+
+	cluster.Confidence = 1
+	for i := range cluster.Tracks {
+		if i == canonical {
+			continue
+		}
+		track := &cluster.Tracks[i]
+
+		confidence := duplicateSuffixConfidence
+		if entities.FoldTitle(track.Title) == canonicalTitle {
+			confidence = duplicateFoldedConfidence
+		}
+
+		if signature, ok := signatures[track.TrackID]; ok && len(canonicalSignature) > 0 {
+			similarity := minhash.Similarity(canonicalSignature, signature)
+			track.Similarity = &similarity
+			confidence = (confidence + similarity) / 2
+		}
+
+		cluster.Confidence = min(cluster.Confidence, confidence)
+	}
+}
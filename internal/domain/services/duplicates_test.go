@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/mocks/internal_/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var duplicateTracks = []entities.DuplicateTrack{
+	{TrackID: 1, ArtistID: 1, Artist: "Queen", Title: "Bohemian Rhapsody (Remastered 2011)"},
+	{TrackID: 2, ArtistID: 1, Artist: "Queen", Title: "Bohemian Rhapsody"},
+	{TrackID: 3, ArtistID: 1, Artist: "Queen", Title: "Bohemian Rhapsody - Live"},
+	{TrackID: 4, ArtistID: 1, Artist: "Queen", Title: "Radio Ga Ga"},
+	{TrackID: 5, ArtistID: 2, Artist: "Кино", Title: "Кукушка"},
+	{TrackID: 6, ArtistID: 2, Artist: "Кино", Title: "КУКУШКА"},
+	{TrackID: 7, ArtistID: 3, Artist: "Ария", Title: "Кукушка"},
+}
+
+func TestDuplicatesServiceClusters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		filters       entities.DuplicateFilters
+		expectedIDs   [][]int
+		expectedCanon []int
+	}{
+		{"case: all", entities.DuplicateFilters{}, [][]int{{5, 6}, {1, 2, 3}}, []int{5, 2}},
+		{"case: min confidence", entities.DuplicateFilters{MinConfidence: 0.9}, [][]int{{5, 6}}, []int{5}},
+		{"case: offset", entities.DuplicateFilters{Offset: 1}, [][]int{{1, 2, 3}}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockDuplicatesRepository(t)
+			repo.EXPECT().GetTrackTitles(mock.Anything, mock.Anything).RunAndReturn(
+				func(_ context.Context, fn func(entities.DuplicateTrack) error) error {
+					for _, track := range duplicateTracks {
+						if err := fn(track); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+
+			clusters, err := services.NewDuplicatesService(repo).Clusters(context.Background(), tt.filters)
+			require.NoError(t, err)
+
+			ids := make([][]int, 0, len(clusters))
+			canon := make([]int, 0, len(clusters))
+			for _, cluster := range clusters {
+				var clusterIDs []int
+				for _, track := range cluster.Tracks {
+					clusterIDs = append(clusterIDs, track.TrackID)
+				}
+				ids = append(ids, clusterIDs)
+				canon = append(canon, cluster.CanonicalTrackID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedCanon, canon)
+		})
+	}
+}
+
+func TestDuplicatesServiceMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		merge       entities.DuplicateMerge
+		expectedErr error
+	}{
+		{"case: merged", entities.DuplicateMerge{TargetTrackID: 2, TrackIDs: []int{1, 3, 3, 2}}, nil},
+		{"case: only target", entities.DuplicateMerge{TargetTrackID: 2, TrackIDs: []int{2}}, domain.ErrDuplicateMergeInvalid},
+		{"case: other title", entities.DuplicateMerge{TargetTrackID: 2, TrackIDs: []int{4}}, domain.ErrDuplicateMergeInvalid},
+		{"case: other artist", entities.DuplicateMerge{TargetTrackID: 5, TrackIDs: []int{7}}, domain.ErrDuplicateMergeInvalid},
+		{"case: unknown track", entities.DuplicateMerge{TargetTrackID: 2, TrackIDs: []int{100}}, domain.ErrTrackNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockDuplicatesRepository(t)
+			repo.EXPECT().GetDuplicateTracks(mock.Anything, mock.Anything).RunAndReturn(
+				func(_ context.Context, ids []int) ([]entities.DuplicateTrack, error) {
+					var tracks []entities.DuplicateTrack
+					for _, track := range duplicateTracks {
+						for _, id := range ids {
+							if track.TrackID == id {
+								tracks = append(tracks, track)
+							}
+						}
+					}
+					return tracks, nil
+				}).Maybe()
+			if tt.expectedErr == nil {
+				repo.EXPECT().MergeTracks(mock.Anything, 2, []int{1, 3}).Return(nil)
+			}
+
+			err := services.NewDuplicatesService(repo).Merge(context.Background(), tt.merge)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
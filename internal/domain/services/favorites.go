@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+)
+
+type FavoritesRepository interface {
+	AddFavorite(ctx context.Context, userID int, trackID int) (favoritedAt time.Time, created bool, err error)
+	DeleteFavorite(ctx context.Context, userID int, trackID int) (err error)
+	GetFavorites(ctx context.Context, filter entities.FavoriteGetListFilters) (favorites []entities.Favorite, err error)
+}
+
+type FavoritesService struct {
+	repo FavoritesRepository
+}
+
+func NewFavoritesService(repo FavoritesRepository) *FavoritesService {
+	return &FavoritesService{repo: repo}
+}
+
+// Add добавляет трек в избранное. Повторное добавление не меняет время добавления, created = false.
+func (s *FavoritesService) Add(ctx context.Context, userID int, trackID int) (favoritedAt time.Time, created bool, err error) {
+	if favoritedAt, created, err = s.repo.AddFavorite(ctx, userID, trackID); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to repo.AddFavorite: %w", err)
+	}
+
+	return favoritedAt, created, nil
+}
+
+func (s *FavoritesService) Remove(ctx context.Context, userID int, trackID int) (err error) {
+	if err = s.repo.DeleteFavorite(ctx, userID, trackID); err != nil {
+		return fmt.Errorf("failed to repo.DeleteFavorite: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FavoritesService) List(ctx context.Context, filters entities.FavoriteGetListFilters) (favorites []entities.Favorite, err error) {
+	if !entities.ValidFavoriteSort(filters.Sort) {
+		return nil, fmt.Errorf("%w: %q", domain.ErrFavoriteInvalidSort, filters.Sort)
+	}
+	if filters.Limit == 0 {
+		filters.Limit = entities.FavoritesDefaultLimit
+	}
+
+	if favorites, err = s.repo.GetFavorites(ctx, filters); err != nil {
+		return nil, fmt.Errorf("failed to repo.GetFavorites: %w", err)
+	}
+
+	// Список уже отфильтрован по пользователю, поэтому флаг известен без запроса.
+	for i := range favorites {
+		isFavorite := true
+		favorites[i].Track.IsFavorite = &isFavorite
+	}
+
+	return favorites, nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/langdetect"
+)
+
+type LanguagesRepository interface {
+	GetLyrics(ctx context.Context, afterID int, limit int, missingOnly bool) (tracks []entities.Track, err error)
+	SetLyricLanguages(ctx context.Context, languages []dao.LyricLanguage) (err error)
+}
+
+// LanguagesService определяет язык текстов треков, созданных до появления определения языка.
+//
+// Новые и изменённые тексты определяет TracksService в той же транзакции, что меняет текст.
+type LanguagesService struct {
+	repo LanguagesRepository
+}
+
+func NewLanguagesService(repo LanguagesRepository) *LanguagesService {
+	return &LanguagesService{repo: repo}
+}
+
+// Backfill определяет язык текстов пакетами по batch треков. Если all, язык пересчитывается
+// у всех треков, иначе только у треков без языка. Возвращает количество обработанных треков.
+func (s *LanguagesService) Backfill(ctx context.Context, batch int, all bool) (processed int, err error) {
+	afterID := 0
+	for {
+		tracks, err := s.repo.GetLyrics(ctx, afterID, batch, !all)
+		if err != nil {
+			return processed, fmt.Errorf("failed to repo.GetLyrics: %w", err)
+		}
+		if len(tracks) == 0 {
+			return processed, nil
+		}
+
+		languages := make([]dao.LyricLanguage, 0, len(tracks))
+		for _, track := range tracks {
+			languages = append(languages, lyricLanguage(track.ID, track.Lyric))
+		}
+
+		if err = s.repo.SetLyricLanguages(ctx, languages); err != nil {
+			return processed, fmt.Errorf("failed to repo.SetLyricLanguages: %w", err)
+		}
+		processed += len(tracks)
+		afterID = tracks[len(tracks)-1].ID
+
+		if len(tracks) < batch {
+			return processed, nil
+		}
+	}
+}
+
+// lyricLanguage язык текста из куплетов.
+func lyricLanguage(trackID int, verses []string) dao.LyricLanguage {
+	result := langdetect.Detect(strings.Join(verses, "\n"))
+
+	return dao.LyricLanguage{
+		TrackID:    trackID,
+		Language:   result.Language,
+		Confidence: result.Confidence,
+	}
+}
@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/mocks/internal_/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguagesServiceBackfill(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		all         bool
+		missingOnly bool
+	}{
+		{"case: missing only", false, true},
+		{"case: all", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var languages []dao.LyricLanguage
+
+			repo := mocks.NewMockLanguagesRepository(t)
+			repo.EXPECT().GetLyrics(mock.Anything, 0, 2, tt.missingOnly).Return([]entities.Track{
+				{ID: 1, Lyric: []string{"Группа крови на рукаве", "Мой порядковый номер на рукаве"}},
+				{ID: 4, Lyric: []string{"Is this the real life? Is this just fantasy?"}},
+			}, nil)
+			repo.EXPECT().GetLyrics(mock.Anything, 4, 2, tt.missingOnly).Return([]entities.Track{
+				{ID: 7, Lyric: []string{}},
+			}, nil)
+			repo.EXPECT().SetLyricLanguages(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, batch []dao.LyricLanguage) error {
+					languages = append(languages, batch...)
+					return nil
+				}).Times(2)
+
+			processed, err := services.NewLanguagesService(repo).Backfill(context.Background(), 2, tt.all)
+			require.NoError(t, err)
+			assert.Equal(t, 3, processed)
+
+			require.Len(t, languages, 3)
+			assert.Equal(t, "ru", languages[0].Language)
+			assert.Equal(t, "en", languages[1].Language)
+			assert.Equal(t, dao.LyricLanguage{TrackID: 7}, languages[2])
+		})
+	}
+}
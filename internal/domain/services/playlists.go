@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type PlaylistsRepository interface {
+	CreatePlaylist(ctx context.Context, playlist dao.Playlist) (id int, err error)
+	GetPlaylists(ctx context.Context, limit int, offset int) (playlists []dao.Playlist, err error)
+	GetPlaylist(ctx context.Context, id int) (playlist dao.Playlist, err error)
+	GetPlaylistEntries(ctx context.Context, playlistID int) (entries []dao.PlaylistEntry, err error)
+	UpdatePlaylist(ctx context.Context, id int, name *string, description *string) (err error)
+	DeletePlaylist(ctx context.Context, id int) (err error)
+	AddEntry(ctx context.Context, playlistID int, trackID int, index int) (entryID int, err error)
+	MoveEntry(ctx context.Context, playlistID int, entryID int, index int) (err error)
+	DeleteEntry(ctx context.Context, playlistID int, entryID int) (err error)
+	CreatePlaylistWithEntries(ctx context.Context, playlist dao.Playlist, trackIDs []int) (id int, err error)
+	FindTracks(ctx context.Context, tracks []entities.TrackCreate) (refs []dao.TrackRef, err error)
+}
+
+// TrackCreator создаёт недостающие при импорте треки.
+type TrackCreator interface {
+	Create(ctx context.Context, track entities.TrackCreate) error
+}
+
+type PlaylistsService struct {
+	repo   PlaylistsRepository
+	tracks TrackCreator
+}
+
+func NewPlaylistsService(repo PlaylistsRepository, tracks TrackCreator) *PlaylistsService {
+	return &PlaylistsService{repo: repo, tracks: tracks}
+}
+
+func (s *PlaylistsService) Create(ctx context.Context, playlist entities.PlaylistCreate) (id int, err error) {
+	if id, err = s.repo.CreatePlaylist(ctx, dao.Playlist{
+		Name:        playlist.Name,
+		Description: playlist.Description,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to repo.CreatePlaylist: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *PlaylistsService) GetList(ctx context.Context, filters entities.PlaylistGetListFilters) ([]entities.Playlist, error) {
+	playlistsDAO, err := s.repo.GetPlaylists(ctx, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.GetPlaylists: %w", err)
+	}
+
+	playlists := make([]entities.Playlist, 0, len(playlistsDAO))
+	for _, playlist := range playlistsDAO {
+		playlists = append(playlists, playlistFromDAO(playlist))
+	}
+
+	return playlists, nil
+}
+
+// GetByID возвращает плейлист вместе с записями.
+func (s *PlaylistsService) GetByID(ctx context.Context, id int) (entities.Playlist, error) {
+	playlistDAO, err := s.repo.GetPlaylist(ctx, id)
+	if err != nil {
+		return entities.Playlist{}, fmt.Errorf("failed to repo.GetPlaylist: %w", err)
+	}
+
+	entriesDAO, err := s.repo.GetPlaylistEntries(ctx, id)
+	if err != nil {
+		return entities.Playlist{}, fmt.Errorf("failed to repo.GetPlaylistEntries: %w", err)
+	}
+
+	playlist := playlistFromDAO(playlistDAO)
+	playlist.TracksCount = len(entriesDAO)
+	playlist.Entries = make([]entities.PlaylistEntry, 0, len(entriesDAO))
+	for i, entry := range entriesDAO {
+		playlist.Entries = append(playlist.Entries, entities.PlaylistEntry{
+			EntryID:  entry.EntryID,
+			TrackID:  entry.TrackID,
+			Artist:   entry.Artist,
+			Track:    entry.Title,
+			Link:     entry.Link,
+			Duration: time.Duration(entry.DurationMs) * time.Millisecond,
+			HasAudio: entry.HasAudio,
+			Index:    i,
+			AddedAt:  entry.CreatedAt,
+		})
+	}
+
+	return playlist, nil
+}
+
+func (s *PlaylistsService) Update(ctx context.Context, playlist entities.PlaylistUpdate) (err error) {
+	if err = s.repo.UpdatePlaylist(ctx, playlist.PlaylistID, playlist.Name, playlist.Description); err != nil {
+		return fmt.Errorf("failed to repo.UpdatePlaylist: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PlaylistsService) Delete(ctx context.Context, id int) (err error) {
+	if err = s.repo.DeletePlaylist(ctx, id); err != nil {
+		return fmt.Errorf("failed to repo.DeletePlaylist: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PlaylistsService) AddEntry(ctx context.Context, entry entities.PlaylistEntryAdd) (entryID int, err error) {
+	index := -1
+	if entry.Index != nil {
+		index = *entry.Index
+	}
+
+	if entryID, err = s.repo.AddEntry(ctx, entry.PlaylistID, entry.TrackID, index); err != nil {
+		return 0, fmt.Errorf("failed to repo.AddEntry: %w", err)
+	}
+
+	return entryID, nil
+}
+
+func (s *PlaylistsService) MoveEntry(ctx context.Context, move entities.PlaylistEntryMove) (err error) {
+	if err = s.repo.MoveEntry(ctx, move.PlaylistID, move.EntryID, move.Index); err != nil {
+		return fmt.Errorf("failed to repo.MoveEntry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PlaylistsService) DeleteEntry(ctx context.Context, playlistID int, entryID int) (err error) {
+	if err = s.repo.DeleteEntry(ctx, playlistID, entryID); err != nil {
+		return fmt.Errorf("failed to repo.DeleteEntry: %w", err)
+	}
+
+	return nil
+}
+
+// Import создаёт плейлист из записей файла. Несопоставленные записи пропускаются и попадают в отчёт.
+func (s *PlaylistsService) Import(ctx context.Context, imp entities.PlaylistImport) (report entities.PlaylistImportReport, err error) {
+	refs, err := s.repo.FindTracks(ctx, imp.Entries)
+	if err != nil {
+		return report, fmt.Errorf("failed to repo.FindTracks: %w", err)
+	}
+
+	found := make(map[string]int, len(refs))
+	for _, ref := range refs {
+		found[importKey(ref.Artist, ref.Title)] = ref.TrackID
+	}
+
+	// Создаём каждый недостающий трек один раз, даже если он встречается в файле несколько раз.
+	created := make(map[string]bool)
+	failed := make(map[string]string)
+	if imp.CreateMissing {
+		var toFind []entities.TrackCreate
+		for _, entry := range imp.Entries {
+			key := importKey(entry.Artist, entry.Title)
+			if _, ok := found[key]; ok || created[key] || failed[key] != "" {
+				continue
+			}
+			if entry.Artist == "" || entry.Title == "" {
+				failed[key] = "artist and title are required"
+				continue
+			}
+
+			if err = s.tracks.Create(ctx, entry); err != nil && !errors.Is(err, domain.ErrTrackAlreadyExists) {
+				failed[key] = err.Error()
+				continue
+			}
+			created[key] = true
+			toFind = append(toFind, entry)
+		}
+
+		if len(toFind) > 0 {
+			if refs, err = s.repo.FindTracks(ctx, toFind); err != nil {
+				return report, fmt.Errorf("failed to repo.FindTracks: %w", err)
+			}
+			for _, ref := range refs {
+				found[importKey(ref.Artist, ref.Title)] = ref.TrackID
+			}
+		}
+	}
+
+	trackIDs := make([]int, 0, len(imp.Entries))
+	report.Entries = make([]entities.PlaylistImportResult, 0, len(imp.Entries))
+	for i, entry := range imp.Entries {
+		key := importKey(entry.Artist, entry.Title)
+		result := entities.PlaylistImportResult{Index: i, Artist: entry.Artist, Title: entry.Title}
+
+		switch trackID, ok := found[key]; {
+		case ok && created[key]:
+			result.Status, result.TrackID = entities.PlaylistImportStatusCreated, trackID
+			report.Created++
+		case ok:
+			result.Status, result.TrackID = entities.PlaylistImportStatusMatched, trackID
+			report.Matched++
+		case failed[key] != "":
+			result.Status, result.Error = entities.PlaylistImportStatusFailed, failed[key]
+			report.Failed++
+		case created[key]:
+			result.Status, result.Error = entities.PlaylistImportStatusFailed, "track was created but not found"
+			report.Failed++
+		default:
+			result.Status = entities.PlaylistImportStatusMissing
+			report.Missing++
+		}
+
+		if result.TrackID != 0 {
+			trackIDs = append(trackIDs, result.TrackID)
+		}
+		report.Entries = append(report.Entries, result)
+	}
+
+	if report.PlaylistID, err = s.repo.CreatePlaylistWithEntries(ctx, dao.Playlist{
+		Name:        imp.Name,
+		Description: imp.Description,
+	}, trackIDs); err != nil {
+		return entities.PlaylistImportReport{}, fmt.Errorf("failed to repo.CreatePlaylistWithEntries: %w", err)
+	}
+
+	return report, nil
+}
+
+func importKey(artist, title string) string {
+	return strings.ToLower(artist) + "\x00" + strings.ToLower(title)
+}
+
+func playlistFromDAO(playlist dao.Playlist) entities.Playlist {
+	return entities.Playlist{
+		ID:          playlist.PlaylistID,
+		Name:        playlist.Name,
+		Description: playlist.Description,
+		TracksCount: playlist.TracksCount,
+		CreatedAt:   playlist.CreatedAt,
+		UpdatedAt:   playlist.UpdatedAt,
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+// scrobbleClockSkew насколько время прослушивания может опережать часы сервера.
+const scrobbleClockSkew = 5 * time.Minute
+
+type ScrobblesRepository interface {
+	GetTrackDurations(ctx context.Context, ids []int) (durations map[int]time.Duration, err error)
+	InsertScrobbles(ctx context.Context, userID int, scrobbles []dao.Scrobble, dedupWindow time.Duration) (inserted []bool, err error)
+	GetHistory(ctx context.Context, filter entities.HistoryFilters) (scrobbles []entities.Scrobble, err error)
+}
+
+// TrackFinder ищет треки по исполнителю и названию без учёта регистра.
+type TrackFinder interface {
+	FindTracks(ctx context.Context, tracks []entities.TrackCreate) (refs []dao.TrackRef, err error)
+}
+
+type ScrobblesService struct {
+	repo   ScrobblesRepository
+	tracks TrackFinder
+	cfg    config.Scrobbles
+	now    func() time.Time
+}
+
+func NewScrobblesService(repo ScrobblesRepository, tracks TrackFinder, cfg config.Scrobbles) *ScrobblesService {
+	return &ScrobblesService{repo: repo, tracks: tracks, cfg: cfg, now: time.Now}
+}
+
+// Submit учитывает прослушивания пользователя. Ошибки отдельных событий возвращаются в их результатах,
+// ошибка метода означает, что не сохранено ничего.
+func (s *ScrobblesService) Submit(ctx context.Context, userID int, events []entities.ScrobbleSubmit) (results []entities.ScrobbleResult, err error) {
+	if len(events) > s.cfg.BatchMaxItems {
+		return nil, fmt.Errorf("%w: maximum is %d", domain.ErrScrobbleBatchTooLarge, s.cfg.BatchMaxItems)
+	}
+
+	trackIDs, err := s.resolveTracks(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := s.repo.GetTrackDurations(ctx, trackIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.GetTrackDurations: %w", err)
+	}
+
+	now := s.now()
+	results = make([]entities.ScrobbleResult, len(events))
+
+	var (
+		scrobbles []dao.Scrobble
+		indexes   []int
+	)
+	for i, event := range events {
+		results[i] = entities.ScrobbleResult{Index: i, TrackID: trackIDs[i], Status: entities.ScrobbleStatusRejected}
+
+		trackLength, ok := durations[trackIDs[i]]
+		switch {
+		case !ok:
+			results[i].Reason = domain.ErrTrackNotFound.Error()
+		case event.PlayedAt.After(now.Add(scrobbleClockSkew)):
+			results[i].Reason = "playedAt is in the future"
+		case event.PlayedAt.Before(now.Add(-s.cfg.MaxAge)):
+			results[i].Reason = fmt.Sprintf("playedAt is older than %s", s.cfg.MaxAge)
+		case !s.countsAsPlay(trackLength, event.DurationPlayed):
+			results[i].Status = entities.ScrobbleStatusIgnored
+			results[i].Reason = "played less than the threshold"
+		default:
+			scrobbles = append(scrobbles, dao.Scrobble{
+				TrackID:          trackIDs[i],
+				PlayedAt:         event.PlayedAt,
+				DurationPlayedMs: event.DurationPlayed.Milliseconds(),
+			})
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(scrobbles) == 0 {
+		return results, nil
+	}
+
+	inserted, err := s.repo.InsertScrobbles(ctx, userID, scrobbles, s.cfg.DedupWindow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.InsertScrobbles: %w", err)
+	}
+
+	for j, i := range indexes {
+		results[i].Status = entities.ScrobbleStatusDuplicate
+		if inserted[j] {
+			results[i].Status = entities.ScrobbleStatusAccepted
+		}
+	}
+
+	return results, nil
+}
+
+// resolveTracks возвращает ID трека для каждого события, 0 если трек по исполнителю и названию не найден.
+func (s *ScrobblesService) resolveTracks(ctx context.Context, events []entities.ScrobbleSubmit) ([]int, error) {
+	trackIDs := make([]int, len(events))
+
+	var wanted []entities.TrackCreate
+	for i, event := range events {
+		trackIDs[i] = event.TrackID
+		if event.TrackID == 0 {
+			wanted = append(wanted, entities.TrackCreate{Artist: event.Artist, Title: event.Title})
+		}
+	}
+	if len(wanted) == 0 {
+		return trackIDs, nil
+	}
+
+	refs, err := s.tracks.FindTracks(ctx, wanted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tracks.FindTracks: %w", err)
+	}
+
+	found := make(map[string]int, len(refs))
+	for _, ref := range refs {
+		found[importKey(ref.Artist, ref.Title)] = ref.TrackID
+	}
+	for i, event := range events {
+		if event.TrackID == 0 {
+			trackIDs[i] = found[importKey(event.Artist, event.Title)]
+		}
+	}
+
+	return trackIDs, nil
+}
+
+// countsAsPlay правило засчитывания: трек длиннее MinTrackLength и прослушан на PlayFraction длины,
+// но не больше PlayThreshold. Если длина трека неизвестна, достаточно прослушать MinTrackLength.
+func (s *ScrobblesService) countsAsPlay(trackLength time.Duration, played time.Duration) bool {
+	if trackLength == 0 {
+		return played >= s.cfg.MinTrackLength
+	}
+	if trackLength < s.cfg.MinTrackLength {
+		return false
+	}
+
+	threshold := min(time.Duration(float64(trackLength)*s.cfg.PlayFraction), s.cfg.PlayThreshold)
+
+	return played >= threshold
+}
+
+// History страница истории прослушиваний от новых к старым.
+func (s *ScrobblesService) History(ctx context.Context, filters entities.HistoryFilters) (page entities.HistoryPage, err error) {
+	if !filters.From.IsZero() && !filters.To.IsZero() && !filters.From.Before(filters.To) {
+		return entities.HistoryPage{}, fmt.Errorf("%w: from must be before to", domain.ErrScrobbleHistoryInvalid)
+	}
+
+	if filters.Limit == 0 {
+		filters.Limit = entities.HistoryDefaultLimit
+	}
+	limit := filters.Limit
+	// Лишняя строка показывает, есть ли следующая страница.
+	filters.Limit++
+
+	scrobbles, err := s.repo.GetHistory(ctx, filters)
+	if err != nil {
+		return entities.HistoryPage{}, fmt.Errorf("failed to repo.GetHistory: %w", err)
+	}
+
+	if len(scrobbles) > limit {
+		scrobbles = scrobbles[:limit]
+		last := scrobbles[limit-1]
+		page.Next = &entities.HistoryCursor{PlayedAt: last.PlayedAt, ID: last.ID}
+	}
+	page.Scrobbles = scrobbles
+
+	return page, nil
+}
@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/mocks/internal_/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrobblesServiceSubmit(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Scrobbles{
+		DedupWindow:    30 * time.Second,
+		MinTrackLength: 30 * time.Second,
+		PlayFraction:   0.5,
+		PlayThreshold:  4 * time.Minute,
+		MaxAge:         14 * 24 * time.Hour,
+		BatchMaxItems:  50,
+	}
+	now := time.Now()
+
+	// 1 - трек на 3 минуты, 2 - на 20 минут, 3 - короче 30 секунд, 4 - без аудио.
+	durations := map[int]time.Duration{1: 3 * time.Minute, 2: 20 * time.Minute, 3: 20 * time.Second, 4: 0}
+
+	tests := []struct {
+		name     string
+		event    entities.ScrobbleSubmit
+		inserted bool
+		expected entities.ScrobbleStatus
+	}{
+		{"case: half of the track", entities.ScrobbleSubmit{TrackID: 1, PlayedAt: now, DurationPlayed: 90 * time.Second}, true, entities.ScrobbleStatusAccepted},
+		{"case: less than half", entities.ScrobbleSubmit{TrackID: 1, PlayedAt: now, DurationPlayed: 89 * time.Second}, false, entities.ScrobbleStatusIgnored},
+		{"case: long track after 4 minutes", entities.ScrobbleSubmit{TrackID: 2, PlayedAt: now, DurationPlayed: 4 * time.Minute}, true, entities.ScrobbleStatusAccepted},
+		{"case: short track", entities.ScrobbleSubmit{TrackID: 3, PlayedAt: now, DurationPlayed: 20 * time.Second}, false, entities.ScrobbleStatusIgnored},
+		{"case: unknown length", entities.ScrobbleSubmit{TrackID: 4, PlayedAt: now, DurationPlayed: 30 * time.Second}, true, entities.ScrobbleStatusAccepted},
+		{"case: duplicate", entities.ScrobbleSubmit{TrackID: 1, PlayedAt: now, DurationPlayed: 3 * time.Minute}, false, entities.ScrobbleStatusDuplicate},
+		{"case: unknown track", entities.ScrobbleSubmit{TrackID: 5, PlayedAt: now, DurationPlayed: 3 * time.Minute}, false, entities.ScrobbleStatusRejected},
+		{"case: by artist and title", entities.ScrobbleSubmit{Artist: "Muse", Title: "Uprising", PlayedAt: now, DurationPlayed: 3 * time.Minute}, true, entities.ScrobbleStatusAccepted},
+		{"case: too old", entities.ScrobbleSubmit{TrackID: 1, PlayedAt: now.Add(-15 * 24 * time.Hour), DurationPlayed: 3 * time.Minute}, false, entities.ScrobbleStatusRejected},
+		{"case: in the future", entities.ScrobbleSubmit{TrackID: 1, PlayedAt: now.Add(time.Hour), DurationPlayed: 3 * time.Minute}, false, entities.ScrobbleStatusRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockScrobblesRepository(t)
+			finder := mocks.NewMockTrackFinder(t)
+
+			if tt.event.TrackID == 0 {
+				finder.EXPECT().FindTracks(mock.Anything, mock.Anything).Return([]dao.TrackRef{{TrackID: 1, Artist: "muse", Title: "uprising"}}, nil)
+			}
+			repo.EXPECT().GetTrackDurations(mock.Anything, mock.Anything).Return(durations, nil)
+			if tt.expected == entities.ScrobbleStatusAccepted || tt.expected == entities.ScrobbleStatusDuplicate {
+				repo.EXPECT().InsertScrobbles(mock.Anything, 7, mock.Anything, cfg.DedupWindow).Return([]bool{tt.inserted}, nil)
+			}
+
+			results, err := services.NewScrobblesService(repo, finder, cfg).Submit(context.Background(), 7, []entities.ScrobbleSubmit{tt.event})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.expected, results[0].Status, results[0].Reason)
+		})
+	}
+}
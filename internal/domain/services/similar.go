@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/minhash"
+)
+
+const (
+	// lyricShingleSize шинглы текста - пары соседних слов.
+	lyricShingleSize = 2
+	// similarCandidatesLimit сколько кандидатов из LSH сравнивается по полной сигнатуре.
+	similarCandidatesLimit = 500
+	// similarMinSimilarity кандидаты с меньшим сходством совпали по полосе случайно.
+	similarMinSimilarity = 0.05
+)
+
+type SimilarTracksRepository interface {
+	GetLyricSignature(ctx context.Context, trackID int) (signature dao.LyricSignature, artistID int, err error)
+	GetSimilarCandidates(ctx context.Context, trackID int, bands []uint64, limit int) (candidates []dao.SimilarCandidate, err error)
+	GetUnindexedLyrics(ctx context.Context, limit int) (tracks []entities.Track, err error)
+	SetLyricSignatures(ctx context.Context, signatures []dao.LyricSignature) (err error)
+}
+
+// SimilarTracksService ищет треки с похожими текстами по MinHash сигнатурам.
+//
+// Сигнатуры пересчитываются TracksService в той же транзакции, что меняет текст,
+// треки из импорта индексирует IndexMissing.
+type SimilarTracksService struct {
+	repo SimilarTracksRepository
+}
+
+func NewSimilarTracksService(repo SimilarTracksRepository) *SimilarTracksService {
+	return &SimilarTracksService{repo: repo}
+}
+
+// Similar возвращает похожие треки. Для трека без текста (или ещё не проиндексированного) список пустой.
+func (s *SimilarTracksService) Similar(ctx context.Context, filters entities.SimilarTracksFilters) (tracks []entities.SimilarTrack, err error) {
+	if filters.Limit <= 0 {
+		filters.Limit = entities.SimilarTracksDefaultLimit
+	}
+	filters.Limit = min(filters.Limit, entities.SimilarTracksMaxLimit)
+
+	target, artistID, err := s.repo.GetLyricSignature(ctx, filters.TrackID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.GetLyricSignature: %w", err)
+	}
+	if len(target.Signature) == 0 {
+		return []entities.SimilarTrack{}, nil
+	}
+
+	candidates, err := s.repo.GetSimilarCandidates(ctx, filters.TrackID, minhash.BandHashes(target.Signature), similarCandidatesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.GetSimilarCandidates: %w", err)
+	}
+
+	tracks = make([]entities.SimilarTrack, 0, len(candidates))
+	for _, candidate := range candidates {
+		similarity := minhash.Similarity(target.Signature, candidate.Signature)
+		if similarity < similarMinSimilarity {
+			continue
+		}
+		tracks = append(tracks, entities.SimilarTrack{
+			TrackID:    candidate.TrackID,
+			ArtistID:   candidate.ArtistID,
+			Artist:     candidate.Artist,
+			Title:      candidate.Title,
+			Similarity: similarity,
+		})
+	}
+
+	return rankSimilar(tracks, artistID, filters.Diversity, filters.Limit), nil
+}
+
+// IndexMissing считает сигнатуры треков, у которых их нет, пачками по batch. Возвращает
+// количество проиндексированных треков.
+func (s *SimilarTracksService) IndexMissing(ctx context.Context, batch int) (indexed int, err error) {
+	for {
+		tracks, err := s.repo.GetUnindexedLyrics(ctx, batch)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to repo.GetUnindexedLyrics: %w", err)
+		}
+		if len(tracks) == 0 {
+			return indexed, nil
+		}
+
+		signatures := make([]dao.LyricSignature, 0, len(tracks))
+		for _, track := range tracks {
+			signatures = append(signatures, lyricSignature(track.ID, track.Lyric))
+		}
+
+		if err = s.repo.SetLyricSignatures(ctx, signatures); err != nil {
+			return indexed, fmt.Errorf("failed to repo.SetLyricSignatures: %w", err)
+		}
+		indexed += len(tracks)
+
+		if len(tracks) < batch {
+			return indexed, nil
+		}
+	}
+}
+
+// rankSimilar выбирает limit треков жадно: на каждом шаге берётся трек с наибольшим
+// сходством, умноженным на (1 - diversity) в степени количества уже выбранных треков
+// его исполнителя. Исполнитель исходного трека считается уже выбранным один раз.
+func rankSimilar(tracks []entities.SimilarTrack, artistID int, diversity float64, limit int) []entities.SimilarTrack {
+	slices.SortStableFunc(tracks, func(a, b entities.SimilarTrack) int {
+		if a.Similarity != b.Similarity {
+			if a.Similarity > b.Similarity {
+				return -1
+			}
+			return 1
+		}
+		return a.TrackID - b.TrackID
+	})
+
+	if diversity <= 0 {
+		return tracks[:min(limit, len(tracks))]
+	}
+
+	picked := map[int]int{artistID: 1}
+	ranked := make([]entities.SimilarTrack, 0, min(limit, len(tracks)))
+	for len(ranked) < limit && len(tracks) > 0 {
+		best, bestScore := 0, -1.0
+		for i, track := range tracks {
+			// Треки отсортированы по сходству, дальше score не может быть выше.
+			if track.Similarity <= bestScore {
+				break
+			}
+			if score := track.Similarity * math.Pow(1-diversity, float64(picked[track.ArtistID])); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		ranked = append(ranked, tracks[best])
+		picked[tracks[best].ArtistID]++
+		tracks = slices.Delete(tracks, best, best+1)
+	}
+
+	return ranked
+}
+
+// lyricSignature сигнатура текста из куплетов.
+func lyricSignature(trackID int, verses []string) dao.LyricSignature {
+	signature := minhash.Sign(minhash.Shingles(strings.Join(verses, "\n"), lyricShingleSize))
+
+	return dao.LyricSignature{
+		TrackID:   trackID,
+		Signature: signature,
+		Bands:     minhash.BandHashes(signature),
+	}
+}
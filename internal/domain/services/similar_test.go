@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/mocks/internal_/domain/services/mocks"
+	"github.com/neyrzx/youmusic/pkg/minhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testSignature сигнатура, совпадающая с base в первых equal позициях.
+func testSignature(equal int) []uint64 {
+	signature := make([]uint64, minhash.Size)
+	for i := range signature {
+		signature[i] = uint64(i)
+		if i >= equal {
+			signature[i] += 1000
+		}
+	}
+	return signature
+}
+
+func TestSimilarTracksServiceSimilar(t *testing.T) {
+	t.Parallel()
+
+	const targetArtist = 1
+
+	candidates := []dao.SimilarCandidate{
+		{TrackID: 2, ArtistID: targetArtist, Signature: testSignature(120)},
+		{TrackID: 3, ArtistID: targetArtist, Signature: testSignature(110)},
+		{TrackID: 4, ArtistID: 2, Signature: testSignature(100)},
+		{TrackID: 5, ArtistID: 2, Signature: testSignature(90)},
+		{TrackID: 6, ArtistID: 3, Signature: testSignature(2)},
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		diversity float64
+		expected  []int
+	}{
+		{"case: by similarity", 0, 0, []int{2, 3, 4, 5}},
+		{"case: limit", 2, 0, []int{2, 3}},
+		{"case: full diversity", 0, 1, []int{4, 2, 3, 5}},
+		{"case: partial diversity", 3, 0.2, []int{4, 2, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockSimilarTracksRepository(t)
+			repo.EXPECT().GetLyricSignature(mock.Anything, 1).
+				Return(dao.LyricSignature{TrackID: 1, Signature: testSignature(minhash.Size)}, targetArtist, nil)
+			repo.EXPECT().GetSimilarCandidates(mock.Anything, 1, mock.Anything, mock.Anything).
+				Return(append([]dao.SimilarCandidate(nil), candidates...), nil)
+
+			tracks, err := services.NewSimilarTracksService(repo).Similar(context.Background(), entities.SimilarTracksFilters{
+				TrackID:   1,
+				Limit:     tt.limit,
+				Diversity: tt.diversity,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int, 0, len(tracks))
+			for _, track := range tracks {
+				ids = append(ids, track.TrackID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+)
+
+type SmartPlaylistsRepository interface {
+	CreateSmartPlaylist(ctx context.Context, playlist dao.SmartPlaylist) (id int, err error)
+	GetSmartPlaylists(ctx context.Context, limit int, offset int) (playlists []dao.SmartPlaylist, err error)
+	GetSmartPlaylist(ctx context.Context, id int) (playlist dao.SmartPlaylist, err error)
+	UpdateSmartPlaylist(ctx context.Context, playlist dao.SmartPlaylist) (err error)
+	DeleteSmartPlaylist(ctx context.Context, id int) (err error)
+}
+
+// TracksLister отбор треков по фильтру, тот же, что у списка треков.
+type TracksLister interface {
+	GetList(ctx context.Context, filters entities.TrackGetListFilters) ([]entities.Track, error)
+}
+
+// SmartPlaylistsService хранит правила смарт-плейлистов и вычисляет их треки при чтении.
+type SmartPlaylistsService struct {
+	repo   SmartPlaylistsRepository
+	tracks TracksLister
+}
+
+func NewSmartPlaylistsService(repo SmartPlaylistsRepository, tracks TracksLister) *SmartPlaylistsService {
+	return &SmartPlaylistsService{repo: repo, tracks: tracks}
+}
+
+func (s *SmartPlaylistsService) Create(ctx context.Context, playlist entities.SmartPlaylistCreate) (id int, err error) {
+	playlistDAO, err := smartPlaylistToDAO(0, playlist.Name, playlist.SmartPlaylistQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	if id, err = s.repo.CreateSmartPlaylist(ctx, playlistDAO); err != nil {
+		return 0, fmt.Errorf("failed to repo.CreateSmartPlaylist: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *SmartPlaylistsService) GetList(ctx context.Context, limit int, offset int) ([]entities.SmartPlaylist, error) {
+	playlistsDAO, err := s.repo.GetSmartPlaylists(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repo.GetSmartPlaylists: %w", err)
+	}
+
+	playlists := make([]entities.SmartPlaylist, 0, len(playlistsDAO))
+	for _, playlistDAO := range playlistsDAO {
+		playlist, err := smartPlaylistFromDAO(playlistDAO)
+		if err != nil {
+			return nil, err
+		}
+		playlists = append(playlists, playlist)
+	}
+
+	return playlists, nil
+}
+
+func (s *SmartPlaylistsService) GetByID(ctx context.Context, id int) (entities.SmartPlaylist, error) {
+	playlistDAO, err := s.repo.GetSmartPlaylist(ctx, id)
+	if err != nil {
+		return entities.SmartPlaylist{}, fmt.Errorf("failed to repo.GetSmartPlaylist: %w", err)
+	}
+
+	return smartPlaylistFromDAO(playlistDAO)
+}
+
+func (s *SmartPlaylistsService) Update(ctx context.Context, playlist entities.SmartPlaylistUpdate) (err error) {
+	playlistDAO, err := smartPlaylistToDAO(playlist.SmartPlaylistID, playlist.Name, playlist.SmartPlaylistQuery)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.UpdateSmartPlaylist(ctx, playlistDAO); err != nil {
+		return fmt.Errorf("failed to repo.UpdateSmartPlaylist: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SmartPlaylistsService) Delete(ctx context.Context, id int) (err error) {
+	if err = s.repo.DeleteSmartPlaylist(ctx, id); err != nil {
+		return fmt.Errorf("failed to repo.DeleteSmartPlaylist: %w", err)
+	}
+
+	return nil
+}
+
+// Tracks вычисляет треки сохранённого смарт-плейлиста.
+func (s *SmartPlaylistsService) Tracks(ctx context.Context, id int) ([]entities.Track, error) {
+	playlist, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Preview(ctx, entities.SmartPlaylistQuery{
+		Rule:  playlist.Rule,
+		Sort:  playlist.Sort,
+		Limit: playlist.Limit,
+	})
+}
+
+// Preview вычисляет треки по правилам без сохранения плейлиста.
+func (s *SmartPlaylistsService) Preview(ctx context.Context, query entities.SmartPlaylistQuery) ([]entities.Track, error) {
+	if err := validateSmartPlaylistQuery(&query); err != nil {
+		return nil, err
+	}
+
+	tracks, err := s.tracks.GetList(ctx, entities.TrackGetListFilters{
+		Limit: query.Limit,
+		Rule:  &query.Rule,
+		Sort:  query.Sort,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to tracks.GetList: %w", err)
+	}
+
+	return tracks, nil
+}
+
+// validateSmartPlaylistQuery проверяет правила, порядок и лимит, нулевой лимит заменяется значением по умолчанию.
+func validateSmartPlaylistQuery(query *entities.SmartPlaylistQuery) error {
+	if err := query.Rule.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrSmartPlaylistInvalidRules, err)
+	}
+
+	if !query.Sort.Valid() {
+		return fmt.Errorf("%w: %q", domain.ErrSmartPlaylistInvalidSort, query.Sort)
+	}
+
+	if query.Limit == 0 {
+		query.Limit = entities.SmartPlaylistDefaultLimit
+	}
+	if query.Limit < 0 || query.Limit > entities.SmartPlaylistMaxLimit {
+		return fmt.Errorf("%w: must be between 1 and %d", domain.ErrSmartPlaylistInvalidLimit, entities.SmartPlaylistMaxLimit)
+	}
+
+	return nil
+}
+
+func smartPlaylistToDAO(id int, name string, query entities.SmartPlaylistQuery) (dao.SmartPlaylist, error) {
+	if err := validateSmartPlaylistQuery(&query); err != nil {
+		return dao.SmartPlaylist{}, err
+	}
+
+	rules, err := json.Marshal(query.Rule)
+	if err != nil {
+		return dao.SmartPlaylist{}, fmt.Errorf("failed to json.Marshal rules: %w", err)
+	}
+
+	return dao.SmartPlaylist{
+		SmartPlaylistID: id,
+		Name:            name,
+		Rules:           rules,
+		Sort:            string(query.Sort),
+		Limit:           query.Limit,
+	}, nil
+}
+
+func smartPlaylistFromDAO(playlist dao.SmartPlaylist) (entities.SmartPlaylist, error) {
+	var rule entities.TrackRule
+	if err := json.Unmarshal(playlist.Rules, &rule); err != nil {
+		return entities.SmartPlaylist{}, fmt.Errorf("failed to json.Unmarshal rules of smart playlist %d: %w", playlist.SmartPlaylistID, err)
+	}
+
+	return entities.SmartPlaylist{
+		ID:        playlist.SmartPlaylistID,
+		Name:      playlist.Name,
+		Rule:      rule,
+		Sort:      entities.TrackSort(playlist.Sort),
+		Limit:     playlist.Limit,
+		CreatedAt: playlist.CreatedAt,
+		UpdatedAt: playlist.UpdatedAt,
+	}, nil
+}
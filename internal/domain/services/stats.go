@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+)
+
+type StatsRepository interface {
+	GetTracksPerYear(ctx context.Context) (stats []entities.YearStat, err error)
+	GetTopArtists(ctx context.Context, limit int) (stats []entities.ArtistStat, err error)
+	GetTopTracks(ctx context.Context, since time.Time, limit int) (stats []entities.TrackPlaysStat, err error)
+	GetLyricsStat(ctx context.Context) (stat entities.LyricsStat, err error)
+	GetNewestTracks(ctx context.Context, limit int) (tracks []entities.NewestTrack, err error)
+	RefreshViews(ctx context.Context) (err error)
+}
+
+// StatsService отдаёт статистику из материализованных представлений, данные отстают
+// от таблиц не больше чем на config.Stats.RefreshInterval.
+type StatsService struct {
+	repo StatsRepository
+	now  func() time.Time
+}
+
+func NewStatsService(repo StatsRepository) *StatsService {
+	return &StatsService{repo: repo, now: time.Now}
+}
+
+func (s *StatsService) TracksPerYear(ctx context.Context) (stats []entities.YearStat, err error) {
+	if stats, err = s.repo.GetTracksPerYear(ctx); err != nil {
+		return nil, fmt.Errorf("failed to repo.GetTracksPerYear: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (s *StatsService) TopArtists(ctx context.Context, limit int) (stats []entities.ArtistStat, err error) {
+	if stats, err = s.repo.GetTopArtists(ctx, statsLimit(limit)); err != nil {
+		return nil, fmt.Errorf("failed to repo.GetTopArtists: %w", err)
+	}
+
+	return stats, nil
+}
+
+// TopTracks самые прослушиваемые треки за последние days дней, включая сегодняшний.
+func (s *StatsService) TopTracks(ctx context.Context, days int, limit int) (stats []entities.TrackPlaysStat, err error) {
+	if days <= 0 {
+		days = entities.StatsDefaultDays
+	}
+	days = min(days, entities.StatsMaxDays)
+
+	since := s.now().UTC().AddDate(0, 0, 1-days)
+	if stats, err = s.repo.GetTopTracks(ctx, since, statsLimit(limit)); err != nil {
+		return nil, fmt.Errorf("failed to repo.GetTopTracks: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (s *StatsService) Lyrics(ctx context.Context) (stat entities.LyricsStat, err error) {
+	if stat, err = s.repo.GetLyricsStat(ctx); err != nil {
+		return entities.LyricsStat{}, fmt.Errorf("failed to repo.GetLyricsStat: %w", err)
+	}
+
+	return stat, nil
+}
+
+func (s *StatsService) Newest(ctx context.Context, limit int) (tracks []entities.NewestTrack, err error) {
+	if tracks, err = s.repo.GetNewestTracks(ctx, statsLimit(limit)); err != nil {
+		return nil, fmt.Errorf("failed to repo.GetNewestTracks: %w", err)
+	}
+
+	return tracks, nil
+}
+
+// Refresh пересчитывает витрины, вызывается фоновой задачей.
+func (s *StatsService) Refresh(ctx context.Context) (err error) {
+	if err = s.repo.RefreshViews(ctx); err != nil {
+		return fmt.Errorf("failed to repo.RefreshViews: %w", err)
+	}
+
+	return nil
+}
+
+func statsLimit(limit int) int {
+	if limit <= 0 {
+		return entities.StatsDefaultLimit
+	}
+
+	return min(limit, entities.StatsMaxLimit)
+}
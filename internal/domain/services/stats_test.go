@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/mocks/internal_/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsServiceTopTracks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		days          int
+		limit         int
+		expectedDays  int
+		expectedLimit int
+	}{
+		{"case: defaults", 0, 0, 30, 10},
+		{"case: today only", 1, 5, 1, 5},
+		{"case: clamped", 1000, 1000, 365, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var since time.Time
+			repo := mocks.NewMockStatsRepository(t)
+			repo.EXPECT().GetTopTracks(mock.Anything, mock.Anything, tt.expectedLimit).RunAndReturn(
+				func(_ context.Context, s time.Time, _ int) ([]entities.TrackPlaysStat, error) {
+					since = s
+					return nil, nil
+				})
+
+			_, err := services.NewStatsService(repo).TopTracks(context.Background(), tt.days, tt.limit)
+			require.NoError(t, err)
+
+			expected := time.Now().UTC().AddDate(0, 0, 1-tt.expectedDays)
+			assert.WithinDuration(t, expected, since, time.Minute)
+		})
+	}
+}
@@ -2,10 +2,14 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/neyrzx/youmusic/internal/config"
 	"github.com/neyrzx/youmusic/internal/domain/entities"
 	domain "github.com/neyrzx/youmusic/internal/domain/errors"
 	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
@@ -25,11 +29,23 @@ type TracksRepository interface {
 	DeleteTrackByID(ctx context.Context, trackID int) (err error)
 	GetByID(ctx context.Context, ID int) (entities.Track, error)
 	GetArtistIDByTrackID(ctx context.Context, tx pgx.Tx, id int) (artistID int, err error)
-	GetTracksByFilter(ctx context.Context, tx pgx.Tx, filter entities.TrackGetListFilters) (tracks map[int]entities.Track, err error)
+	GetTracksByFilter(ctx context.Context, tx pgx.Tx, filter entities.TrackGetListFilters) (tracks []entities.Track, err error)
 	GetLyricsByTrackIDs(ctx context.Context, tx pgx.Tx, IDs []int) (lyrics []dao.Lyric, err error)
 	GetLyricPaginated(ctx context.Context, tx pgx.Tx, trackID int, offset int) (lyric dao.Lyric, err error)
 	IsTrackExists(ctx context.Context, trackName string, artistName string) (exists bool, err error)
 	IsArtistExists(ctx context.Context, tx pgx.Tx, name string) (id int, exists bool)
+	GetExistingTracks(ctx context.Context, tracks []entities.TrackCreate) (existing []entities.TrackCreate, err error)
+	GetOrCreateArtists(ctx context.Context, tx pgx.Tx, names []string) (ids map[string]int, err error)
+	CreateTracks(ctx context.Context, tx pgx.Tx, tracks []dao.Track) (ids []int, err error)
+	GetTrackID(ctx context.Context, tx pgx.Tx, title string, artistID int) (id int, exists bool, err error)
+	GetOrCreateAlbum(ctx context.Context, tx pgx.Tx, album dao.Album) (id int, err error)
+	SetTrackAlbum(ctx context.Context, tx pgx.Tx, trackID int, albumID int) (err error)
+	SetTrackTags(ctx context.Context, trackID int, tags []string) (err error)
+	SetTrackUpdatedBy(ctx context.Context, tx pgx.Tx, trackID int, userID int) (err error)
+	SetLyricSignature(ctx context.Context, tx pgx.Tx, signature dao.LyricSignature) (err error)
+	SetLyricLanguage(ctx context.Context, tx pgx.Tx, language dao.LyricLanguage) (err error)
+	GetFavoriteTrackIDs(ctx context.Context, userID int, trackIDs []int) (favoriteIDs []int, err error)
+	ExportTracks(ctx context.Context, filter entities.TrackGetListFilters, fn func(track entities.Track) error) (err error)
 	WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error
 }
 
@@ -40,16 +56,19 @@ type TracksInfoGateway interface {
 type TracksService struct {
 	repo        TracksRepository
 	infoGateway TracksInfoGateway
+	cfg         config.TracksService
 }
 
-func NewTracksService(repo TracksRepository, infoGateway TracksInfoGateway) *TracksService {
-	return &TracksService{repo: repo, infoGateway: infoGateway}
+func NewTracksService(repo TracksRepository, infoGateway TracksInfoGateway, cfg config.TracksService) *TracksService {
+	return &TracksService{repo: repo, infoGateway: infoGateway, cfg: cfg}
 }
 
 func (s *TracksService) Create(ctx context.Context, track entities.TrackCreate) (err error) {
 	ctx, cancelFunc := context.WithTimeout(ctx, methodTimout)
 	defer cancelFunc()
 
+	track.Title, track.Artist = entities.NormalizeName(track.Title), entities.NormalizeName(track.Artist)
+
 	trackExists, err := s.repo.IsTrackExists(ctx, track.Title, track.Artist)
 	if err != nil {
 		return fmt.Errorf("failed to repo.IsTrackExists(%s, %s): %w", track.Title, track.Artist, err)
@@ -66,11 +85,21 @@ func (s *TracksService) Create(ctx context.Context, track entities.TrackCreate)
 		return fmt.Errorf("failed to infoGateway.Info(): %w", err)
 	}
 
+	if _, err = s.saveTrack(ctx, track, trackInfo); err != nil {
+		return fmt.Errorf("failed from repo create track: %w", err)
+	}
+
+	return nil
+}
+
+// saveTrack сохраняет трек, исполнителя (если его ещё нет) и текст в одной транзакции.
+func (s *TracksService) saveTrack(ctx context.Context, track entities.TrackCreate, trackInfo entities.TrackInfoResult) (trackID int, err error) {
 	err = s.repo.WithTx(ctx, func(tx pgx.Tx) error {
 		trackDAO := dao.Track{
 			Title:      track.Title,
 			Link:       trackInfo.Link,
 			ReleasedAt: trackInfo.ReleaseDate,
+			CreatedBy:  entities.UserIDFromContext(ctx),
 		}
 		artistID, exists := s.repo.IsArtistExists(ctx, tx, track.Artist)
 		if exists {
@@ -82,7 +111,6 @@ func (s *TracksService) Create(ctx context.Context, track entities.TrackCreate)
 			}
 		}
 
-		var trackID int
 		trackID, err = s.repo.CreateTrack(ctx, tx, trackDAO)
 		if err != nil {
 			return fmt.Errorf("failed to CreateTrack(%v+): %w", trackDAO, err)
@@ -99,13 +127,20 @@ func (s *TracksService) Create(ctx context.Context, track entities.TrackCreate)
 			return fmt.Errorf("failed to CreateLyric for artist (%d, %s): %w", artistID, track.Artist, err)
 		}
 
+		if err = s.repo.SetLyricSignature(ctx, tx, lyricSignature(trackID, lyric)); err != nil {
+			return fmt.Errorf("failed to repo.SetLyricSignature: %w", err)
+		}
+		if err = s.repo.SetLyricLanguage(ctx, tx, lyricLanguage(trackID, lyric)); err != nil {
+			return fmt.Errorf("failed to repo.SetLyricLanguage: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("failed from repo create track: %w", err)
+		return 0, err
 	}
 
-	return nil
+	return trackID, nil
 }
 
 func (s *TracksService) GetByID(ctx context.Context, id int) (track entities.Track, err error) {
@@ -116,7 +151,12 @@ func (s *TracksService) GetByID(ctx context.Context, id int) (track entities.Tra
 		return entities.Track{}, fmt.Errorf("failed to repo.GetByID: %w", err)
 	}
 
-	return track, nil
+	tracks := []entities.Track{track}
+	if err = s.markFavorites(ctx, tracks); err != nil {
+		return entities.Track{}, err
+	}
+
+	return tracks[0], nil
 }
 
 func (s *TracksService) GetList(ctx context.Context, filters entities.TrackGetListFilters) (tracks []entities.Track, err error) {
@@ -124,15 +164,17 @@ func (s *TracksService) GetList(ctx context.Context, filters entities.TrackGetLi
 	defer cancelFunc()
 
 	err = s.repo.WithTx(ctx, func(tx pgx.Tx) error {
-		var tracksMap map[int]entities.Track
-		tracksMap, err = s.repo.GetTracksByFilter(ctx, tx, filters)
+		tracks, err = s.repo.GetTracksByFilter(ctx, tx, filters)
 		if err != nil {
 			return fmt.Errorf("failed while getting tracks by filter: %w", err)
 		}
 
-		var IDs []int
-		for ID := range tracksMap {
-			IDs = append(IDs, ID)
+		// Индексы треков по ID, чтобы разложить тексты, не теряя порядок сортировки.
+		indexes := make(map[int]int, len(tracks))
+		IDs := make([]int, 0, len(tracks))
+		for i, track := range tracks {
+			indexes[track.ID] = i
+			IDs = append(IDs, track.ID)
 		}
 
 		var lyrics []dao.Lyric
@@ -141,15 +183,10 @@ func (s *TracksService) GetList(ctx context.Context, filters entities.TrackGetLi
 			return fmt.Errorf("failed while getting lyrics for tracks by IDs: %w", err)
 		}
 
-		var track entities.Track
 		for _, lyric := range lyrics {
-			track = tracksMap[lyric.TrackID]
-			track.Lyric = append(track.Lyric, lyric.Verse)
-			tracksMap[lyric.TrackID] = track
-		}
-
-		for _, track := range tracksMap {
-			tracks = append(tracks, track)
+			if i, ok := indexes[lyric.TrackID]; ok {
+				tracks[i].Lyric = append(tracks[i].Lyric, lyric.Verse)
+			}
 		}
 
 		return nil
@@ -158,13 +195,143 @@ func (s *TracksService) GetList(ctx context.Context, filters entities.TrackGetLi
 		return nil, fmt.Errorf("failed to repo.GetByFilter: %w", err)
 	}
 
+	if err = s.markFavorites(ctx, tracks); err != nil {
+		return nil, err
+	}
+
 	return tracks, nil
 }
 
+// markFavorites заполняет IsFavorite для пользователя из контекста одним запросом на все треки.
+// Для анонимных запросов IsFavorite остаётся nil.
+func (s *TracksService) markFavorites(ctx context.Context, tracks []entities.Track) error {
+	userID := entities.UserIDFromContext(ctx)
+	if userID == nil || len(tracks) == 0 {
+		return nil
+	}
+
+	IDs := make([]int, 0, len(tracks))
+	for _, track := range tracks {
+		IDs = append(IDs, track.ID)
+	}
+
+	favoriteIDs, err := s.repo.GetFavoriteTrackIDs(ctx, *userID, IDs)
+	if err != nil {
+		return fmt.Errorf("failed to repo.GetFavoriteTrackIDs: %w", err)
+	}
+
+	favorites := make(map[int]bool, len(favoriteIDs))
+	for _, id := range favoriteIDs {
+		favorites[id] = true
+	}
+
+	for i := range tracks {
+		isFavorite := favorites[tracks[i].ID]
+		tracks[i].IsFavorite = &isFavorite
+	}
+
+	return nil
+}
+
+// Upsert создаёт или обновляет трек по данным из локального источника, не обращаясь к music-info.
+func (s *TracksService) Upsert(ctx context.Context, track entities.TrackUpsert) (trackID int, created bool, err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, methodTimout)
+	defer cancelFunc()
+
+	track.Title, track.Artist = entities.NormalizeName(track.Title), entities.NormalizeName(track.Artist)
+
+	err = s.repo.WithTx(ctx, func(tx pgx.Tx) error {
+		artistID, exists := s.repo.IsArtistExists(ctx, tx, track.Artist)
+		if !exists {
+			if artistID, err = s.repo.CreateArtist(ctx, tx, dao.Artist{Name: track.Artist}); err != nil {
+				return fmt.Errorf("failed to repo.CreateArtist(%s): %w", track.Artist, err)
+			}
+		}
+
+		var trackExists bool
+		if track.TrackID != 0 {
+			_, err = s.repo.GetArtistIDByTrackID(ctx, tx, track.TrackID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("failed to repo.GetArtistIDByTrackID: %w", err)
+			}
+			trackID, trackExists = track.TrackID, err == nil
+		}
+		if !trackExists {
+			if trackID, trackExists, err = s.repo.GetTrackID(ctx, tx, track.Title, artistID); err != nil {
+				return fmt.Errorf("failed to repo.GetTrackID: %w", err)
+			}
+		}
+
+		trackDAO := dao.Track{
+			TrackID:    trackID,
+			ArtistID:   artistID,
+			Title:      track.Title,
+			ReleasedAt: track.Released,
+		}
+
+		if trackExists {
+			if err = s.repo.UpdateTrack(ctx, tx, trackDAO); err != nil {
+				return fmt.Errorf("failed to repo.UpdateTrack: %w", err)
+			}
+		} else {
+			if trackID, err = s.repo.CreateTrack(ctx, tx, trackDAO); err != nil {
+				return fmt.Errorf("failed to repo.CreateTrack: %w", err)
+			}
+			created = true
+		}
+
+		if track.Album != "" {
+			var albumID int
+			if albumID, err = s.repo.GetOrCreateAlbum(ctx, tx, dao.Album{ArtistID: artistID, Title: track.Album}); err != nil {
+				return fmt.Errorf("failed to repo.GetOrCreateAlbum: %w", err)
+			}
+			if err = s.repo.SetTrackAlbum(ctx, tx, trackID, albumID); err != nil {
+				return fmt.Errorf("failed to repo.SetTrackAlbum: %w", err)
+			}
+		}
+
+		if track.Lyric != "" {
+			lyrics := utils.SplitLyricsToVerses(ctx, track.Lyric)
+			if err = s.repo.DeleteLyricByTrackID(ctx, tx, trackID); err != nil {
+				return fmt.Errorf("failed to repo.DeleteLyricByTrackID: %w", err)
+			}
+			if err = s.repo.CreateLyricFromSlice(ctx, tx, trackID, lyrics); err != nil {
+				return fmt.Errorf("failed to repo.CreateLyricFromSlice: %w", err)
+			}
+			if err = s.repo.SetLyricSignature(ctx, tx, lyricSignature(trackID, lyrics)); err != nil {
+				return fmt.Errorf("failed to repo.SetLyricSignature: %w", err)
+			}
+			if err = s.repo.SetLyricLanguage(ctx, tx, lyricLanguage(trackID, lyrics)); err != nil {
+				return fmt.Errorf("failed to repo.SetLyricLanguage: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to upsert track: %w", err)
+	}
+
+	return trackID, created, nil
+}
+
+// Export передаёт в fn все треки, подходящие под фильтры, не загружая их в память целиком.
+//
+// Таймаут не ограничивается methodTimout, выгрузка живёт столько, сколько контекст вызывающего.
+func (s *TracksService) Export(ctx context.Context, filters entities.TrackGetListFilters, fn func(track entities.Track) error) (err error) {
+	if err = s.repo.ExportTracks(ctx, filters, fn); err != nil {
+		return fmt.Errorf("failed to repo.ExportTracks: %w", err)
+	}
+
+	return nil
+}
+
 func (s *TracksService) Update(ctx context.Context, updateData entities.TrackUpdate) (err error) {
 	ctx, cancelFunc := context.WithTimeout(ctx, methodTimout)
 	defer cancelFunc()
 
+	updateData.Track, updateData.Artist = entities.NormalizeName(updateData.Track), entities.NormalizeName(updateData.Artist)
+
 	err = s.repo.WithTx(ctx, func(tx pgx.Tx) error {
 		var (
 			artist dao.Artist
@@ -203,6 +370,18 @@ func (s *TracksService) Update(ctx context.Context, updateData entities.TrackUpd
 			if err = s.repo.CreateLyricFromSlice(ctx, tx, updateData.TrackID, lyrics); err != nil {
 				return fmt.Errorf("failed to repo.CreateLyricFromSlice: %w", err)
 			}
+			if err = s.repo.SetLyricSignature(ctx, tx, lyricSignature(updateData.TrackID, lyrics)); err != nil {
+				return fmt.Errorf("failed to repo.SetLyricSignature: %w", err)
+			}
+			if err = s.repo.SetLyricLanguage(ctx, tx, lyricLanguage(updateData.TrackID, lyrics)); err != nil {
+				return fmt.Errorf("failed to repo.SetLyricLanguage: %w", err)
+			}
+		}
+
+		if userID := entities.UserIDFromContext(ctx); userID != nil {
+			if err = s.repo.SetTrackUpdatedBy(ctx, tx, updateData.TrackID, *userID); err != nil {
+				return fmt.Errorf("failed to repo.SetTrackUpdatedBy: %w", err)
+			}
 		}
 
 		return nil
@@ -222,6 +401,34 @@ func (s *TracksService) Delete(ctx context.Context, trackID int) (err error) {
 	return nil
 }
 
+const (
+	maxTrackTags      = 20
+	maxTrackTagLength = 64
+)
+
+// SetTags заменяет теги трека. Теги нормализуются (entities.NormalizeTrackTag), повторы отбрасываются.
+func (s *TracksService) SetTags(ctx context.Context, trackID int, tags []string) (err error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = entities.NormalizeTrackTag(tag)
+		if tag == "" || utf8.RuneCountInString(tag) > maxTrackTagLength {
+			return fmt.Errorf("%w: tag must be 1-%d characters long", domain.ErrTrackTagsInvalid, maxTrackTagLength)
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > maxTrackTags {
+		return fmt.Errorf("%w: at most %d tags are allowed", domain.ErrTrackTagsInvalid, maxTrackTags)
+	}
+
+	if err = s.repo.SetTrackTags(ctx, trackID, normalized); err != nil {
+		return fmt.Errorf("failed to repo.SetTrackTags: %w", err)
+	}
+
+	return nil
+}
+
 func (s *TracksService) GetLyric(ctx context.Context, trackID int, offset int) (entities.TrackVerse, error) {
 	verseDao, err := s.repo.GetLyricPaginated(ctx, nil, trackID, offset)
 	if err != nil {
@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	domain "github.com/neyrzx/youmusic/internal/domain/errors"
+	"github.com/neyrzx/youmusic/internal/domain/services"
+	"github.com/neyrzx/youmusic/mocks/internal_/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracksServiceGetByIDFavorite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		user        *entities.User
+		favoriteIDs []int
+		expected    *bool
+	}{
+		{"case: anonymous", nil, nil, nil},
+		{"case: favorite", &entities.User{ID: 7}, []int{42}, func() *bool { v := true; return &v }()},
+		{"case: not favorite", &entities.User{ID: 7}, nil, func() *bool { v := false; return &v }()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = entities.ContextWithUser(ctx, *tt.user)
+			}
+
+			repo := mocks.NewMockTracksRepository(t)
+			repo.EXPECT().GetByID(mock.Anything, 42).Return(entities.Track{ID: 42}, nil)
+			if tt.user != nil {
+				repo.EXPECT().GetFavoriteTrackIDs(mock.Anything, tt.user.ID, []int{42}).Return(tt.favoriteIDs, nil)
+			}
+
+			track, err := services.NewTracksService(repo, mocks.NewMockTracksInfoGateway(t), config.TracksService{}).GetByID(ctx, 42)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, track.IsFavorite)
+		})
+	}
+}
+
+// Проверка существования проходит, но вставка упирается в уникальный name_key: параллельный запрос
+// или расхождение entities.NameKey с SQL name_key. Ошибка должна остаться доменной, а не 500.
+func TestTracksServiceCreateConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		artistErr   error
+		trackErr    error
+		expectedErr error
+	}{
+		{"case: artist created concurrently", domain.ErrArtistAlreadyExists, nil, domain.ErrArtistAlreadyExists},
+		{"case: track created concurrently", nil, domain.ErrTrackAlreadyExists, domain.ErrTrackAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockTracksRepository(t)
+			repo.EXPECT().IsTrackExists(mock.Anything, "Song", "Group").Return(false, nil)
+			repo.EXPECT().WithTx(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, fn func(tx pgx.Tx) error) error {
+				return fn(nil)
+			})
+			repo.EXPECT().IsArtistExists(mock.Anything, mock.Anything, "Group").Return(0, false)
+			repo.EXPECT().CreateArtist(mock.Anything, mock.Anything, mock.Anything).Return(1, tt.artistErr)
+			if tt.artistErr == nil {
+				repo.EXPECT().CreateTrack(mock.Anything, mock.Anything, mock.Anything).Return(0, tt.trackErr)
+			}
+
+			gateway := mocks.NewMockTracksInfoGateway(t)
+			gateway.EXPECT().Info(mock.Anything, mock.Anything).Return(entities.TrackInfoResult{}, nil)
+
+			err := services.NewTracksService(repo, gateway, config.TracksService{}).
+				Create(context.Background(), entities.TrackCreate{Title: "Song", Artist: "Group"})
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
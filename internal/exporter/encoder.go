@@ -0,0 +1,177 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+)
+
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// verseSeparator разделитель куплетов, совпадает с тем, по которому текст разбивается при создании трека.
+const verseSeparator = "\n\n"
+
+// Track запись экспорта.
+//
+// Поля совпадают с колонками cmd/import, поэтому выгрузку в CSV и NDJSON можно загрузить обратно.
+type Track struct {
+	TrackID int    `json:"trackID"`
+	Artist  string `json:"artist"`
+	Title   string `json:"title"`
+	Link    string `json:"link"`
+	Date    string `json:"date"`
+	Lyrics  string `json:"lyrics"`
+}
+
+func NewTrack(track entities.Track) Track {
+	return Track{
+		TrackID: track.ID,
+		Artist:  track.Artist,
+		Title:   track.Track,
+		Link:    track.Link,
+		Date:    track.Released.Format(time.DateOnly),
+		Lyrics:  strings.Join(track.Lyric, verseSeparator),
+	}
+}
+
+// Encoder потоково пишет треки в выбранном формате.
+type Encoder interface {
+	Encode(track entities.Track) error
+	// Close дописывает завершающую часть формата (например, закрывающую скобку JSON массива).
+	Close() error
+}
+
+func NewEncoder(w io.Writer, format Format) (Encoder, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonEncoder{w: w}, nil
+	case FormatNDJSON:
+		return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// ContentType возвращает MIME тип формата.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(track entities.Track) error {
+	prefix := ","
+	if e.count == 0 {
+		prefix = "["
+	}
+	e.count++
+
+	data, err := json.Marshal(NewTrack(track))
+	if err != nil {
+		return fmt.Errorf("failed to json.Marshal: %w", err)
+	}
+
+	if _, err = io.WriteString(e.w, prefix); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+
+	return err
+}
+
+func (e *jsonEncoder) Close() (err error) {
+	if e.count == 0 {
+		_, err = io.WriteString(e.w, "[]\n")
+		return err
+	}
+
+	_, err = io.WriteString(e.w, "]\n")
+	return err
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(track entities.Track) error {
+	return e.enc.Encode(NewTrack(track))
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
+
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+var csvHeader = []string{"trackID", "artist", "title", "link", "date", "lyrics"}
+
+func (e *csvEncoder) Encode(track entities.Track) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	t := NewTrack(track)
+	if err := e.w.Write([]string{strconv.Itoa(t.TrackID), t.Artist, t.Title, t.Link, t.Date, t.Lyrics}); err != nil {
+		return fmt.Errorf("failed to csv.Write: %w", err)
+	}
+
+	return nil
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+
+	if err := e.w.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to csv.Write: %w", err)
+	}
+
+	return nil
+}
+
+// Flush сбрасывает буфер CSV, чтобы данные уходили клиенту по мере выгрузки.
+func (e *csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,85 @@
+package exporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	tracks := []entities.Track{
+		{
+			ID:       1,
+			Artist:   "Muse",
+			Track:    "Supermassive Black Hole",
+			Lyric:    []string{"Verse1", "Verse2"},
+			Link:     "https://example.com",
+			Released: time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:       2,
+			Artist:   "Кино",
+			Track:    "Кукушка",
+			Released: time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   exporter.Format
+		tracks   []entities.Track
+		expected string
+	}{
+		{
+			"case: json",
+			exporter.FormatJSON,
+			tracks,
+			`[{"trackID":1,"artist":"Muse","title":"Supermassive Black Hole","link":"https://example.com","date":"2006-07-16","lyrics":"Verse1\n\nVerse2"},` +
+				`{"trackID":2,"artist":"Кино","title":"Кукушка","link":"","date":"1990-01-01","lyrics":""}]` + "\n",
+		},
+		{
+			"case: json empty",
+			exporter.FormatJSON,
+			nil,
+			"[]\n",
+		},
+		{
+			"case: ndjson",
+			exporter.FormatNDJSON,
+			tracks[1:],
+			`{"trackID":2,"artist":"Кино","title":"Кукушка","link":"","date":"1990-01-01","lyrics":""}` + "\n",
+		},
+		{
+			"case: csv",
+			exporter.FormatCSV,
+			tracks,
+			"trackID,artist,title,link,date,lyrics\n" +
+				"1,Muse,Supermassive Black Hole,https://example.com,2006-07-16,\"Verse1\n\nVerse2\"\n" +
+				"2,Кино,Кукушка,,1990-01-01,\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			encoder, err := exporter.NewEncoder(&buf, test.format)
+			require.NoError(t, err)
+
+			for _, track := range test.tracks {
+				require.NoError(t, encoder.Encode(track))
+			}
+			require.NoError(t, encoder.Close())
+
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
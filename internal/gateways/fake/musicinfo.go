@@ -0,0 +1,344 @@
+package fake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	packageKey  = "fake"
+	packageName = "musicInfo"
+)
+
+// Fixture описывает ответ /info для пары группа + песня, формат совпадает с SongDetail из api/music-info.yaml.
+type Fixture struct {
+	Group       string `json:"group" yaml:"group"`
+	Song        string `json:"song" yaml:"song"`
+	ReleaseDate string `json:"releaseDate" yaml:"releaseDate"`
+	Text        string `json:"text" yaml:"text"`
+	Link        string `json:"link" yaml:"link"`
+}
+
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+type HTTPError struct {
+	Message string `json:"message"`
+}
+
+type fixtureKey struct {
+	group string
+	song  string
+}
+
+func newFixtureKey(group, song string) fixtureKey {
+	return fixtureKey{
+		group: strings.ToLower(strings.TrimSpace(group)),
+		song:  strings.ToLower(strings.TrimSpace(song)),
+	}
+}
+
+// MusicInfoServer фейковая реализация api/music-info.yaml.
+//
+// Отдаёт фикстуры, загруженные из каталога (JSON/YAML), умеет добавлять задержку
+// и инъектировать ошибки 400/500/таймауты. Фикстуры можно добавлять на лету через /__admin.
+type MusicInfoServer struct {
+	cfg    config.MusicInfoFake
+	logger *zerolog.Logger
+
+	mu       sync.RWMutex
+	fixtures map[fixtureKey]Fixture
+}
+
+func NewMusicInfoServer(cfg config.MusicInfoFake) *MusicInfoServer {
+	logger := logger.DefaultLogger().With().Str(packageKey, packageName).Logger()
+
+	return &MusicInfoServer{
+		cfg:      cfg,
+		logger:   &logger,
+		fixtures: make(map[fixtureKey]Fixture),
+	}
+}
+
+// LoadFixtures загружает все *.json, *.yaml и *.yml файлы из каталога cfg.FixturesDir.
+//
+// Файл может содержать как одну фикстуру, так и список фикстур.
+func (s *MusicInfoServer) LoadFixtures() (count int, err error) {
+	if s.cfg.FixturesDir == "" {
+		return 0, nil
+	}
+
+	entries, err := os.ReadDir(s.cfg.FixturesDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to os.ReadDir(%s): %w", s.cfg.FixturesDir, err)
+	}
+
+	var fixtures []Fixture
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(s.cfg.FixturesDir, entry.Name())
+
+		var loaded []Fixture
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json":
+			loaded, err = decodeFixtures(path, json.Unmarshal)
+		case ".yaml", ".yml":
+			loaded, err = decodeFixtures(path, yaml.Unmarshal)
+		default:
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		fixtures = append(fixtures, loaded...)
+	}
+
+	if err = s.AddFixtures(fixtures...); err != nil {
+		return 0, err
+	}
+
+	return len(fixtures), nil
+}
+
+func decodeFixtures(path string, unmarshal func([]byte, any) error) ([]Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to os.ReadFile(%s): %w", path, err)
+	}
+
+	var fixtures []Fixture
+	if err = unmarshal(data, &fixtures); err == nil {
+		return fixtures, nil
+	}
+
+	var fixture Fixture
+	if err = unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to decode fixtures from %s: %w", path, err)
+	}
+
+	return []Fixture{fixture}, nil
+}
+
+// AddFixtures добавляет (или перезаписывает) фикстуры.
+func (s *MusicInfoServer) AddFixtures(fixtures ...Fixture) error {
+	for i, fixture := range fixtures {
+		if err := fixture.validate(); err != nil {
+			return fmt.Errorf("fixture #%d: %w", i, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, fixture := range fixtures {
+		s.fixtures[newFixtureKey(fixture.Group, fixture.Song)] = fixture
+	}
+
+	return nil
+}
+
+// Fixtures возвращает все фикстуры, отсортированные по группе и песне.
+func (s *MusicInfoServer) Fixtures() []Fixture {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fixtures := make([]Fixture, 0, len(s.fixtures))
+	for _, fixture := range s.fixtures {
+		fixtures = append(fixtures, fixture)
+	}
+
+	sort.Slice(fixtures, func(i, j int) bool {
+		if fixtures[i].Group != fixtures[j].Group {
+			return fixtures[i].Group < fixtures[j].Group
+		}
+		return fixtures[i].Song < fixtures[j].Song
+	})
+
+	return fixtures
+}
+
+// Reset удаляет все фикстуры, в том числе добавленные через /__admin, и заново загружает каталог.
+func (s *MusicInfoServer) Reset() (count int, err error) {
+	s.mu.Lock()
+	s.fixtures = make(map[fixtureKey]Fixture)
+	s.mu.Unlock()
+
+	return s.LoadFixtures()
+}
+
+func (s *MusicInfoServer) fixture(group, song string) (Fixture, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fixture, ok := s.fixtures[newFixtureKey(group, song)]
+	return fixture, ok
+}
+
+func (f Fixture) validate() error {
+	if strings.TrimSpace(f.Group) == "" || strings.TrimSpace(f.Song) == "" {
+		return errors.New("group and song are required")
+	}
+
+	return nil
+}
+
+// Handler возвращает http.Handler с маршрутами /info и /__admin.
+func (s *MusicInfoServer) Handler() http.Handler {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	s.Register(e)
+
+	return e
+}
+
+func (s *MusicInfoServer) Register(e *echo.Echo) {
+	e.GET("/info", s.Info)
+
+	admin := e.Group("/__admin")
+	admin.GET("/fixtures", s.AdminList)
+	admin.POST("/fixtures", s.AdminCreate)
+	admin.DELETE("/fixtures", s.AdminReset)
+}
+
+func (s *MusicInfoServer) Info(c echo.Context) (err error) {
+	group, song := c.QueryParam("group"), c.QueryParam("song")
+
+	if err = s.delay(c); err != nil {
+		return nil
+	}
+
+	switch s.fault() {
+	case http.StatusBadRequest:
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "injected bad request"})
+	case http.StatusInternalServerError:
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "injected internal server error"})
+	case http.StatusGatewayTimeout:
+		s.hang(c)
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: "injected timeout"})
+	}
+
+	if group == "" || song == "" {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "group and song query params are required"})
+	}
+
+	fixture, ok := s.fixture(group, song)
+	if !ok {
+		s.logger.Info().Str("group", group).Str("song", song).Msg("fixture not found")
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "song not found"})
+	}
+
+	return c.JSON(http.StatusOK, SongDetail{
+		ReleaseDate: fixture.ReleaseDate,
+		Text:        fixture.Text,
+		Link:        fixture.Link,
+	})
+}
+
+func (s *MusicInfoServer) AdminList(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.Fixtures())
+}
+
+// AdminCreate принимает как одну фикстуру, так и список.
+func (s *MusicInfoServer) AdminCreate(c echo.Context) (err error) {
+	var raw json.RawMessage
+	if err = json.NewDecoder(c.Request().Body).Decode(&raw); err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+	}
+
+	var fixtures []Fixture
+	if err = json.Unmarshal(raw, &fixtures); err != nil {
+		var fixture Fixture
+		if err = json.Unmarshal(raw, &fixture); err != nil {
+			return c.JSON(http.StatusBadRequest, HTTPError{Message: "request body malformed"})
+		}
+		fixtures = []Fixture{fixture}
+	}
+
+	if err = s.AddFixtures(fixtures...); err != nil {
+		return c.JSON(http.StatusBadRequest, HTTPError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, fixtures)
+}
+
+func (s *MusicInfoServer) AdminReset(c echo.Context) error {
+	if _, err := s.Reset(); err != nil {
+		s.logger.Err(err).Msg("failed to Reset")
+		return c.JSON(http.StatusInternalServerError, HTTPError{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// delay выдерживает сконфигурированную задержку, возвращает ошибку если клиент отвалился раньше.
+func (s *MusicInfoServer) delay(c echo.Context) error {
+	latency := s.cfg.Latency
+	if s.cfg.LatencyJitter > 0 {
+		latency += rand.N(s.cfg.LatencyJitter)
+	}
+
+	if latency <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.Request().Context().Done():
+		return c.Request().Context().Err()
+	}
+}
+
+// fault разыгрывает инъекцию ошибки, возвращает код ошибки или 0.
+func (s *MusicInfoServer) fault() int {
+	p := rand.Float64()
+
+	if p -= s.cfg.BadRequestRate; p < 0 {
+		return http.StatusBadRequest
+	}
+	if p -= s.cfg.InternalErrorRate; p < 0 {
+		return http.StatusInternalServerError
+	}
+	if p -= s.cfg.TimeoutRate; p < 0 {
+		return http.StatusGatewayTimeout
+	}
+
+	return 0
+}
+
+func (s *MusicInfoServer) hang(c echo.Context) {
+	timer := time.NewTimer(s.cfg.Timeout)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-c.Request().Context().Done():
+	}
+}
@@ -0,0 +1,98 @@
+package fake_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/gateways"
+	"github.com/neyrzx/youmusic/internal/gateways/fake"
+	"github.com/neyrzx/youmusic/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMusicInfoServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.MusicInfoFake
+		admin   string
+		track   entities.TrackInfo
+		want    entities.TrackInfoResult
+		wantErr bool
+	}{
+		{
+			name:  "fixture from directory",
+			cfg:   config.MusicInfoFake{FixturesDir: "testdata"},
+			track: entities.TrackInfo{Group: "muse", Song: "supermassive black hole"},
+			want: entities.TrackInfoResult{
+				ReleaseDate: time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC),
+				Text:        "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?\n\nOoh\nYou set my soul alight",
+				Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+			},
+		},
+		{
+			name:  "fixture added via admin",
+			cfg:   config.MusicInfoFake{FixturesDir: "testdata"},
+			admin: `{"group":"Кино","song":"Кукушка","releaseDate":"01.01.1990","text":"Песен ещё ненаписанных","link":"https://example.com"}`,
+			track: entities.TrackInfo{Group: "Кино", Song: "Кукушка"},
+			want: entities.TrackInfoResult{
+				ReleaseDate: time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Text:        "Песен ещё ненаписанных",
+				Link:        "https://example.com",
+			},
+		},
+		{
+			name:    "unknown song",
+			cfg:     config.MusicInfoFake{FixturesDir: "testdata"},
+			track:   entities.TrackInfo{Group: "Muse", Song: "Uprising"},
+			wantErr: true,
+		},
+		{
+			name:    "injected internal error",
+			cfg:     config.MusicInfoFake{FixturesDir: "testdata", InternalErrorRate: 1},
+			track:   entities.TrackInfo{Group: "Muse", Song: "Supermassive Black Hole"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fake.NewMusicInfoServer(tt.cfg)
+			_, err := server.LoadFixtures()
+			assert.NoError(t, err)
+
+			ts := httptest.NewServer(server.Handler())
+			defer ts.Close()
+
+			if tt.admin != "" {
+				res, err := http.Post(ts.URL+"/__admin/fixtures", "application/json", bytes.NewBufferString(tt.admin))
+				assert.NoError(t, err)
+				res.Body.Close()
+				assert.Equal(t, http.StatusCreated, res.StatusCode)
+			}
+
+			cfg := config.GatewayHTTPClient{
+				RetryStratagyDelay:       time.Millisecond,
+				RetryStrategyMaxDelay:    time.Millisecond,
+				RetryStrategyMaxDuration: 10 * time.Millisecond,
+				RetryStrategyFactor:      1,
+				BaseURL:                  ts.URL,
+			}
+			gw := gateways.NewMusicInfoGateway(httpclient.NewHTTPClient(cfg), cfg)
+
+			got, err := gw.Info(context.Background(), tt.track)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -35,7 +35,12 @@ type InfoResponse struct {
 }
 
 func (gw *MusicInfoGateway) Info(ctx context.Context, track entities.TrackInfo) (entities.TrackInfoResult, error) {
-	_, cancelFunc := context.WithTimeout(ctx, timeoutInfo)
+	timeout := gw.cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = timeoutInfo
+	}
+
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
 	defer cancelFunc()
 
 	url, err := url.Parse(fmt.Sprintf("%s/info", gw.cfg.BaseURL))
@@ -0,0 +1,92 @@
+package gateways_test
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/gateways"
+	"github.com/neyrzx/youmusic/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cassetteInfo = "testdata/cassettes/musicinfo_info.json"
+
+// Перезапись кассеты с реального сервиса:
+//
+//	GATEWAY_MUSIC_INFO_BASE_URL=http://localhost:9191 go test ./internal/gateways -run TestMusicInfoGatewayInfo -record
+var record = flag.Bool("record", false, "record cassettes against GATEWAY_MUSIC_INFO_BASE_URL")
+
+func TestMusicInfoGatewayInfo(t *testing.T) {
+	mode, baseURL := httpclient.ModeReplay, "http://musicinfo.test"
+	if *record {
+		mode, baseURL = httpclient.ModeRecord, os.Getenv("GATEWAY_MUSIC_INFO_BASE_URL")
+	}
+
+	recorder, err := httpclient.NewRecorder(cassetteInfo, mode, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, recorder.Save()) })
+
+	cfg := config.GatewayHTTPClient{
+		RetryStratagyDelay:       time.Millisecond,
+		RetryStrategyMaxDelay:    time.Millisecond,
+		RetryStrategyMaxDuration: 50 * time.Millisecond,
+		RetryStrategyFactor:      1,
+		BaseURL:                  baseURL,
+	}
+	gw := gateways.NewMusicInfoGateway(httpclient.NewHTTPClient(cfg, httpclient.WithTransport(recorder)), cfg)
+
+	tests := []struct {
+		name     string
+		track    entities.TrackInfo
+		expected entities.TrackInfoResult
+		wantErr  bool
+	}{
+		{
+			name:  "case: success",
+			track: entities.TrackInfo{Group: "Muse", Song: "Supermassive Black Hole"},
+			expected: entities.TrackInfoResult{
+				ReleaseDate: time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC),
+				Text:        "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?\n\nOoh\nYou set my soul alight",
+				Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+			},
+		},
+		{
+			name:  "case: success after retry on 500",
+			track: entities.TrackInfo{Group: "Muse", Song: "Hysteria"},
+			expected: entities.TrackInfoResult{
+				ReleaseDate: time.Date(2003, time.December, 1, 0, 0, 0, 0, time.UTC),
+				Text:        "It's bugging me\nGrating me",
+				Link:        "https://www.youtube.com/watch?v=3dm_5qWWDV8",
+			},
+		},
+		{
+			name:    "case: bad request",
+			track:   entities.TrackInfo{Group: "Muse", Song: "Uprising"},
+			wantErr: true,
+		},
+		{
+			name:    "case: malformed release date",
+			track:   entities.TrackInfo{Group: "Muse", Song: "Starlight"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := gw.Info(context.Background(), test.track)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
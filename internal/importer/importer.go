@@ -0,0 +1,319 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/neyrzx/youmusic/pkg/utils"
+	"github.com/rs/zerolog"
+)
+
+const (
+	packageKey  = "importer"
+	packageName = "tracks"
+)
+
+// errDryRun откатывает транзакцию пакета в режиме dry-run.
+var errDryRun = errors.New("dry run")
+
+type Repository interface {
+	WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error
+	IsArtistExists(ctx context.Context, tx pgx.Tx, name string) (id int, exists bool)
+	CreateArtist(ctx context.Context, tx pgx.Tx, artist dao.Artist) (id int, err error)
+	GetExistingTracks(ctx context.Context, tracks []entities.TrackCreate) (existing []entities.TrackCreate, err error)
+	CreateTracks(ctx context.Context, tx pgx.Tx, tracks []dao.Track) (ids []int, err error)
+	CreateLyric(ctx context.Context, tx pgx.Tx, lyrics []dao.Lyric) (err error)
+}
+
+type Options struct {
+	// BatchSize сколько записей вставляется одной транзакцией.
+	BatchSize int
+	// DryRun выполняет импорт в транзакциях, которые откатываются, чекпоинт не сохраняется.
+	DryRun bool
+	// CheckpointPath файл с количеством уже обработанных записей, позволяет продолжить прерванный импорт.
+	CheckpointPath string
+	// RejectPath файл (NDJSON), куда дописываются отклонённые записи с причиной.
+	RejectPath string
+}
+
+// Stats итоги импорта.
+type Stats struct {
+	Skipped  int `json:"skipped"`
+	Created  int `json:"created"`
+	Exists   int `json:"exists"`
+	Rejected int `json:"rejected"`
+}
+
+// Reject отклонённая запись в файле отказов.
+type Reject struct {
+	Line   int     `json:"line"`
+	Reason string  `json:"reason"`
+	Record *Record `json:"record,omitempty"`
+	Raw    string  `json:"raw,omitempty"`
+}
+
+type Importer struct {
+	repo   Repository
+	opts   Options
+	logger *zerolog.Logger
+}
+
+func NewImporter(repo Repository, opts Options) *Importer {
+	logger := logger.DefaultLogger().With().Str(packageKey, packageName).Logger()
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+
+	return &Importer{repo: repo, opts: opts, logger: &logger}
+}
+
+// Run читает записи из reader и загружает их пакетами.
+func (im *Importer) Run(ctx context.Context, reader Reader) (stats Stats, err error) {
+	offset, err := im.readCheckpoint()
+	if err != nil {
+		return stats, err
+	}
+
+	var rejects io.Writer = io.Discard
+	if im.opts.RejectPath != "" {
+		file, err := os.OpenFile(im.opts.RejectPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return stats, fmt.Errorf("failed to os.OpenFile(%s): %w", im.opts.RejectPath, err)
+		}
+		defer file.Close()
+		rejects = file
+	}
+	rejectEncoder := json.NewEncoder(rejects)
+
+	var (
+		consumed = 0
+		batch    = make([]Record, 0, im.opts.BatchSize)
+		// pending отказы, ещё не покрытые чекпоинтом. Они пишутся в файл только после сохранения
+		// чекпоинта, иначе продолженный импорт записал бы их повторно.
+		pending []Reject
+	)
+
+	reject := func(r Reject) {
+		stats.Rejected++
+		im.logger.Warn().Int("line", r.Line).Str("reason", r.Reason).Msg("record rejected")
+		pending = append(pending, r)
+	}
+
+	// flush загружает накопленный пакет, сохраняет чекпоинт и дописывает отказы.
+	flush := func() error {
+		if len(batch) > 0 {
+			batchStats, err := im.load(ctx, batch)
+			if err != nil {
+				return fmt.Errorf("failed to load batch ending at line %d: %w", batch[len(batch)-1].Line, err)
+			}
+			stats.Created += batchStats.Created
+			stats.Exists += batchStats.Exists
+			batch = batch[:0]
+		}
+
+		if err := im.writeCheckpoint(consumed); err != nil {
+			return err
+		}
+
+		for _, r := range pending {
+			if err := rejectEncoder.Encode(r); err != nil {
+				return fmt.Errorf("failed to write reject: %w", err)
+			}
+		}
+		pending = pending[:0]
+
+		return nil
+	}
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return stats, err
+		}
+
+		consumed++
+		if consumed <= offset {
+			stats.Skipped++
+			continue
+		}
+
+		if parseErr != nil {
+			reject(Reject{Line: parseErr.Line, Reason: parseErr.Err.Error(), Raw: parseErr.Raw})
+		} else if err = record.Validate(); err != nil {
+			reject(Reject{Line: record.Line, Reason: err.Error(), Record: &record})
+		} else {
+			batch = append(batch, record)
+		}
+
+		if len(batch)+len(pending) >= im.opts.BatchSize {
+			if err = flush(); err != nil {
+				return stats, err
+			}
+		}
+	}
+
+	return stats, flush()
+}
+
+// load загружает один пакет записей в отдельной транзакции.
+func (im *Importer) load(ctx context.Context, records []Record) (stats Stats, err error) {
+	candidates := make([]entities.TrackCreate, 0, len(records))
+	for i := range records {
+		records[i].Title, records[i].Artist = entities.NormalizeName(records[i].Title), entities.NormalizeName(records[i].Artist)
+		candidates = append(candidates, entities.TrackCreate{Title: records[i].Title, Artist: records[i].Artist})
+	}
+
+	existing, err := im.repo.GetExistingTracks(ctx, candidates)
+	if err != nil {
+		return stats, fmt.Errorf("failed to repo.GetExistingTracks: %w", err)
+	}
+
+	skip := make(map[entities.TrackCreate]struct{}, len(existing))
+	for _, track := range existing {
+		skip[nameKeys(track)] = struct{}{}
+	}
+
+	var pending []Record
+	for i, record := range records {
+		if _, ok := skip[nameKeys(candidates[i])]; ok {
+			stats.Exists++
+			continue
+		}
+		// Повторы внутри файла тоже считаются существующими.
+		skip[nameKeys(candidates[i])] = struct{}{}
+		pending = append(pending, record)
+	}
+
+	if len(pending) == 0 {
+		return stats, nil
+	}
+
+	err = im.repo.WithTx(ctx, func(tx pgx.Tx) (err error) {
+		artists := make(map[string]int)
+
+		tracks := make([]dao.Track, 0, len(pending))
+		for _, record := range pending {
+			artistID, ok := artists[entities.NameKey(record.Artist)]
+			if !ok {
+				if artistID, err = im.resolveArtist(ctx, tx, record.Artist); err != nil {
+					return err
+				}
+				artists[entities.NameKey(record.Artist)] = artistID
+			}
+
+			// Дата уже проверена в Validate.
+			released, _ := record.Released()
+
+			tracks = append(tracks, dao.Track{
+				Title:      record.Title,
+				ArtistID:   artistID,
+				Link:       record.Link,
+				ReleasedAt: released,
+			})
+		}
+
+		ids, err := im.repo.CreateTracks(ctx, tx, tracks)
+		if err != nil {
+			return fmt.Errorf("failed to repo.CreateTracks: %w", err)
+		}
+
+		var lyrics []dao.Lyric
+		for i, record := range pending {
+			for _, verse := range utils.SplitLyricsToVerses(ctx, record.Lyrics) {
+				lyrics = append(lyrics, dao.Lyric{TrackID: ids[i], Verse: verse})
+			}
+		}
+
+		if err = im.repo.CreateLyric(ctx, tx, lyrics); err != nil {
+			return fmt.Errorf("failed to repo.CreateLyric: %w", err)
+		}
+
+		if im.opts.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return stats, err
+	}
+
+	stats.Created += len(pending)
+
+	return stats, nil
+}
+
+// nameKeys переводит пару название + исполнитель в ключи name_key.
+func nameKeys(track entities.TrackCreate) entities.TrackCreate {
+	return entities.TrackCreate{Title: entities.NameKey(track.Title), Artist: entities.NameKey(track.Artist)}
+}
+
+func (im *Importer) resolveArtist(ctx context.Context, tx pgx.Tx, name string) (id int, err error) {
+	if id, exists := im.repo.IsArtistExists(ctx, tx, name); exists {
+		return id, nil
+	}
+
+	if id, err = im.repo.CreateArtist(ctx, tx, dao.Artist{Name: name}); err != nil {
+		return 0, fmt.Errorf("failed to repo.CreateArtist(%s): %w", name, err)
+	}
+
+	return id, nil
+}
+
+func (im *Importer) readCheckpoint() (offset int, err error) {
+	if im.opts.CheckpointPath == "" {
+		return 0, nil
+	}
+
+	data, err := os.ReadFile(im.opts.CheckpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to os.ReadFile(%s): %w", im.opts.CheckpointPath, err)
+	}
+
+	if offset, err = strconv.Atoi(strings.TrimSpace(string(data))); err != nil {
+		return 0, fmt.Errorf("failed to parse checkpoint %s: %w", im.opts.CheckpointPath, err)
+	}
+
+	return offset, nil
+}
+
+// writeCheckpoint атомарно сохраняет количество обработанных записей.
+func (im *Importer) writeCheckpoint(consumed int) error {
+	if im.opts.CheckpointPath == "" || im.opts.DryRun {
+		return nil
+	}
+
+	tmp := im.opts.CheckpointPath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(consumed)+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to os.WriteFile(%s): %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, im.opts.CheckpointPath); err != nil {
+		return fmt.Errorf("failed to os.Rename(%s): %w", tmp, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package importer_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCrash = errors.New("crash")
+
+// fakeRepository падает на CreateTracks с номером failOn (с 1), 0 - без сбоев.
+type fakeRepository struct {
+	failOn int
+	calls  int
+	titles []string
+}
+
+func (r *fakeRepository) WithTx(_ context.Context, fn func(tx pgx.Tx) error) error {
+	return fn(nil)
+}
+
+func (r *fakeRepository) IsArtistExists(context.Context, pgx.Tx, string) (int, bool) {
+	return 1, true
+}
+
+func (r *fakeRepository) CreateArtist(context.Context, pgx.Tx, dao.Artist) (int, error) {
+	return 1, nil
+}
+
+func (r *fakeRepository) GetExistingTracks(context.Context, []entities.TrackCreate) ([]entities.TrackCreate, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) CreateTracks(_ context.Context, _ pgx.Tx, tracks []dao.Track) ([]int, error) {
+	r.calls++
+	if r.calls == r.failOn {
+		return nil, errCrash
+	}
+
+	ids := make([]int, len(tracks))
+	for i, track := range tracks {
+		r.titles = append(r.titles, track.Title)
+		ids[i] = len(r.titles)
+	}
+
+	return ids, nil
+}
+
+func (r *fakeRepository) CreateLyric(context.Context, pgx.Tx, []dao.Lyric) error {
+	return nil
+}
+
+func TestImporterResume(t *testing.T) {
+	t.Parallel()
+
+	const input = `{"artist":"Muse","title":"Uprising"}
+{"artist":"Muse","title":"Resistance"}
+{"artist":"Muse"}
+{"artist":"Muse","title":"Starlight"}
+{"title":"Hysteria"}
+{"artist":"Muse","title":"Madness"}
+`
+
+	dir := t.TempDir()
+	opts := importer.Options{
+		BatchSize:      2,
+		CheckpointPath: filepath.Join(dir, "checkpoint"),
+		RejectPath:     filepath.Join(dir, "rejects.ndjson"),
+	}
+
+	run := func(repo *fakeRepository) (importer.Stats, error) {
+		reader, err := importer.NewReader(strings.NewReader(input), importer.FormatNDJSON)
+		require.NoError(t, err)
+
+		return importer.NewImporter(repo, opts).Run(context.Background(), reader)
+	}
+
+	crashed := &fakeRepository{failOn: 2}
+	_, err := run(crashed)
+	require.ErrorIs(t, err, errCrash)
+
+	resumed := &fakeRepository{}
+	stats, err := run(resumed)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Uprising", "Resistance"}, crashed.titles)
+	assert.Equal(t, []string{"Starlight", "Madness"}, resumed.titles)
+	assert.Equal(t, importer.Stats{Skipped: 2, Created: 2, Rejected: 2}, stats)
+
+	file, err := os.Open(opts.RejectPath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var lines []int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var reject importer.Reject
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &reject))
+		lines = append(lines, reject.Line)
+	}
+	require.NoError(t, scanner.Err())
+
+	assert.Equal(t, []int{3, 5}, lines)
+}
@@ -0,0 +1,255 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/neyrzx/youmusic/pkg/utils"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// ndjsonMaxLineSize максимальная длина строки NDJSON (тексты песен бывают длинными).
+const ndjsonMaxLineSize = 4 * 1024 * 1024
+
+var (
+	ErrUnknownFormat  = errors.New("unknown import format")
+	ErrMissingColumns = errors.New("csv header must contain artist and title columns")
+)
+
+// Record строка импорта.
+type Record struct {
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Link   string `json:"link,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Lyrics string `json:"lyrics,omitempty"`
+
+	// Line номер строки во входном файле, с которой начинается запись.
+	Line int `json:"-"`
+}
+
+// Released разбирает дату выхода в формате utils.ReleaseDateLayout или RFC 3339 (YYYY-MM-DD).
+// Пустая дата допустима и возвращает нулевое время.
+func (r Record) Released() (time.Time, error) {
+	value := strings.TrimSpace(r.Date)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{utils.ReleaseDateLayout, time.DateOnly} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, expected %s or %s", value, utils.ReleaseDateLayout, time.DateOnly)
+}
+
+// Validate проверяет обязательные поля и дату.
+func (r Record) Validate() error {
+	if strings.TrimSpace(r.Artist) == "" {
+		return errors.New("artist is required")
+	}
+	if strings.TrimSpace(r.Title) == "" {
+		return errors.New("title is required")
+	}
+	if _, err := r.Released(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reader последовательно читает записи, в конце возвращает io.EOF.
+//
+// Ошибка разбора отдельной строки возвращается вместе с записью (в ней заполнен Line),
+// чтение после неё можно продолжать.
+type Reader interface {
+	Read() (Record, error)
+}
+
+// ParseError ошибка разбора одной строки, не прерывает импорт.
+type ParseError struct {
+	Line int
+	Raw  string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func NewReader(r io.Reader, format Format) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), ndjsonMaxLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// FormatFromPath определяет формат по расширению файла.
+func FormatFromPath(path string) (Format, error) {
+	switch {
+	case strings.HasSuffix(path, ".csv"):
+		return FormatCSV, nil
+	case strings.HasSuffix(path, ".ndjson"), strings.HasSuffix(path, ".jsonl"):
+		return FormatNDJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// csvColumnAliases допустимые названия колонок, в том числе в терминах music-info (group, song, text).
+var csvColumnAliases = map[string]string{
+	"artist":      "artist",
+	"group":       "artist",
+	"title":       "title",
+	"song":        "title",
+	"track":       "title",
+	"link":        "link",
+	"date":        "date",
+	"releasedate": "date",
+	"released":    "date",
+	"lyrics":      "lyrics",
+	"lyric":       "lyrics",
+	"text":        "lyrics",
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = false
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if column, ok := csvColumnAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[column] = i
+		}
+	}
+
+	_, hasArtist := columns["artist"]
+	_, hasTitle := columns["title"]
+	if !hasArtist || !hasTitle {
+		return nil, ErrMissingColumns
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvReader) Read() (Record, error) {
+	fields, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return Record{}, io.EOF
+	}
+	if err != nil {
+		var csvErr *csv.ParseError
+		if !errors.As(err, &csvErr) {
+			return Record{}, fmt.Errorf("failed to csv.Read: %w", err)
+		}
+		return Record{Line: csvErr.StartLine}, &ParseError{Line: csvErr.StartLine, Raw: strings.Join(fields, ","), Err: csvErr.Err}
+	}
+
+	line, _ := r.reader.FieldPos(0)
+
+	get := func(column string) string {
+		i, ok := r.columns[column]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return fields[i]
+	}
+
+	return Record{
+		Artist: strings.TrimSpace(get("artist")),
+		Title:  strings.TrimSpace(get("title")),
+		Link:   strings.TrimSpace(get("link")),
+		Date:   strings.TrimSpace(get("date")),
+		Lyrics: get("lyrics"),
+		Line:   line,
+	}, nil
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonReader) Read() (Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		raw := strings.TrimSpace(r.scanner.Text())
+		if raw == "" {
+			continue
+		}
+
+		var record struct {
+			Record
+			// Алиасы в терминах music-info.
+			Group       string `json:"group"`
+			Song        string `json:"song"`
+			ReleaseDate string `json:"releaseDate"`
+			Text        string `json:"text"`
+		}
+		if err := json.Unmarshal([]byte(raw), &record); err != nil {
+			return Record{Line: r.line}, &ParseError{Line: r.line, Raw: raw, Err: err}
+		}
+
+		result := record.Record
+		result.Artist = strings.TrimSpace(firstNonEmpty(result.Artist, record.Group))
+		result.Title = strings.TrimSpace(firstNonEmpty(result.Title, record.Song))
+		result.Date = strings.TrimSpace(firstNonEmpty(result.Date, record.ReleaseDate))
+		result.Lyrics = firstNonEmpty(result.Lyrics, record.Text)
+		result.Link = strings.TrimSpace(result.Link)
+		result.Line = r.line
+
+		return result, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return Record{}, fmt.Errorf("failed to scanner.Scan: %w", err)
+	}
+
+	return Record{}, io.EOF
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
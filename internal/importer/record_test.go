@@ -0,0 +1,83 @@
+package importer_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/neyrzx/youmusic/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		format          importer.Format
+		input           string
+		expectedRecords []importer.Record
+		expectedErrors  []int
+	}{
+		{
+			"case: csv with multiline lyrics",
+			importer.FormatCSV,
+			"group,song,date,link,text\n" +
+				"Muse,Supermassive Black Hole,16.07.2006,https://example.com,\"Verse1\n\nVerse2\"\n" +
+				"Кино,Кукушка,,,\n",
+			[]importer.Record{
+				{Artist: "Muse", Title: "Supermassive Black Hole", Date: "16.07.2006", Link: "https://example.com", Lyrics: "Verse1\n\nVerse2", Line: 2},
+				{Artist: "Кино", Title: "Кукушка", Line: 5},
+			},
+			nil,
+		},
+		{
+			"case: ndjson with aliases and broken line",
+			importer.FormatNDJSON,
+			`{"artist":"Muse","title":"Uprising","date":"2009-09-07"}` + "\n" +
+				"\n" +
+				`{"group":"Кино","song":"Кукушка","text":"Verse"` + "\n" +
+				`{"group":"Кино","song":"Группа крови","releaseDate":"01.01.1988","text":"Verse"}` + "\n",
+			[]importer.Record{
+				{Artist: "Muse", Title: "Uprising", Date: "2009-09-07", Line: 1},
+				{Artist: "Кино", Title: "Группа крови", Date: "01.01.1988", Lyrics: "Verse", Line: 4},
+			},
+			[]int{3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader, err := importer.NewReader(strings.NewReader(test.input), test.format)
+			require.NoError(t, err)
+
+			var (
+				actualRecords []importer.Record
+				actualErrors  []int
+			)
+			for {
+				record, err := reader.Read()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				var parseErr *importer.ParseError
+				if errors.As(err, &parseErr) {
+					actualErrors = append(actualErrors, parseErr.Line)
+					continue
+				}
+				require.NoError(t, err)
+
+				assert.NoError(t, record.Validate())
+				actualRecords = append(actualRecords, record)
+			}
+
+			assert.Equal(t, test.expectedRecords, actualRecords)
+			assert.Equal(t, test.expectedErrors, actualErrors)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/pkg/audiotag"
+	"github.com/neyrzx/youmusic/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const (
+	packageKey  = "scanner"
+	packageName = "library"
+)
+
+// supportedExtensions расширения файлов, которые читает сканер.
+var supportedExtensions = map[string]struct{}{
+	".mp3":  {},
+	".flac": {},
+}
+
+type TracksService interface {
+	Upsert(ctx context.Context, track entities.TrackUpsert) (trackID int, created bool, err error)
+}
+
+// CoversService сохраняет обложку, встроенную в теги файла.
+type CoversService interface {
+	SaveEmbedded(ctx context.Context, trackID int, data []byte) (err error)
+}
+
+type FingerprintStore interface {
+	GetFiles(ctx context.Context, root string) (files []dao.LibraryFile, err error)
+	SaveFile(ctx context.Context, file dao.LibraryFile) (err error)
+	DeleteFiles(ctx context.Context, paths []string) (err error)
+}
+
+// Report результат сканирования. Пути абсолютные.
+type Report struct {
+	New       []string          `json:"new"`
+	Changed   []string          `json:"changed"`
+	Missing   []string          `json:"missing"`
+	Unchanged int               `json:"unchanged"`
+	Failed    map[string]string `json:"failed,omitempty"`
+}
+
+// Scanner обходит каталог с аудиофайлами и синхронизирует теги с библиотекой.
+//
+// Файл перечитывается, только если изменились его размер или mtime.
+//
+// Если передан covers, встроенная в теги обложка сохраняется для трека (и альбома без обложки).
+type Scanner struct {
+	tracks TracksService
+	covers CoversService
+	store  FingerprintStore
+	logger *zerolog.Logger
+}
+
+func NewScanner(tracks TracksService, covers CoversService, store FingerprintStore) *Scanner {
+	logger := logger.DefaultLogger().With().Str(packageKey, packageName).Logger()
+
+	return &Scanner{tracks: tracks, covers: covers, store: store, logger: &logger}
+}
+
+func (s *Scanner) Scan(ctx context.Context, root string) (report Report, err error) {
+	if root, err = filepath.Abs(root); err != nil {
+		return report, fmt.Errorf("failed to filepath.Abs(%s): %w", root, err)
+	}
+
+	known, err := s.store.GetFiles(ctx, root+string(filepath.Separator))
+	if err != nil {
+		return report, fmt.Errorf("failed to store.GetFiles: %w", err)
+	}
+
+	fingerprints := make(map[string]dao.LibraryFile, len(known))
+	for _, file := range known {
+		fingerprints[file.Path] = file
+	}
+
+	report.Failed = make(map[string]string)
+	seen := make(map[string]struct{})
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			report.Failed[path] = err.Error()
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := supportedExtensions[strings.ToLower(filepath.Ext(path))]; !ok {
+			return nil
+		}
+
+		seen[path] = struct{}{}
+
+		info, err := d.Info()
+		if err != nil {
+			report.Failed[path] = err.Error()
+			return nil
+		}
+
+		fingerprint, known := fingerprints[path]
+		if known && fingerprint.Size == info.Size() && fingerprint.ModifiedAt.Equal(modTime(info)) {
+			report.Unchanged++
+			return nil
+		}
+
+		if err = s.scanFile(ctx, path, info, fingerprint.TrackID); err != nil {
+			s.logger.Err(err).Str("path", path).Msg("failed to scan file")
+			report.Failed[path] = err.Error()
+			return nil
+		}
+
+		if known {
+			report.Changed = append(report.Changed, path)
+		} else {
+			report.New = append(report.New, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to filepath.WalkDir(%s): %w", root, err)
+	}
+
+	for path := range fingerprints {
+		if _, ok := seen[path]; !ok {
+			report.Missing = append(report.Missing, path)
+		}
+	}
+	sort.Strings(report.Missing)
+
+	if len(report.Missing) > 0 {
+		if err = s.store.DeleteFiles(ctx, report.Missing); err != nil {
+			return report, fmt.Errorf("failed to store.DeleteFiles: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// scanFile читает теги файла и сохраняет трек. trackID - трек, с которым файл был связан
+// при прошлом сканировании, он обновляется, даже если в тегах изменилось название.
+func (s *Scanner) scanFile(ctx context.Context, path string, info fs.FileInfo, trackID int) error {
+	tags, err := readTags(path)
+	if err != nil {
+		return err
+	}
+
+	track := entities.TrackUpsert{
+		TrackID: trackID,
+		Artist:  tags.Artist,
+		Title:   tags.Title,
+		Album:   tags.Album,
+		Lyric:   tags.Lyrics,
+	}
+	if tags.Year > 0 {
+		track.Released = time.Date(tags.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Без тегов пробуем имя файла вида "Исполнитель - Название.mp3".
+	if track.Artist == "" || track.Title == "" {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if artist, title, ok := strings.Cut(name, " - "); ok {
+			track.Artist = firstNonEmpty(track.Artist, strings.TrimSpace(artist))
+			track.Title = firstNonEmpty(track.Title, strings.TrimSpace(title))
+		}
+	}
+	if track.Artist == "" || track.Title == "" {
+		return errors.New("artist and title tags are required")
+	}
+
+	trackID, _, err = s.tracks.Upsert(ctx, track)
+	if err != nil {
+		return fmt.Errorf("failed to tracks.Upsert: %w", err)
+	}
+
+	// Битая обложка не повод пропускать сам трек.
+	if s.covers != nil && tags.Picture != nil {
+		if err = s.covers.SaveEmbedded(ctx, trackID, tags.Picture.Data); err != nil {
+			s.logger.Err(err).Str("path", path).Int("trackID", trackID).Msg("failed to covers.SaveEmbedded")
+		}
+	}
+
+	if err = s.store.SaveFile(ctx, dao.LibraryFile{
+		Path:       path,
+		Size:       info.Size(),
+		ModifiedAt: modTime(info),
+		TrackID:    trackID,
+	}); err != nil {
+		return fmt.Errorf("failed to store.SaveFile: %w", err)
+	}
+
+	return nil
+}
+
+func readTags(path string) (audiotag.Tags, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return audiotag.Tags{}, fmt.Errorf("failed to os.Open: %w", err)
+	}
+	defer file.Close()
+
+	tags, err := audiotag.Read(file)
+	if err != nil && !errors.Is(err, audiotag.ErrNoTags) {
+		return audiotag.Tags{}, fmt.Errorf("failed to audiotag.Read: %w", err)
+	}
+
+	return tags, nil
+}
+
+// modTime приводит mtime к точности TIMESTAMP в Postgres (микросекунды), иначе сравнение с сохранённым не сойдётся.
+func modTime(info fs.FileInfo) time.Time {
+	return info.ModTime().UTC().Truncate(time.Microsecond)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,190 @@
+package scanner_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/domain/entities"
+	"github.com/neyrzx/youmusic/internal/domain/repositories/dao"
+	"github.com/neyrzx/youmusic/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var modifiedAt = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeTracks выдаёт новым трекам ID по порядку с 100, трек с заданным TrackID обновляется.
+type fakeTracks struct {
+	upserts []entities.TrackUpsert
+}
+
+func (f *fakeTracks) Upsert(_ context.Context, track entities.TrackUpsert) (int, bool, error) {
+	f.upserts = append(f.upserts, track)
+	if track.TrackID != 0 {
+		return track.TrackID, false, nil
+	}
+
+	return 99 + len(f.upserts), true, nil
+}
+
+type fakeStore struct {
+	files   []dao.LibraryFile
+	saved   []dao.LibraryFile
+	deleted []string
+}
+
+func (f *fakeStore) GetFiles(context.Context, string) ([]dao.LibraryFile, error) {
+	return f.files, nil
+}
+
+func (f *fakeStore) SaveFile(_ context.Context, file dao.LibraryFile) error {
+	f.saved = append(f.saved, file)
+	return nil
+}
+
+func (f *fakeStore) DeleteFiles(_ context.Context, paths []string) error {
+	f.deleted = append(f.deleted, paths...)
+	return nil
+}
+
+// id3 собирает ID3v2.4 тег с исполнителем и названием.
+func id3(artist, title string) []byte {
+	frame := func(id, value string) []byte {
+		data := append([]byte{3}, value...)
+		return append(append([]byte(id), 0, 0, 0, byte(len(data)), 0, 0), data...)
+	}
+
+	body := append(frame("TPE1", artist), frame("TIT2", title)...)
+
+	var buf bytes.Buffer
+	buf.WriteString("ID3")
+	buf.Write([]byte{4, 0, 0, 0, 0, 0, byte(len(body))})
+	buf.Write(body)
+	buf.Write([]byte{0xFF, 0xFB, 0x90, 0x00})
+	return buf.Bytes()
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	untagged := []byte("data")
+
+	tests := []struct {
+		name            string
+		files           map[string][]byte
+		known           []dao.LibraryFile
+		expectedReport  scanner.Report
+		expectedUpserts []entities.TrackUpsert
+		expectedSaved   []dao.LibraryFile
+		expectedDeleted []string
+	}{
+		{
+			"case: new file without tags is named by file name",
+			map[string][]byte{"Muse - Uprising.mp3": untagged, "cover.jpg": untagged},
+			nil,
+			scanner.Report{New: []string{"Muse - Uprising.mp3"}},
+			[]entities.TrackUpsert{{Artist: "Muse", Title: "Uprising"}},
+			[]dao.LibraryFile{{Path: "Muse - Uprising.mp3", Size: 4, TrackID: 100}},
+			nil,
+		},
+		{
+			"case: unchanged file is not read",
+			map[string][]byte{"a/Muse - Uprising.mp3": untagged},
+			[]dao.LibraryFile{{Path: "a/Muse - Uprising.mp3", Size: 4, TrackID: 7}},
+			scanner.Report{Unchanged: 1},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"case: changed title updates the linked track",
+			map[string][]byte{"uprising.mp3": id3("Muse", "Uprising (Live)")},
+			[]dao.LibraryFile{{Path: "uprising.mp3", Size: 4, TrackID: 7}},
+			scanner.Report{Changed: []string{"uprising.mp3"}},
+			[]entities.TrackUpsert{{TrackID: 7, Artist: "Muse", Title: "Uprising (Live)"}},
+			[]dao.LibraryFile{{Path: "uprising.mp3", Size: int64(len(id3("Muse", "Uprising (Live)"))), TrackID: 7}},
+			nil,
+		},
+		{
+			"case: changed file with unlinked track is matched by tags",
+			map[string][]byte{"Muse - Uprising.mp3": untagged},
+			[]dao.LibraryFile{{Path: "Muse - Uprising.mp3", Size: 1}},
+			scanner.Report{Changed: []string{"Muse - Uprising.mp3"}},
+			[]entities.TrackUpsert{{Artist: "Muse", Title: "Uprising"}},
+			[]dao.LibraryFile{{Path: "Muse - Uprising.mp3", Size: 4, TrackID: 100}},
+			nil,
+		},
+		{
+			"case: missing files are deleted",
+			nil,
+			[]dao.LibraryFile{{Path: "b.flac", Size: 4, TrackID: 8}, {Path: "a.mp3", Size: 4, TrackID: 7}},
+			scanner.Report{Missing: []string{"a.mp3", "b.flac"}},
+			nil,
+			nil,
+			[]string{"a.mp3", "b.flac"},
+		},
+		{
+			"case: file without tags and artist in name fails",
+			map[string][]byte{"track.mp3": untagged},
+			nil,
+			scanner.Report{Failed: map[string]string{"track.mp3": "artist and title tags are required"}},
+			nil,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			abs := func(path string) string { return filepath.Join(root, path) }
+			absAll := func(paths []string) (result []string) {
+				for _, path := range paths {
+					result = append(result, abs(path))
+				}
+				return result
+			}
+
+			for path, data := range tt.files {
+				require.NoError(t, os.MkdirAll(filepath.Dir(abs(path)), 0o755))
+				require.NoError(t, os.WriteFile(abs(path), data, 0o644))
+				require.NoError(t, os.Chtimes(abs(path), modifiedAt, modifiedAt))
+			}
+
+			store := &fakeStore{}
+			for _, file := range tt.known {
+				file.Path, file.ModifiedAt = abs(file.Path), modifiedAt
+				store.files = append(store.files, file)
+			}
+			tracks := &fakeTracks{}
+
+			report, err := scanner.NewScanner(tracks, nil, store).Scan(context.Background(), root)
+			require.NoError(t, err)
+
+			expectedFailed := make(map[string]string)
+			for path, reason := range tt.expectedReport.Failed {
+				expectedFailed[abs(path)] = reason
+			}
+			var expectedSaved []dao.LibraryFile
+			for _, file := range tt.expectedSaved {
+				file.Path, file.ModifiedAt = abs(file.Path), modifiedAt
+				expectedSaved = append(expectedSaved, file)
+			}
+
+			assert.Equal(t, absAll(tt.expectedReport.New), report.New)
+			assert.Equal(t, absAll(tt.expectedReport.Changed), report.Changed)
+			assert.Equal(t, absAll(tt.expectedReport.Missing), report.Missing)
+			assert.Equal(t, tt.expectedReport.Unchanged, report.Unchanged)
+			assert.Equal(t, expectedFailed, report.Failed)
+			assert.Equal(t, tt.expectedUpserts, tracks.upserts)
+			assert.Equal(t, expectedSaved, store.saved)
+			assert.Equal(t, absAll(tt.expectedDeleted), store.deleted)
+		})
+	}
+}
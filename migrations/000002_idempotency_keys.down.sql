@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "idempotency_keys_expires_at_idx";
+
+DROP TABLE IF EXISTS idempotency_keys;
+
+END;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    "key" VARCHAR(255) NOT NULL PRIMARY KEY,
+    "request_hash" CHAR(64) NOT NULL,
+    "response_status" INTEGER,
+    "response_content_type" VARCHAR(255),
+    "response_body" BYTEA,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "expires_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "idempotency_keys_expires_at_idx" ON idempotency_keys ("expires_at");
+
+END;
@@ -0,0 +1,15 @@
+BEGIN;
+
+DROP TABLE IF EXISTS library_files;
+
+ALTER TABLE IF EXISTS tracks
+    DROP CONSTRAINT "tracks_album_id_fkey"
+;
+
+ALTER TABLE IF EXISTS tracks
+    DROP COLUMN "album_id"
+;
+
+DROP TABLE IF EXISTS albums;
+
+END;
@@ -0,0 +1,44 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS albums
+(
+    "album_id" SERIAL NOT NULL PRIMARY KEY,
+    "artist_id" INTEGER NOT NULL,
+    "title" VARCHAR(255) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS albums
+    ADD CONSTRAINT "albums_artist_id_fkey" FOREIGN KEY ("artist_id") REFERENCES artists ("artist_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS albums
+    ADD CONSTRAINT "albums_title_artist_id_unique" UNIQUE ("title", "artist_id")
+;
+
+ALTER TABLE IF EXISTS tracks
+    ADD COLUMN "album_id" INTEGER
+;
+
+ALTER TABLE IF EXISTS tracks
+    ADD CONSTRAINT "tracks_album_id_fkey" FOREIGN KEY ("album_id") REFERENCES albums ("album_id")
+    ON DELETE SET NULL
+;
+
+-- Отпечатки файлов, просканированных сканером библиотеки.
+CREATE TABLE IF NOT EXISTS library_files
+(
+    "path" VARCHAR(4096) NOT NULL PRIMARY KEY,
+    "size" BIGINT NOT NULL,
+    "modified_at" TIMESTAMP NOT NULL,
+    "track_id" INTEGER,
+    "scanned_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS library_files
+    ADD CONSTRAINT "library_files_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE SET NULL
+;
+
+END;
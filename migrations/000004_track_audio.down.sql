@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS track_audio;
+
+ALTER TABLE IF EXISTS tracks
+    DROP COLUMN "duration_ms"
+;
+
+END;
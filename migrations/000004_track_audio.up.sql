@@ -0,0 +1,24 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS tracks
+    ADD COLUMN "duration_ms" INTEGER
+;
+
+-- Загруженный аудиофайл трека, сам файл лежит в blob хранилище по ключу blob_key.
+CREATE TABLE IF NOT EXISTS track_audio
+(
+    "track_id" INTEGER NOT NULL PRIMARY KEY,
+    "blob_key" VARCHAR(1024) NOT NULL,
+    "content_type" VARCHAR(255) NOT NULL,
+    "size" BIGINT NOT NULL,
+    "etag" VARCHAR(255) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS track_audio
+    ADD CONSTRAINT "track_audio_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+END;
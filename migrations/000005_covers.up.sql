@@ -0,0 +1,37 @@
+BEGIN;
+
+-- Обложки треков и альбомов, оригинал и превью лежат в blob хранилище под префиксом blob_key.
+CREATE TABLE IF NOT EXISTS covers
+(
+    "cover_id" SERIAL NOT NULL PRIMARY KEY,
+    "track_id" INTEGER,
+    "album_id" INTEGER,
+    "blob_key" VARCHAR(1024) NOT NULL,
+    "content_type" VARCHAR(255) NOT NULL,
+    "etag" VARCHAR(255) NOT NULL,
+    "width" INTEGER NOT NULL,
+    "height" INTEGER NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT "covers_owner_check" CHECK (num_nonnulls("track_id", "album_id") = 1)
+);
+
+ALTER TABLE IF EXISTS covers
+    ADD CONSTRAINT "covers_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS covers
+    ADD CONSTRAINT "covers_album_id_fkey" FOREIGN KEY ("album_id") REFERENCES albums ("album_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS covers
+    ADD CONSTRAINT "covers_track_id_unique" UNIQUE ("track_id")
+;
+
+ALTER TABLE IF EXISTS covers
+    ADD CONSTRAINT "covers_album_id_unique" UNIQUE ("album_id")
+;
+
+END;
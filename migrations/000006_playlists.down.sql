@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS playlist_tracks;
+DROP TABLE IF EXISTS playlists;
+
+END;
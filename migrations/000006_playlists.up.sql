@@ -0,0 +1,43 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS playlists
+(
+    "playlist_id" SERIAL NOT NULL PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL,
+    "description" VARCHAR(2048) NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Записи плейлиста упорядочены по position. Позиции идут с шагом (см. repositories.playlistPositionGap),
+-- поэтому вставка и перемещение записи меняют только одну строку; когда между соседями не остаётся места,
+-- позиции плейлиста перенумеровываются.
+CREATE TABLE IF NOT EXISTS playlist_tracks
+(
+    "entry_id" SERIAL NOT NULL PRIMARY KEY,
+    "playlist_id" INTEGER NOT NULL,
+    "track_id" INTEGER NOT NULL,
+    "position" BIGINT NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS playlist_tracks
+    ADD CONSTRAINT "playlist_tracks_playlist_id_fkey" FOREIGN KEY ("playlist_id") REFERENCES playlists ("playlist_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS playlist_tracks
+    ADD CONSTRAINT "playlist_tracks_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+-- DEFERRABLE: уникальность проверяется в конце запроса, а не по строкам, иначе перенумерация одним UPDATE
+-- падает на промежуточных совпадениях позиций.
+ALTER TABLE IF EXISTS playlist_tracks
+    ADD CONSTRAINT "playlist_tracks_playlist_id_position_unique" UNIQUE ("playlist_id", "position")
+    DEFERRABLE INITIALLY IMMEDIATE
+;
+
+CREATE INDEX IF NOT EXISTS "playlist_tracks_track_id_idx" ON playlist_tracks ("track_id");
+
+END;
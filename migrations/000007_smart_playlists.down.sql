@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS smart_playlists;
+DROP TABLE IF EXISTS track_tags;
+
+END;
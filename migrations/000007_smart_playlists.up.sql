@@ -0,0 +1,31 @@
+BEGIN;
+
+-- Теги треков (жанры, настроения и т.п.), хранятся в нижнем регистре.
+CREATE TABLE IF NOT EXISTS track_tags
+(
+    "track_id" INTEGER NOT NULL,
+    "tag" VARCHAR(64) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("track_id", "tag")
+);
+
+ALTER TABLE IF EXISTS track_tags
+    ADD CONSTRAINT "track_tags_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+CREATE INDEX IF NOT EXISTS "track_tags_tag_idx" ON track_tags ("tag");
+
+-- Смарт-плейлисты хранят только правила (entities.TrackRule в JSON), треки вычисляются при чтении.
+CREATE TABLE IF NOT EXISTS smart_playlists
+(
+    "smart_playlist_id" SERIAL NOT NULL PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL,
+    "rules" JSONB NOT NULL,
+    "sort" VARCHAR(32) NOT NULL DEFAULT '',
+    "tracks_limit" INTEGER NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+END;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS tracks
+    DROP COLUMN IF EXISTS "created_by",
+    DROP COLUMN IF EXISTS "updated_by"
+;
+
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
+
+END;
@@ -0,0 +1,56 @@
+BEGIN;
+
+-- Email хранится в нижнем регистре.
+CREATE TABLE IF NOT EXISTS users
+(
+    "user_id" SERIAL NOT NULL PRIMARY KEY,
+    "email" VARCHAR(255) NOT NULL,
+    "password_hash" VARCHAR(255) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS users
+    ADD CONSTRAINT "users_email_unique" UNIQUE ("email")
+;
+
+-- Refresh токены хранятся как SHA-256. При обновлении токен отзывается и заменяется новым из того же
+-- семейства (family_id), повторное использование отозванного токена отзывает всё семейство.
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+    "token_id" SERIAL NOT NULL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL,
+    "family_id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "token_hash" BYTEA NOT NULL,
+    "expires_at" TIMESTAMP NOT NULL,
+    "revoked_at" TIMESTAMP,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS refresh_tokens
+    ADD CONSTRAINT "refresh_tokens_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES users ("user_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS refresh_tokens
+    ADD CONSTRAINT "refresh_tokens_token_hash_unique" UNIQUE ("token_hash")
+;
+
+CREATE INDEX IF NOT EXISTS "refresh_tokens_family_id_idx" ON refresh_tokens ("family_id");
+
+ALTER TABLE IF EXISTS tracks
+    ADD COLUMN IF NOT EXISTS "created_by" INTEGER,
+    ADD COLUMN IF NOT EXISTS "updated_by" INTEGER
+;
+
+ALTER TABLE IF EXISTS tracks
+    ADD CONSTRAINT "tracks_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES users ("user_id")
+    ON DELETE SET NULL
+;
+
+ALTER TABLE IF EXISTS tracks
+    ADD CONSTRAINT "tracks_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES users ("user_id")
+    ON DELETE SET NULL
+;
+
+END;
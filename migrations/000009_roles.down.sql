@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS users
+    DROP COLUMN IF EXISTS "role"
+;
+
+END;
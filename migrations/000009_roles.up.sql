@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS users
+    ADD COLUMN IF NOT EXISTS "role" VARCHAR(16) NOT NULL DEFAULT 'viewer'
+;
+
+ALTER TABLE IF EXISTS users
+    ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('viewer', 'editor', 'moderator', 'admin'))
+;
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS rate_limit_counters;
+DROP TABLE IF EXISTS api_keys;
+
+END;
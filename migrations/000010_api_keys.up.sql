@@ -0,0 +1,46 @@
+BEGIN;
+
+-- Ключ хранится как SHA-256, prefix - первые символы ключа, чтобы владелец мог отличить ключи в списке.
+-- scopes - подмножество прав роли владельца на момент создания ключа.
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    "key_id" SERIAL NOT NULL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    "prefix" VARCHAR(16) NOT NULL,
+    "key_hash" BYTEA NOT NULL,
+    "scopes" TEXT[] NOT NULL DEFAULT '{}',
+    "quota" INTEGER NOT NULL,
+    "expires_at" TIMESTAMP,
+    "last_used_at" TIMESTAMP,
+    "revoked_at" TIMESTAMP,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS api_keys
+    ADD CONSTRAINT "api_keys_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES users ("user_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS api_keys
+    ADD CONSTRAINT "api_keys_key_hash_unique" UNIQUE ("key_hash")
+;
+
+ALTER TABLE IF EXISTS api_keys
+    ADD CONSTRAINT "api_keys_quota_check" CHECK ("quota" > 0)
+;
+
+CREATE INDEX IF NOT EXISTS "api_keys_user_id_idx" ON api_keys ("user_id");
+
+-- Счётчики запросов в фиксированных окнах (pkg/ratelimit). Строка переиспользуется при смене окна.
+CREATE TABLE IF NOT EXISTS rate_limit_counters
+(
+    "key" VARCHAR(255) NOT NULL PRIMARY KEY,
+    "window_start" TIMESTAMP NOT NULL,
+    "count" BIGINT NOT NULL,
+    "expires_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "rate_limit_counters_expires_at_idx" ON rate_limit_counters ("expires_at");
+
+END;
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS favorites
+(
+    "user_id" INTEGER NOT NULL,
+    "track_id" INTEGER NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("user_id", "track_id")
+);
+
+ALTER TABLE IF EXISTS favorites
+    ADD CONSTRAINT "favorites_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES users ("user_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS favorites
+    ADD CONSTRAINT "favorites_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+-- Список избранного по умолчанию упорядочен по времени добавления.
+CREATE INDEX IF NOT EXISTS "favorites_user_id_created_at_idx" ON favorites ("user_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS "favorites_track_id_idx" ON favorites ("track_id");
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Секции удаляются вместе с секционированной таблицей.
+DROP TABLE IF EXISTS scrobbles;
+
+END;
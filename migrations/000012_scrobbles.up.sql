@@ -0,0 +1,50 @@
+BEGIN;
+
+-- Прослушивания секционированы по месяцам played_at. Секции на следующие месяцы создаёт фоновая задача
+-- сервиса (ScrobblesRepository.EnsurePartitions), DEFAULT секция принимает всё, что вне созданных.
+CREATE TABLE IF NOT EXISTS scrobbles
+(
+    "scrobble_id" BIGSERIAL NOT NULL,
+    "user_id" INTEGER NOT NULL,
+    "track_id" INTEGER NOT NULL,
+    "played_at" TIMESTAMP NOT NULL,
+    "duration_played_ms" INTEGER NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("scrobble_id", "played_at")
+) PARTITION BY RANGE ("played_at");
+
+ALTER TABLE IF EXISTS scrobbles
+    ADD CONSTRAINT "scrobbles_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES users ("user_id")
+    ON DELETE CASCADE
+;
+
+ALTER TABLE IF EXISTS scrobbles
+    ADD CONSTRAINT "scrobbles_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+-- Точные повторы отсекаются ограничением, повторы в окне дедупликации - при вставке.
+ALTER TABLE IF EXISTS scrobbles
+    ADD CONSTRAINT "scrobbles_user_id_track_id_played_at_unique" UNIQUE ("user_id", "track_id", "played_at")
+;
+
+CREATE INDEX IF NOT EXISTS "scrobbles_user_id_played_at_idx" ON scrobbles ("user_id", "played_at" DESC, "scrobble_id" DESC);
+CREATE INDEX IF NOT EXISTS "scrobbles_track_id_idx" ON scrobbles ("track_id");
+
+CREATE TABLE IF NOT EXISTS scrobbles_default PARTITION OF scrobbles DEFAULT;
+
+DO $$
+DECLARE
+    month DATE;
+BEGIN
+    FOR month IN
+        SELECT generate_series(date_trunc('month', NOW()) - INTERVAL '1 month', date_trunc('month', NOW()) + INTERVAL '3 months', INTERVAL '1 month')
+    LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF scrobbles FOR VALUES FROM (%L) TO (%L)',
+            'scrobbles_' || to_char(month, 'YYYY_MM'), month, month + INTERVAL '1 month'
+        );
+    END LOOP;
+END $$;
+
+END;
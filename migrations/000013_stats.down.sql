@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP MATERIALIZED VIEW IF EXISTS stats_newest_tracks;
+DROP MATERIALIZED VIEW IF EXISTS stats_lyrics;
+DROP MATERIALIZED VIEW IF EXISTS stats_track_plays_daily;
+DROP MATERIALIZED VIEW IF EXISTS stats_artists;
+DROP MATERIALIZED VIEW IF EXISTS stats_tracks_per_year;
+
+END;
@@ -0,0 +1,53 @@
+BEGIN;
+
+-- Витрины /stats. Обновляются фоновой задачей через REFRESH MATERIALIZED VIEW CONCURRENTLY,
+-- для этого у каждой витрины есть уникальный индекс.
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_tracks_per_year AS
+    SELECT EXTRACT(YEAR FROM released_at)::INTEGER AS "year", COUNT(*) AS "tracks"
+    FROM tracks
+    GROUP BY 1
+WITH DATA;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "stats_tracks_per_year_year_idx" ON stats_tracks_per_year ("year");
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_artists AS
+    SELECT artists.artist_id, artists.name, COUNT(tracks.track_id) AS "tracks"
+    FROM artists JOIN tracks ON tracks.artist_id = artists.artist_id
+    GROUP BY artists.artist_id, artists.name
+WITH DATA;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "stats_artists_artist_id_idx" ON stats_artists ("artist_id");
+CREATE INDEX IF NOT EXISTS "stats_artists_tracks_idx" ON stats_artists ("tracks" DESC, "artist_id");
+
+-- Прослушивания по дням, топ за окно считается суммой по дням.
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_track_plays_daily AS
+    SELECT track_id, played_at::DATE AS "day", COUNT(*) AS "plays"
+    FROM scrobbles
+    GROUP BY track_id, played_at::DATE
+WITH DATA;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "stats_track_plays_daily_track_id_day_idx" ON stats_track_plays_daily ("track_id", "day");
+CREATE INDEX IF NOT EXISTS "stats_track_plays_daily_day_idx" ON stats_track_plays_daily ("day");
+
+-- Одна строка, id нужен только для уникального индекса.
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_lyrics AS
+    SELECT
+        1 AS "id",
+        (SELECT COUNT(*) FROM tracks) AS "tracks",
+        (SELECT COUNT(DISTINCT track_id) FROM lyrics) AS "tracks_with_lyrics",
+        (SELECT COUNT(*) FROM lyrics) AS "verses"
+WITH DATA;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "stats_lyrics_id_idx" ON stats_lyrics ("id");
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_newest_tracks AS
+    SELECT tracks.track_id, artists.name AS "artist", tracks.title, tracks.released_at, tracks.created_at
+    FROM tracks JOIN artists ON artists.artist_id = tracks.artist_id
+    ORDER BY tracks.created_at DESC, tracks.track_id DESC
+    LIMIT 500
+WITH DATA;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "stats_newest_tracks_track_id_idx" ON stats_newest_tracks ("track_id");
+
+END;
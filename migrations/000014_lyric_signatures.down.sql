@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS lyric_bands;
+DROP TABLE IF EXISTS lyric_signatures;
+
+END;
@@ -0,0 +1,33 @@
+BEGIN;
+
+-- MinHash сигнатуры текстов для /tracks/:id/similar. Строка есть у каждого проиндексированного трека,
+-- у трека без текста сигнатура пустая. Треки без строки индексирует фоновая задача.
+CREATE TABLE IF NOT EXISTS lyric_signatures
+(
+    "track_id" INTEGER NOT NULL PRIMARY KEY,
+    "signature" BIGINT[] NOT NULL,
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE IF EXISTS lyric_signatures
+    ADD CONSTRAINT "lyric_signatures_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+-- Полосы LSH: треки с одинаковым хешем полосы - кандидаты в похожие.
+CREATE TABLE IF NOT EXISTS lyric_bands
+(
+    "track_id" INTEGER NOT NULL,
+    "band" SMALLINT NOT NULL,
+    "hash" BIGINT NOT NULL,
+    PRIMARY KEY ("track_id", "band")
+);
+
+ALTER TABLE IF EXISTS lyric_bands
+    ADD CONSTRAINT "lyric_bands_track_id_fkey" FOREIGN KEY ("track_id") REFERENCES tracks ("track_id")
+    ON DELETE CASCADE
+;
+
+CREATE INDEX IF NOT EXISTS "lyric_bands_band_hash_idx" ON lyric_bands ("band", "hash");
+
+END;
@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS tracks DROP CONSTRAINT IF EXISTS "tracks_title_key_artist_id_unique";
+ALTER TABLE IF EXISTS artists DROP CONSTRAINT IF EXISTS "artists_name_key_unique";
+
+ALTER TABLE IF EXISTS tracks DROP COLUMN IF EXISTS "title_key";
+ALTER TABLE IF EXISTS artists DROP COLUMN IF EXISTS "name_key";
+
+DROP FUNCTION IF EXISTS name_key(TEXT);
+
+ALTER TABLE IF EXISTS tracks
+    ADD CONSTRAINT "tracks_title_artist_id_unique" UNIQUE ("title", "artist_id")
+;
+
+ALTER TABLE IF EXISTS artists
+    ADD CONSTRAINT "artists_name_unique" UNIQUE ("name")
+;
+
+END;
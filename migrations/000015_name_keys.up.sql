@@ -0,0 +1,105 @@
+BEGIN;
+
+-- name_key ключ сравнения названий треков и имён исполнителей: NFKC, схлопнутые пробелы, нижний регистр.
+-- Имена, отличающиеся только формой Unicode, регистром или пробелами, считаются одинаковыми.
+CREATE OR REPLACE FUNCTION name_key(value TEXT) RETURNS TEXT
+    LANGUAGE SQL IMMUTABLE STRICT PARALLEL SAFE
+    AS $$ SELECT lower(btrim(regexp_replace(normalize(value, NFKC), '\s+', ' ', 'g'))) $$;
+
+-- До смены ограничений проверяем, что существующие данные им не противоречат. Если есть
+-- совпадения, миграция падает со списком строк. Совпадающих исполнителей сливает SQL из HINT
+-- ошибки, совпадающие треки исполнителя сливаются через /admin/duplicates/merge (приложение
+-- предыдущей версии) или переименовываются. После этого миграция запускается снова (после migrate force 14).
+DO $$
+DECLARE
+    collision RECORD;
+    report TEXT := '';
+    total INTEGER := 0;
+    artists_total INTEGER := 0;
+    remedy TEXT := 'rename the rows or merge duplicate tracks of an artist via /admin/duplicates/merge';
+BEGIN
+    FOR collision IN
+        SELECT 'artists' AS kind, 'artists ' || string_agg(format('%s:%L', artist_id, name), ', ' ORDER BY artist_id) AS line
+        FROM artists
+        GROUP BY name_key(name)
+        HAVING COUNT(*) > 1
+        UNION ALL
+        SELECT 'tracks', 'tracks of artist ' || artist_id || ' ' || string_agg(format('%s:%L', track_id, title), ', ' ORDER BY track_id)
+        FROM tracks
+        GROUP BY artist_id, name_key(title)
+        HAVING COUNT(*) > 1
+    LOOP
+        total := total + 1;
+        IF collision.kind = 'artists' THEN
+            artists_total := artists_total + 1;
+        END IF;
+        RAISE WARNING 'name_key collision: %', collision.line;
+        IF total <= 20 THEN
+            report := report || E'\n' || collision.line;
+        END IF;
+    END LOOP;
+
+    -- Исполнители сливаются в исполнителя с наименьшим artist_id, их альбомы с одинаковым названием -
+    -- в альбом с наименьшим album_id. Если UPDATE tracks падает на tracks_title_artist_id_unique,
+    -- у сливаемых исполнителей есть треки с одинаковым названием: их нужно сначала слить через
+    -- /admin/duplicates/merge.
+    IF artists_total > 0 THEN
+        remedy := $hint$merge artists with the same name_key into the one with the lowest artist_id:
+BEGIN;
+CREATE TEMP TABLE artist_merge ON COMMIT DROP AS
+    SELECT artist_id AS source, MIN(artist_id) OVER (PARTITION BY lower(btrim(regexp_replace(normalize(name, NFKC), '\s+', ' ', 'g')))) AS target
+    FROM artists;
+DELETE FROM artist_merge WHERE source = target;
+CREATE TEMP TABLE album_merge ON COMMIT DROP AS
+    SELECT album_id AS source, MIN(album_id) OVER (PARTITION BY COALESCE(artist_merge.target, albums.artist_id), albums.title) AS target
+    FROM albums LEFT JOIN artist_merge ON artist_merge.source = albums.artist_id;
+DELETE FROM album_merge WHERE source = target;
+UPDATE covers SET album_id = moved.target
+FROM (
+    SELECT DISTINCT ON (album_merge.target) album_merge.target, covers.cover_id
+    FROM album_merge JOIN covers ON covers.album_id = album_merge.source
+    ORDER BY album_merge.target, album_merge.source
+) AS moved
+WHERE covers.cover_id = moved.cover_id AND NOT EXISTS (SELECT 1 FROM covers AS other WHERE other.album_id = moved.target);
+UPDATE tracks SET album_id = album_merge.target FROM album_merge WHERE tracks.album_id = album_merge.source;
+DELETE FROM albums USING album_merge WHERE albums.album_id = album_merge.source;
+UPDATE albums SET artist_id = artist_merge.target FROM artist_merge WHERE albums.artist_id = artist_merge.source;
+UPDATE tracks SET artist_id = artist_merge.target FROM artist_merge WHERE tracks.artist_id = artist_merge.source;
+DELETE FROM artists USING artist_merge WHERE artists.artist_id = artist_merge.source;
+COMMIT;
+if UPDATE tracks fails on tracks_title_artist_id_unique, merge the tracks with the same title via /admin/duplicates/merge first; $hint$ || remedy;
+    END IF;
+
+    IF total > 0 THEN
+        RAISE EXCEPTION 'found % name_key collisions, rename or merge them before migrating (first 20):%', total, report
+            USING HINT = remedy;
+    END IF;
+END $$;
+
+ALTER TABLE IF EXISTS artists DROP CONSTRAINT IF EXISTS "artists_name_unique";
+ALTER TABLE IF EXISTS tracks DROP CONSTRAINT IF EXISTS "tracks_title_artist_id_unique";
+
+-- Новые записи нормализуются приложением (entities.NormalizeName), существующие приводим к тому же виду.
+UPDATE artists SET name = normalize(btrim(regexp_replace(name, '\s+', ' ', 'g')), NFC)
+WHERE name <> normalize(btrim(regexp_replace(name, '\s+', ' ', 'g')), NFC);
+
+UPDATE tracks SET title = normalize(btrim(regexp_replace(title, '\s+', ' ', 'g')), NFC)
+WHERE title <> normalize(btrim(regexp_replace(title, '\s+', ' ', 'g')), NFC);
+
+ALTER TABLE IF EXISTS artists
+    ADD COLUMN "name_key" TEXT GENERATED ALWAYS AS (name_key("name")) STORED
+;
+
+ALTER TABLE IF EXISTS tracks
+    ADD COLUMN "title_key" TEXT GENERATED ALWAYS AS (name_key("title")) STORED
+;
+
+ALTER TABLE IF EXISTS artists
+    ADD CONSTRAINT "artists_name_key_unique" UNIQUE ("name_key")
+;
+
+ALTER TABLE IF EXISTS tracks
+    ADD CONSTRAINT "tracks_title_key_artist_id_unique" UNIQUE ("title_key", "artist_id")
+;
+
+END;
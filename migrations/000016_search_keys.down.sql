@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "tracks_title_search_key_missing_idx";
+DROP INDEX IF EXISTS "artists_search_key_missing_idx";
+DROP INDEX IF EXISTS "tracks_title_search_key_idx";
+DROP INDEX IF EXISTS "artists_search_key_idx";
+
+ALTER TABLE IF EXISTS tracks DROP COLUMN IF EXISTS "title_search_key";
+ALTER TABLE IF EXISTS artists DROP COLUMN IF EXISTS "search_key";
+
+END;
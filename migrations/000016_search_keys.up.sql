@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Ключи поиска translit.SearchKey: совпадают для написаний кириллицей и латиницей ("Кино" и "Kino").
+-- Ключи считает приложение, у строк, созданных до миграции, их заполняет фоновая задача.
+ALTER TABLE IF EXISTS artists ADD COLUMN IF NOT EXISTS "search_key" TEXT;
+ALTER TABLE IF EXISTS tracks ADD COLUMN IF NOT EXISTS "title_search_key" TEXT;
+
+CREATE INDEX IF NOT EXISTS "artists_search_key_idx" ON artists ("search_key");
+CREATE INDEX IF NOT EXISTS "tracks_title_search_key_idx" ON tracks ("title_search_key");
+CREATE INDEX IF NOT EXISTS "artists_search_key_missing_idx" ON artists ("artist_id") WHERE "search_key" IS NULL;
+CREATE INDEX IF NOT EXISTS "tracks_title_search_key_missing_idx" ON tracks ("track_id") WHERE "title_search_key" IS NULL;
+
+END;
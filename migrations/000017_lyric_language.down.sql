@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "tracks_lyric_language_idx";
+
+ALTER TABLE IF EXISTS tracks DROP COLUMN IF EXISTS "lyric_language_confidence";
+ALTER TABLE IF EXISTS tracks DROP COLUMN IF EXISTS "lyric_language";
+
+END;
@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Язык текста трека (ISO 639-1) и уверенность определения от 0 до 1. NULL - текста нет
+-- или язык не определён. Определяется при записи текста, старые строки заполняет cmd/langdetect.
+ALTER TABLE IF EXISTS tracks ADD COLUMN IF NOT EXISTS "lyric_language" TEXT;
+ALTER TABLE IF EXISTS tracks ADD COLUMN IF NOT EXISTS "lyric_language_confidence" REAL;
+
+CREATE INDEX IF NOT EXISTS "tracks_lyric_language_idx" ON tracks ("lyric_language");
+
+END;
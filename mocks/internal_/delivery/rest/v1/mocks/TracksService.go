@@ -69,6 +69,65 @@ func (_c *MockTracksService_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CreateBatch provides a mock function with given fields: ctx, tracks
+func (_m *MockTracksService) CreateBatch(ctx context.Context, tracks []entities.TrackCreate) ([]entities.TrackBatchResult, error) {
+	ret := _m.Called(ctx, tracks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 []entities.TrackBatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.TrackCreate) ([]entities.TrackBatchResult, error)); ok {
+		return rf(ctx, tracks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.TrackCreate) []entities.TrackBatchResult); ok {
+		r0 = rf(ctx, tracks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.TrackBatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entities.TrackCreate) error); ok {
+		r1 = rf(ctx, tracks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTracksService_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockTracksService_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tracks []entities.TrackCreate
+func (_e *MockTracksService_Expecter) CreateBatch(ctx interface{}, tracks interface{}) *MockTracksService_CreateBatch_Call {
+	return &MockTracksService_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, tracks)}
+}
+
+func (_c *MockTracksService_CreateBatch_Call) Run(run func(ctx context.Context, tracks []entities.TrackCreate)) *MockTracksService_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entities.TrackCreate))
+	})
+	return _c
+}
+
+func (_c *MockTracksService_CreateBatch_Call) Return(_a0 []entities.TrackBatchResult, _a1 error) *MockTracksService_CreateBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTracksService_CreateBatch_Call) RunAndReturn(run func(context.Context, []entities.TrackCreate) ([]entities.TrackBatchResult, error)) *MockTracksService_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, trackID
 func (_m *MockTracksService) Delete(ctx context.Context, trackID int) error {
 	ret := _m.Called(ctx, trackID)
@@ -116,6 +175,54 @@ func (_c *MockTracksService_Delete_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// Export provides a mock function with given fields: ctx, filters, fn
+func (_m *MockTracksService) Export(ctx context.Context, filters entities.TrackGetListFilters, fn func(entities.Track) error) error {
+	ret := _m.Called(ctx, filters, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TrackGetListFilters, func(entities.Track) error) error); ok {
+		r0 = rf(ctx, filters, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksService_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockTracksService_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters entities.TrackGetListFilters
+//   - fn func(entities.Track) error
+func (_e *MockTracksService_Expecter) Export(ctx interface{}, filters interface{}, fn interface{}) *MockTracksService_Export_Call {
+	return &MockTracksService_Export_Call{Call: _e.mock.On("Export", ctx, filters, fn)}
+}
+
+func (_c *MockTracksService_Export_Call) Run(run func(ctx context.Context, filters entities.TrackGetListFilters, fn func(entities.Track) error)) *MockTracksService_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entities.TrackGetListFilters), args[2].(func(entities.Track) error))
+	})
+	return _c
+}
+
+func (_c *MockTracksService_Export_Call) Return(_a0 error) *MockTracksService_Export_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTracksService_Export_Call) RunAndReturn(run func(context.Context, entities.TrackGetListFilters, func(entities.Track) error) error) *MockTracksService_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function with given fields: ctx, ID
 func (_m *MockTracksService) GetByID(ctx context.Context, ID int) (entities.Track, error) {
 	ret := _m.Called(ctx, ID)
@@ -290,6 +397,54 @@ func (_c *MockTracksService_GetLyric_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// SetTags provides a mock function with given fields: ctx, trackID, tags
+func (_m *MockTracksService) SetTags(ctx context.Context, trackID int, tags []string) error {
+	ret := _m.Called(ctx, trackID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, trackID, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksService_SetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTags'
+type MockTracksService_SetTags_Call struct {
+	*mock.Call
+}
+
+// SetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - trackID int
+//   - tags []string
+func (_e *MockTracksService_Expecter) SetTags(ctx interface{}, trackID interface{}, tags interface{}) *MockTracksService_SetTags_Call {
+	return &MockTracksService_SetTags_Call{Call: _e.mock.On("SetTags", ctx, trackID, tags)}
+}
+
+func (_c *MockTracksService_SetTags_Call) Run(run func(ctx context.Context, trackID int, tags []string)) *MockTracksService_SetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockTracksService_SetTags_Call) Return(_a0 error) *MockTracksService_SetTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTracksService_SetTags_Call) RunAndReturn(run func(context.Context, int, []string) error) *MockTracksService_SetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, track
 func (_m *MockTracksService) Update(ctx context.Context, track entities.TrackUpdate) error {
 	ret := _m.Called(ctx, track)
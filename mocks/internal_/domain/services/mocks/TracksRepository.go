@@ -239,6 +239,66 @@ func (_c *MockTracksRepository_CreateTrack_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// CreateTracks provides a mock function with given fields: ctx, tx, tracks
+func (_m *MockTracksRepository) CreateTracks(ctx context.Context, tx pgx.Tx, tracks []dao.Track) ([]int, error) {
+	ret := _m.Called(ctx, tx, tracks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTracks")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, []dao.Track) ([]int, error)); ok {
+		return rf(ctx, tx, tracks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, []dao.Track) []int); ok {
+		r0 = rf(ctx, tx, tracks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, []dao.Track) error); ok {
+		r1 = rf(ctx, tx, tracks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTracksRepository_CreateTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTracks'
+type MockTracksRepository_CreateTracks_Call struct {
+	*mock.Call
+}
+
+// CreateTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - tracks []dao.Track
+func (_e *MockTracksRepository_Expecter) CreateTracks(ctx interface{}, tx interface{}, tracks interface{}) *MockTracksRepository_CreateTracks_Call {
+	return &MockTracksRepository_CreateTracks_Call{Call: _e.mock.On("CreateTracks", ctx, tx, tracks)}
+}
+
+func (_c *MockTracksRepository_CreateTracks_Call) Run(run func(ctx context.Context, tx pgx.Tx, tracks []dao.Track)) *MockTracksRepository_CreateTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].([]dao.Track))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_CreateTracks_Call) Return(ids []int, err error) *MockTracksRepository_CreateTracks_Call {
+	_c.Call.Return(ids, err)
+	return _c
+}
+
+func (_c *MockTracksRepository_CreateTracks_Call) RunAndReturn(run func(context.Context, pgx.Tx, []dao.Track) ([]int, error)) *MockTracksRepository_CreateTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteLyricByTrackID provides a mock function with given fields: ctx, tx, trackID
 func (_m *MockTracksRepository) DeleteLyricByTrackID(ctx context.Context, tx pgx.Tx, trackID int) error {
 	ret := _m.Called(ctx, tx, trackID)
@@ -334,6 +394,54 @@ func (_c *MockTracksRepository_DeleteTrackByID_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// ExportTracks provides a mock function with given fields: ctx, filter, fn
+func (_m *MockTracksRepository) ExportTracks(ctx context.Context, filter entities.TrackGetListFilters, fn func(entities.Track) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTracks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TrackGetListFilters, func(entities.Track) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksRepository_ExportTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTracks'
+type MockTracksRepository_ExportTracks_Call struct {
+	*mock.Call
+}
+
+// ExportTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.TrackGetListFilters
+//   - fn func(entities.Track) error
+func (_e *MockTracksRepository_Expecter) ExportTracks(ctx interface{}, filter interface{}, fn interface{}) *MockTracksRepository_ExportTracks_Call {
+	return &MockTracksRepository_ExportTracks_Call{Call: _e.mock.On("ExportTracks", ctx, filter, fn)}
+}
+
+func (_c *MockTracksRepository_ExportTracks_Call) Run(run func(ctx context.Context, filter entities.TrackGetListFilters, fn func(entities.Track) error)) *MockTracksRepository_ExportTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entities.TrackGetListFilters), args[2].(func(entities.Track) error))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_ExportTracks_Call) Return(err error) *MockTracksRepository_ExportTracks_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTracksRepository_ExportTracks_Call) RunAndReturn(run func(context.Context, entities.TrackGetListFilters, func(entities.Track) error) error) *MockTracksRepository_ExportTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetArtistIDByTrackID provides a mock function with given fields: ctx, tx, id
 func (_m *MockTracksRepository) GetArtistIDByTrackID(ctx context.Context, tx pgx.Tx, id int) (int, error) {
 	ret := _m.Called(ctx, tx, id)
@@ -449,6 +557,125 @@ func (_c *MockTracksRepository_GetByID_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetExistingTracks provides a mock function with given fields: ctx, tracks
+func (_m *MockTracksRepository) GetExistingTracks(ctx context.Context, tracks []entities.TrackCreate) ([]entities.TrackCreate, error) {
+	ret := _m.Called(ctx, tracks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExistingTracks")
+	}
+
+	var r0 []entities.TrackCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.TrackCreate) ([]entities.TrackCreate, error)); ok {
+		return rf(ctx, tracks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.TrackCreate) []entities.TrackCreate); ok {
+		r0 = rf(ctx, tracks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.TrackCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entities.TrackCreate) error); ok {
+		r1 = rf(ctx, tracks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTracksRepository_GetExistingTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExistingTracks'
+type MockTracksRepository_GetExistingTracks_Call struct {
+	*mock.Call
+}
+
+// GetExistingTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tracks []entities.TrackCreate
+func (_e *MockTracksRepository_Expecter) GetExistingTracks(ctx interface{}, tracks interface{}) *MockTracksRepository_GetExistingTracks_Call {
+	return &MockTracksRepository_GetExistingTracks_Call{Call: _e.mock.On("GetExistingTracks", ctx, tracks)}
+}
+
+func (_c *MockTracksRepository_GetExistingTracks_Call) Run(run func(ctx context.Context, tracks []entities.TrackCreate)) *MockTracksRepository_GetExistingTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entities.TrackCreate))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_GetExistingTracks_Call) Return(existing []entities.TrackCreate, err error) *MockTracksRepository_GetExistingTracks_Call {
+	_c.Call.Return(existing, err)
+	return _c
+}
+
+func (_c *MockTracksRepository_GetExistingTracks_Call) RunAndReturn(run func(context.Context, []entities.TrackCreate) ([]entities.TrackCreate, error)) *MockTracksRepository_GetExistingTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFavoriteTrackIDs provides a mock function with given fields: ctx, userID, trackIDs
+func (_m *MockTracksRepository) GetFavoriteTrackIDs(ctx context.Context, userID int, trackIDs []int) ([]int, error) {
+	ret := _m.Called(ctx, userID, trackIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFavoriteTrackIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) ([]int, error)); ok {
+		return rf(ctx, userID, trackIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) []int); ok {
+		r0 = rf(ctx, userID, trackIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, userID, trackIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTracksRepository_GetFavoriteTrackIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFavoriteTrackIDs'
+type MockTracksRepository_GetFavoriteTrackIDs_Call struct {
+	*mock.Call
+}
+
+// GetFavoriteTrackIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - trackIDs []int
+func (_e *MockTracksRepository_Expecter) GetFavoriteTrackIDs(ctx interface{}, userID interface{}, trackIDs interface{}) *MockTracksRepository_GetFavoriteTrackIDs_Call {
+	return &MockTracksRepository_GetFavoriteTrackIDs_Call{Call: _e.mock.On("GetFavoriteTrackIDs", ctx, userID, trackIDs)}
+}
+
+func (_c *MockTracksRepository_GetFavoriteTrackIDs_Call) Run(run func(ctx context.Context, userID int, trackIDs []int)) *MockTracksRepository_GetFavoriteTrackIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_GetFavoriteTrackIDs_Call) Return(favoriteIDs []int, err error) *MockTracksRepository_GetFavoriteTrackIDs_Call {
+	_c.Call.Return(favoriteIDs, err)
+	return _c
+}
+
+func (_c *MockTracksRepository_GetFavoriteTrackIDs_Call) RunAndReturn(run func(context.Context, int, []int) ([]int, error)) *MockTracksRepository_GetFavoriteTrackIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLyricPaginated provides a mock function with given fields: ctx, tx, trackID, offset
 func (_m *MockTracksRepository) GetLyricPaginated(ctx context.Context, tx pgx.Tx, trackID int, offset int) (dao.Lyric, error) {
 	ret := _m.Called(ctx, tx, trackID, offset)
@@ -568,24 +795,208 @@ func (_c *MockTracksRepository_GetLyricsByTrackIDs_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// GetOrCreateAlbum provides a mock function with given fields: ctx, tx, album
+func (_m *MockTracksRepository) GetOrCreateAlbum(ctx context.Context, tx pgx.Tx, album dao.Album) (int, error) {
+	ret := _m.Called(ctx, tx, album)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrCreateAlbum")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, dao.Album) (int, error)); ok {
+		return rf(ctx, tx, album)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, dao.Album) int); ok {
+		r0 = rf(ctx, tx, album)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, dao.Album) error); ok {
+		r1 = rf(ctx, tx, album)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTracksRepository_GetOrCreateAlbum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrCreateAlbum'
+type MockTracksRepository_GetOrCreateAlbum_Call struct {
+	*mock.Call
+}
+
+// GetOrCreateAlbum is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - album dao.Album
+func (_e *MockTracksRepository_Expecter) GetOrCreateAlbum(ctx interface{}, tx interface{}, album interface{}) *MockTracksRepository_GetOrCreateAlbum_Call {
+	return &MockTracksRepository_GetOrCreateAlbum_Call{Call: _e.mock.On("GetOrCreateAlbum", ctx, tx, album)}
+}
+
+func (_c *MockTracksRepository_GetOrCreateAlbum_Call) Run(run func(ctx context.Context, tx pgx.Tx, album dao.Album)) *MockTracksRepository_GetOrCreateAlbum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(dao.Album))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_GetOrCreateAlbum_Call) Return(id int, err error) *MockTracksRepository_GetOrCreateAlbum_Call {
+	_c.Call.Return(id, err)
+	return _c
+}
+
+func (_c *MockTracksRepository_GetOrCreateAlbum_Call) RunAndReturn(run func(context.Context, pgx.Tx, dao.Album) (int, error)) *MockTracksRepository_GetOrCreateAlbum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrCreateArtists provides a mock function with given fields: ctx, tx, names
+func (_m *MockTracksRepository) GetOrCreateArtists(ctx context.Context, tx pgx.Tx, names []string) (map[string]int, error) {
+	ret := _m.Called(ctx, tx, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrCreateArtists")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, []string) (map[string]int, error)); ok {
+		return rf(ctx, tx, names)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, []string) map[string]int); ok {
+		r0 = rf(ctx, tx, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, []string) error); ok {
+		r1 = rf(ctx, tx, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTracksRepository_GetOrCreateArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrCreateArtists'
+type MockTracksRepository_GetOrCreateArtists_Call struct {
+	*mock.Call
+}
+
+// GetOrCreateArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - names []string
+func (_e *MockTracksRepository_Expecter) GetOrCreateArtists(ctx interface{}, tx interface{}, names interface{}) *MockTracksRepository_GetOrCreateArtists_Call {
+	return &MockTracksRepository_GetOrCreateArtists_Call{Call: _e.mock.On("GetOrCreateArtists", ctx, tx, names)}
+}
+
+func (_c *MockTracksRepository_GetOrCreateArtists_Call) Run(run func(ctx context.Context, tx pgx.Tx, names []string)) *MockTracksRepository_GetOrCreateArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_GetOrCreateArtists_Call) Return(ids map[string]int, err error) *MockTracksRepository_GetOrCreateArtists_Call {
+	_c.Call.Return(ids, err)
+	return _c
+}
+
+func (_c *MockTracksRepository_GetOrCreateArtists_Call) RunAndReturn(run func(context.Context, pgx.Tx, []string) (map[string]int, error)) *MockTracksRepository_GetOrCreateArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrackID provides a mock function with given fields: ctx, tx, title, artistID
+func (_m *MockTracksRepository) GetTrackID(ctx context.Context, tx pgx.Tx, title string, artistID int) (int, bool, error) {
+	ret := _m.Called(ctx, tx, title, artistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrackID")
+	}
+
+	var r0 int
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, string, int) (int, bool, error)); ok {
+		return rf(ctx, tx, title, artistID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, string, int) int); ok {
+		r0 = rf(ctx, tx, title, artistID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx, string, int) bool); ok {
+		r1 = rf(ctx, tx, title, artistID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, pgx.Tx, string, int) error); ok {
+		r2 = rf(ctx, tx, title, artistID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTracksRepository_GetTrackID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrackID'
+type MockTracksRepository_GetTrackID_Call struct {
+	*mock.Call
+}
+
+// GetTrackID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - title string
+//   - artistID int
+func (_e *MockTracksRepository_Expecter) GetTrackID(ctx interface{}, tx interface{}, title interface{}, artistID interface{}) *MockTracksRepository_GetTrackID_Call {
+	return &MockTracksRepository_GetTrackID_Call{Call: _e.mock.On("GetTrackID", ctx, tx, title, artistID)}
+}
+
+func (_c *MockTracksRepository_GetTrackID_Call) Run(run func(ctx context.Context, tx pgx.Tx, title string, artistID int)) *MockTracksRepository_GetTrackID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_GetTrackID_Call) Return(id int, exists bool, err error) *MockTracksRepository_GetTrackID_Call {
+	_c.Call.Return(id, exists, err)
+	return _c
+}
+
+func (_c *MockTracksRepository_GetTrackID_Call) RunAndReturn(run func(context.Context, pgx.Tx, string, int) (int, bool, error)) *MockTracksRepository_GetTrackID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTracksByFilter provides a mock function with given fields: ctx, tx, filter
-func (_m *MockTracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, filter entities.TrackGetListFilters) (map[int]entities.Track, error) {
+func (_m *MockTracksRepository) GetTracksByFilter(ctx context.Context, tx pgx.Tx, filter entities.TrackGetListFilters) ([]entities.Track, error) {
 	ret := _m.Called(ctx, tx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTracksByFilter")
 	}
 
-	var r0 map[int]entities.Track
+	var r0 []entities.Track
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, entities.TrackGetListFilters) (map[int]entities.Track, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, entities.TrackGetListFilters) ([]entities.Track, error)); ok {
 		return rf(ctx, tx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, entities.TrackGetListFilters) map[int]entities.Track); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, entities.TrackGetListFilters) []entities.Track); ok {
 		r0 = rf(ctx, tx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[int]entities.Track)
+			r0 = ret.Get(0).([]entities.Track)
 		}
 	}
 
@@ -618,12 +1029,12 @@ func (_c *MockTracksRepository_GetTracksByFilter_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockTracksRepository_GetTracksByFilter_Call) Return(tracks map[int]entities.Track, err error) *MockTracksRepository_GetTracksByFilter_Call {
+func (_c *MockTracksRepository_GetTracksByFilter_Call) Return(tracks []entities.Track, err error) *MockTracksRepository_GetTracksByFilter_Call {
 	_c.Call.Return(tracks, err)
 	return _c
 }
 
-func (_c *MockTracksRepository_GetTracksByFilter_Call) RunAndReturn(run func(context.Context, pgx.Tx, entities.TrackGetListFilters) (map[int]entities.Track, error)) *MockTracksRepository_GetTracksByFilter_Call {
+func (_c *MockTracksRepository_GetTracksByFilter_Call) RunAndReturn(run func(context.Context, pgx.Tx, entities.TrackGetListFilters) ([]entities.Track, error)) *MockTracksRepository_GetTracksByFilter_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -744,6 +1155,248 @@ func (_c *MockTracksRepository_IsTrackExists_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// SetLyricLanguage provides a mock function with given fields: ctx, tx, language
+func (_m *MockTracksRepository) SetLyricLanguage(ctx context.Context, tx pgx.Tx, language dao.LyricLanguage) error {
+	ret := _m.Called(ctx, tx, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLyricLanguage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, dao.LyricLanguage) error); ok {
+		r0 = rf(ctx, tx, language)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksRepository_SetLyricLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLyricLanguage'
+type MockTracksRepository_SetLyricLanguage_Call struct {
+	*mock.Call
+}
+
+// SetLyricLanguage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - language dao.LyricLanguage
+func (_e *MockTracksRepository_Expecter) SetLyricLanguage(ctx interface{}, tx interface{}, language interface{}) *MockTracksRepository_SetLyricLanguage_Call {
+	return &MockTracksRepository_SetLyricLanguage_Call{Call: _e.mock.On("SetLyricLanguage", ctx, tx, language)}
+}
+
+func (_c *MockTracksRepository_SetLyricLanguage_Call) Run(run func(ctx context.Context, tx pgx.Tx, language dao.LyricLanguage)) *MockTracksRepository_SetLyricLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(dao.LyricLanguage))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_SetLyricLanguage_Call) Return(err error) *MockTracksRepository_SetLyricLanguage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTracksRepository_SetLyricLanguage_Call) RunAndReturn(run func(context.Context, pgx.Tx, dao.LyricLanguage) error) *MockTracksRepository_SetLyricLanguage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLyricSignature provides a mock function with given fields: ctx, tx, signature
+func (_m *MockTracksRepository) SetLyricSignature(ctx context.Context, tx pgx.Tx, signature dao.LyricSignature) error {
+	ret := _m.Called(ctx, tx, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLyricSignature")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, dao.LyricSignature) error); ok {
+		r0 = rf(ctx, tx, signature)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksRepository_SetLyricSignature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLyricSignature'
+type MockTracksRepository_SetLyricSignature_Call struct {
+	*mock.Call
+}
+
+// SetLyricSignature is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - signature dao.LyricSignature
+func (_e *MockTracksRepository_Expecter) SetLyricSignature(ctx interface{}, tx interface{}, signature interface{}) *MockTracksRepository_SetLyricSignature_Call {
+	return &MockTracksRepository_SetLyricSignature_Call{Call: _e.mock.On("SetLyricSignature", ctx, tx, signature)}
+}
+
+func (_c *MockTracksRepository_SetLyricSignature_Call) Run(run func(ctx context.Context, tx pgx.Tx, signature dao.LyricSignature)) *MockTracksRepository_SetLyricSignature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(dao.LyricSignature))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_SetLyricSignature_Call) Return(err error) *MockTracksRepository_SetLyricSignature_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTracksRepository_SetLyricSignature_Call) RunAndReturn(run func(context.Context, pgx.Tx, dao.LyricSignature) error) *MockTracksRepository_SetLyricSignature_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrackAlbum provides a mock function with given fields: ctx, tx, trackID, albumID
+func (_m *MockTracksRepository) SetTrackAlbum(ctx context.Context, tx pgx.Tx, trackID int, albumID int) error {
+	ret := _m.Called(ctx, tx, trackID, albumID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTrackAlbum")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int) error); ok {
+		r0 = rf(ctx, tx, trackID, albumID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksRepository_SetTrackAlbum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrackAlbum'
+type MockTracksRepository_SetTrackAlbum_Call struct {
+	*mock.Call
+}
+
+// SetTrackAlbum is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - trackID int
+//   - albumID int
+func (_e *MockTracksRepository_Expecter) SetTrackAlbum(ctx interface{}, tx interface{}, trackID interface{}, albumID interface{}) *MockTracksRepository_SetTrackAlbum_Call {
+	return &MockTracksRepository_SetTrackAlbum_Call{Call: _e.mock.On("SetTrackAlbum", ctx, tx, trackID, albumID)}
+}
+
+func (_c *MockTracksRepository_SetTrackAlbum_Call) Run(run func(ctx context.Context, tx pgx.Tx, trackID int, albumID int)) *MockTracksRepository_SetTrackAlbum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_SetTrackAlbum_Call) Return(err error) *MockTracksRepository_SetTrackAlbum_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTracksRepository_SetTrackAlbum_Call) RunAndReturn(run func(context.Context, pgx.Tx, int, int) error) *MockTracksRepository_SetTrackAlbum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrackTags provides a mock function with given fields: ctx, trackID, tags
+func (_m *MockTracksRepository) SetTrackTags(ctx context.Context, trackID int, tags []string) error {
+	ret := _m.Called(ctx, trackID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTrackTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, trackID, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksRepository_SetTrackTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrackTags'
+type MockTracksRepository_SetTrackTags_Call struct {
+	*mock.Call
+}
+
+// SetTrackTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - trackID int
+//   - tags []string
+func (_e *MockTracksRepository_Expecter) SetTrackTags(ctx interface{}, trackID interface{}, tags interface{}) *MockTracksRepository_SetTrackTags_Call {
+	return &MockTracksRepository_SetTrackTags_Call{Call: _e.mock.On("SetTrackTags", ctx, trackID, tags)}
+}
+
+func (_c *MockTracksRepository_SetTrackTags_Call) Run(run func(ctx context.Context, trackID int, tags []string)) *MockTracksRepository_SetTrackTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_SetTrackTags_Call) Return(err error) *MockTracksRepository_SetTrackTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTracksRepository_SetTrackTags_Call) RunAndReturn(run func(context.Context, int, []string) error) *MockTracksRepository_SetTrackTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrackUpdatedBy provides a mock function with given fields: ctx, tx, trackID, userID
+func (_m *MockTracksRepository) SetTrackUpdatedBy(ctx context.Context, tx pgx.Tx, trackID int, userID int) error {
+	ret := _m.Called(ctx, tx, trackID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTrackUpdatedBy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, int, int) error); ok {
+		r0 = rf(ctx, tx, trackID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTracksRepository_SetTrackUpdatedBy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrackUpdatedBy'
+type MockTracksRepository_SetTrackUpdatedBy_Call struct {
+	*mock.Call
+}
+
+// SetTrackUpdatedBy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - trackID int
+//   - userID int
+func (_e *MockTracksRepository_Expecter) SetTrackUpdatedBy(ctx interface{}, tx interface{}, trackID interface{}, userID interface{}) *MockTracksRepository_SetTrackUpdatedBy_Call {
+	return &MockTracksRepository_SetTrackUpdatedBy_Call{Call: _e.mock.On("SetTrackUpdatedBy", ctx, tx, trackID, userID)}
+}
+
+func (_c *MockTracksRepository_SetTrackUpdatedBy_Call) Run(run func(ctx context.Context, tx pgx.Tx, trackID int, userID int)) *MockTracksRepository_SetTrackUpdatedBy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockTracksRepository_SetTrackUpdatedBy_Call) Return(err error) *MockTracksRepository_SetTrackUpdatedBy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTracksRepository_SetTrackUpdatedBy_Call) RunAndReturn(run func(context.Context, pgx.Tx, int, int) error) *MockTracksRepository_SetTrackUpdatedBy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateArtist provides a mock function with given fields: ctx, tx, artist
 func (_m *MockTracksRepository) UpdateArtist(ctx context.Context, tx pgx.Tx, artist dao.Artist) error {
 	ret := _m.Called(ctx, tx, artist)
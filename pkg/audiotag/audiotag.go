@@ -0,0 +1,85 @@
+// Package audiotag читает теги аудиофайлов (ID3v2 в MP3 и Vorbis comment во FLAC) без внешних зависимостей.
+package audiotag
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrNoTags = errors.New("no supported tags found")
+
+// Tags теги, которые нужны библиотеке.
+type Tags struct {
+	Artist string
+	Title  string
+	Album  string
+	// Year год выпуска, 0 если не указан.
+	Year   int
+	Lyrics string
+	// Picture встроенная обложка (предпочтительно front cover), nil если её нет.
+	Picture *Picture
+}
+
+// Picture изображение, встроенное в теги (кадр APIC в ID3v2, блок PICTURE во FLAC).
+type Picture struct {
+	MIMEType string
+	Data     []byte
+}
+
+// pictureTypeFrontCover тип изображения "Cover (front)", одинаковый в ID3v2 и FLAC.
+const pictureTypeFrontCover = 3
+
+// pictureSelector выбирает обложку среди нескольких изображений: первое попавшееся, но front cover в приоритете.
+type pictureSelector struct {
+	picture     *Picture
+	pictureType uint32
+}
+
+func (s *pictureSelector) add(pictureType uint32, picture Picture) {
+	if len(picture.Data) == 0 {
+		return
+	}
+	if s.picture == nil || (pictureType == pictureTypeFrontCover && s.pictureType != pictureTypeFrontCover) {
+		s.picture = &picture
+		s.pictureType = pictureType
+	}
+}
+
+// Read определяет формат по сигнатуре и читает теги.
+func Read(r io.ReadSeeker) (Tags, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return Tags{}, fmt.Errorf("failed to read signature: %w", err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return Tags{}, fmt.Errorf("failed to r.Seek: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, []byte("ID3")):
+		return readID3v2(r)
+	case bytes.Equal(magic, []byte("fLaC")):
+		return readFLAC(r)
+	default:
+		return Tags{}, ErrNoTags
+	}
+}
+
+// parseYear достаёт год из "2006", "2006-07-16" и т.п.
+func parseYear(value string) int {
+	value = strings.TrimSpace(value)
+	if len(value) < 4 {
+		return 0
+	}
+
+	year, err := strconv.Atoi(value[:4])
+	if err != nil {
+		return 0
+	}
+
+	return year
+}
@@ -0,0 +1,282 @@
+package audiotag_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/pkg/audiotag"
+	"github.com/stretchr/testify/assert"
+)
+
+func syncsafe(n int) []byte {
+	return []byte{byte(n>>21) & 0x7F, byte(n>>14) & 0x7F, byte(n>>7) & 0x7F, byte(n) & 0x7F}
+}
+
+func id3Frame(version byte, id string, data []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(id)
+	if version == 4 {
+		buf.Write(syncsafe(len(data)))
+	} else {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+	}
+	buf.Write([]byte{0, 0})
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func id3Tag(version byte, frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	body = append(body, make([]byte, 16)...) // padding
+
+	var buf bytes.Buffer
+	buf.WriteString("ID3")
+	buf.Write([]byte{version, 0, 0})
+	buf.Write(syncsafe(len(body)))
+	buf.Write(body)
+	buf.Write([]byte{0xFF, 0xFB, 0x90, 0x00}) // начало mp3 фрейма
+	return buf.Bytes()
+}
+
+func utf16le(s string) []byte {
+	out := []byte{0xFF, 0xFE}
+	for _, r := range s {
+		out = binary.LittleEndian.AppendUint16(out, uint16(r))
+	}
+	return out
+}
+
+func flacFile(comments ...string) []byte {
+	var block bytes.Buffer
+	vendor := "reference libFLAC 1.4.3"
+	_ = binary.Write(&block, binary.LittleEndian, uint32(len(vendor)))
+	block.WriteString(vendor)
+	_ = binary.Write(&block, binary.LittleEndian, uint32(len(comments)))
+	for _, c := range comments {
+		_ = binary.Write(&block, binary.LittleEndian, uint32(len(c)))
+		block.WriteString(c)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("fLaC")
+	// STREAMINFO (34 байта)
+	buf.Write([]byte{0x00, 0, 0, 34})
+	buf.Write(make([]byte, 34))
+	// VORBIS_COMMENT, последний блок
+	size := block.Len()
+	buf.Write([]byte{0x80 | 4, byte(size >> 16), byte(size >> 8), byte(size)})
+	buf.Write(block.Bytes())
+	return buf.Bytes()
+}
+
+// flacWithPicture дописывает блок PICTURE (front cover) последним блоком метаданных.
+func flacWithPicture(flac []byte, mime string, data []byte) []byte {
+	var block bytes.Buffer
+	_ = binary.Write(&block, binary.BigEndian, uint32(3))
+	_ = binary.Write(&block, binary.BigEndian, uint32(len(mime)))
+	block.WriteString(mime)
+	_ = binary.Write(&block, binary.BigEndian, uint32(0)) // описание
+	block.Write(make([]byte, 16))                         // ширина, высота, глубина, цвета
+	_ = binary.Write(&block, binary.BigEndian, uint32(len(data)))
+	block.Write(data)
+
+	// Блок Vorbis comment в flacFile последний - снимаем с него флаг.
+	out := bytes.Clone(flac)
+	out[4+4+34] &^= 0x80
+
+	size := block.Len()
+	out = append(out, 0x80|6, byte(size>>16), byte(size>>8), byte(size))
+	return append(out, block.Bytes()...)
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected audiotag.Tags
+		wantErr  bool
+	}{
+		{
+			"case: id3v2.3 latin1 and utf16 lyrics",
+			id3Tag(3,
+				id3Frame(3, "TPE1", append([]byte{0}, "Muse"...)),
+				id3Frame(3, "TIT2", append([]byte{0}, "Supermassive Black Hole"...)),
+				id3Frame(3, "TALB", append([]byte{0}, "Black Holes and Revelations"...)),
+				id3Frame(3, "TYER", append([]byte{0}, "2006"...)),
+				id3Frame(3, "USLT", append([]byte{1, 'e', 'n', 'g', 0xFF, 0xFE, 0, 0}, utf16le("Verse1\r\n\r\nVerse2")...)),
+			),
+			audiotag.Tags{
+				Artist: "Muse",
+				Title:  "Supermassive Black Hole",
+				Album:  "Black Holes and Revelations",
+				Year:   2006,
+				Lyrics: "Verse1\n\nVerse2",
+			},
+			false,
+		},
+		{
+			"case: id3v2.4 utf8",
+			id3Tag(4,
+				id3Frame(4, "TPE1", append([]byte{3}, "Кино"...)),
+				id3Frame(4, "TIT2", append([]byte{3}, "Кукушка"...)),
+				id3Frame(4, "TDRC", append([]byte{3}, "1990-01-01"...)),
+				id3Frame(4, "USLT", append([]byte{3, 'r', 'u', 's', 'd', 'e', 's', 'c', 0}, "Песен ещё ненаписанных"...)),
+			),
+			audiotag.Tags{
+				Artist: "Кино",
+				Title:  "Кукушка",
+				Year:   1990,
+				Lyrics: "Песен ещё ненаписанных",
+			},
+			false,
+		},
+		{
+			"case: flac vorbis comment",
+			flacFile("artist=Muse", "TITLE=Uprising", "ALBUM=The Resistance", "DATE=2009-09-07", "LYRICS=Paranoia is in bloom"),
+			audiotag.Tags{
+				Artist: "Muse",
+				Title:  "Uprising",
+				Album:  "The Resistance",
+				Year:   2009,
+				Lyrics: "Paranoia is in bloom",
+			},
+			false,
+		},
+		{
+			"case: id3v2.3 front cover preferred",
+			id3Tag(3,
+				id3Frame(3, "TPE1", append([]byte{0}, "Muse"...)),
+				id3Frame(3, "APIC", append([]byte("\x00image/png\x00\x04back\x00"), 0x89, 'P', 'N', 'G')),
+				id3Frame(3, "APIC", append([]byte("\x00image/jpg\x00\x03front\x00"), 0xFF, 0xD8, 0xFF)),
+			),
+			audiotag.Tags{
+				Artist:  "Muse",
+				Picture: &audiotag.Picture{MIMEType: "image/jpeg", Data: []byte{0xFF, 0xD8, 0xFF}},
+			},
+			false,
+		},
+		{
+			"case: flac picture block",
+			flacWithPicture(flacFile("ARTIST=Muse"), "image/png", []byte{0x89, 'P', 'N', 'G'}),
+			audiotag.Tags{
+				Artist:  "Muse",
+				Picture: &audiotag.Picture{MIMEType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}},
+			},
+			false,
+		},
+		{
+			"case: unknown format",
+			[]byte("RIFF....WAVE"),
+			audiotag.Tags{},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := audiotag.Read(bytes.NewReader(test.data))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func flacStreamInfo(sampleRate, totalSamples int64) []byte {
+	info := make([]byte, 34)
+	info[10] = byte(sampleRate >> 12)
+	info[11] = byte(sampleRate >> 4)
+	info[12] = byte(sampleRate<<4) | 0x02 // 2 канала
+	info[13] = 0xF0 | byte(totalSamples>>32)&0x0F
+	binary.BigEndian.PutUint32(info[14:18], uint32(totalSamples))
+
+	var buf bytes.Buffer
+	buf.WriteString("fLaC")
+	buf.Write([]byte{0x80, 0, 0, 34})
+	buf.Write(info)
+	return buf.Bytes()
+}
+
+// mp3CBR MPEG1 Layer III 128 кбит/с 44.1 кГц, stereo.
+func mp3CBR(audioSize int) []byte {
+	data := make([]byte, audioSize)
+	copy(data, []byte{0xFF, 0xFB, 0x90, 0x00})
+	return data
+}
+
+// withID3 добавляет перед аудиоданными ID3v2 тег.
+func withID3(audio []byte) []byte {
+	tag := id3Tag(3, id3Frame(3, "TIT2", append([]byte{0}, "Uprising"...)))
+	return append(tag[:len(tag)-4], audio...) // без начала mp3 фрейма из id3Tag
+}
+
+// mp3Xing первый фрейм с заголовком Xing и количеством фреймов.
+func mp3Xing(frames uint32) []byte {
+	data := make([]byte, 417)
+	copy(data, []byte{0xFF, 0xFB, 0x90, 0x00})
+	copy(data[36:], "Xing")
+	binary.BigEndian.PutUint32(data[40:], 0x01)
+	binary.BigEndian.PutUint32(data[44:], frames)
+	return data
+}
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected time.Duration
+		wantErr  bool
+	}{
+		{
+			"case: flac streaminfo",
+			flacStreamInfo(44100, 441000*3),
+			30 * time.Second,
+			false,
+		},
+		{
+			"case: mp3 cbr with id3v2",
+			withID3(mp3CBR(16000 * 5)),
+			5 * time.Second,
+			false,
+		},
+		{
+			"case: mp3 vbr xing",
+			mp3Xing(1000),
+			time.Duration(1000 * 1152 * int64(time.Second) / 44100),
+			false,
+		},
+		{
+			"case: unknown format",
+			[]byte("RIFF....WAVE"),
+			0,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := audiotag.Duration(bytes.NewReader(test.data))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package audiotag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported audio format")
+
+// mp3SyncSearchLimit сколько байт после ID3v2 просматривается в поисках первого MPEG фрейма.
+const mp3SyncSearchLimit = 64 << 10
+
+// Битрейты (кбит/с) по индексу из заголовка MPEG фрейма.
+var (
+	mpeg1Bitrates = [3][16]int{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0}, // Layer I
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},    // Layer II
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},     // Layer III
+	}
+	mpeg2Bitrates = [3][16]int{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0}, // Layer I
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},      // Layer II
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},      // Layer III
+	}
+	mpeg1SampleRates = [3]int{44100, 48000, 32000}
+)
+
+// Duration определяет длительность MP3 (по заголовку Xing/Info/VBRI или битрейту CBR) или FLAC (по STREAMINFO).
+func Duration(r io.ReadSeeker) (time.Duration, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return 0, fmt.Errorf("failed to read signature: %w", err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to r.Seek: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(magic, []byte("fLaC")):
+		return flacDuration(r)
+	case bytes.HasPrefix(magic, []byte("ID3")), magic[0] == 0xFF && magic[1]&0xE0 == 0xE0:
+		return mp3Duration(r)
+	default:
+		return 0, ErrUnsupportedFormat
+	}
+}
+
+func flacDuration(r io.Reader) (time.Duration, error) {
+	// "fLaC" + заголовок блока + STREAMINFO, который по спецификации всегда идёт первым.
+	data := make([]byte, 4+4+34)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, fmt.Errorf("failed to read STREAMINFO: %w", err)
+	}
+	if data[4]&^flacLastBlockFlag != 0 {
+		return 0, fmt.Errorf("%w: STREAMINFO block expected", ErrUnsupportedFormat)
+	}
+
+	info := data[8:]
+	sampleRate := int64(info[10])<<12 | int64(info[11])<<4 | int64(info[12])>>4
+	totalSamples := int64(info[13]&0x0F)<<32 | int64(binary.BigEndian.Uint32(info[14:18]))
+
+	if sampleRate == 0 {
+		return 0, fmt.Errorf("%w: invalid sample rate", ErrUnsupportedFormat)
+	}
+
+	return time.Duration(totalSamples * int64(time.Second) / sampleRate), nil
+}
+
+type mpegFrame struct {
+	version    int // 1, 2 или 25 (MPEG 2.5)
+	layer      int
+	bitrate    int // кбит/с
+	sampleRate int
+	mono       bool
+}
+
+func (f mpegFrame) samplesPerFrame() int {
+	switch {
+	case f.layer == 1:
+		return 384
+	case f.layer == 3 && f.version != 1:
+		return 576
+	default:
+		return 1152
+	}
+}
+
+// sideInfoSize размер side information Layer III, после неё лежит заголовок Xing/Info.
+func (f mpegFrame) sideInfoSize() int {
+	switch {
+	case f.version == 1 && f.mono:
+		return 17
+	case f.version == 1:
+		return 32
+	case f.mono:
+		return 9
+	default:
+		return 17
+	}
+}
+
+func parseMPEGFrame(h []byte) (frame mpegFrame, ok bool) {
+	if h[0] != 0xFF || h[1]&0xE0 != 0xE0 {
+		return frame, false
+	}
+
+	switch (h[1] >> 3) & 0x03 {
+	case 0:
+		frame.version = 25
+	case 2:
+		frame.version = 2
+	case 3:
+		frame.version = 1
+	default:
+		return frame, false
+	}
+
+	frame.layer = 4 - int((h[1]>>1)&0x03)
+	if frame.layer == 4 {
+		return frame, false
+	}
+
+	bitrateIdx, sampleRateIdx := h[2]>>4, (h[2]>>2)&0x03
+	if sampleRateIdx == 3 {
+		return frame, false
+	}
+
+	if frame.version == 1 {
+		frame.bitrate = mpeg1Bitrates[frame.layer-1][bitrateIdx]
+	} else {
+		frame.bitrate = mpeg2Bitrates[frame.layer-1][bitrateIdx]
+	}
+	if frame.bitrate == 0 {
+		return frame, false
+	}
+
+	frame.sampleRate = mpeg1SampleRates[sampleRateIdx]
+	switch frame.version {
+	case 2:
+		frame.sampleRate /= 2
+	case 25:
+		frame.sampleRate /= 4
+	}
+
+	frame.mono = h[3]>>6 == 0x03
+
+	return frame, true
+}
+
+func mp3Duration(r io.ReadSeeker) (time.Duration, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to r.Seek: %w", err)
+	}
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to r.Seek: %w", err)
+	}
+
+	var start int64
+
+	header := make([]byte, id3HeaderSize)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return 0, fmt.Errorf("failed to read header: %w", err)
+	}
+	if bytes.HasPrefix(header, []byte("ID3")) {
+		start = id3HeaderSize + int64(syncsafe(header[6:10]))
+		// Флаг footer добавляет ещё 10 байт в конце тега.
+		if header[5]&0x10 != 0 {
+			start += id3HeaderSize
+		}
+	}
+
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to r.Seek: %w", err)
+	}
+
+	buf := make([]byte, mp3SyncSearchLimit)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, fmt.Errorf("failed to read audio data: %w", err)
+	}
+	buf = buf[:n]
+
+	for i := 0; i+4 <= len(buf); i++ {
+		frame, ok := parseMPEGFrame(buf[i : i+4])
+		if !ok {
+			continue
+		}
+
+		if frames, ok := vbrFrameCount(buf[i:], frame); ok {
+			samples := int64(frames) * int64(frame.samplesPerFrame())
+			return time.Duration(samples * int64(time.Second) / int64(frame.sampleRate)), nil
+		}
+
+		// CBR: длительность по размеру аудиоданных, без ID3v1 тега в конце.
+		audioSize := size - start - int64(i)
+		if hasID3v1(r, size) {
+			audioSize -= 128
+		}
+
+		return time.Duration(audioSize * 8 * int64(time.Second) / (int64(frame.bitrate) * 1000)), nil
+	}
+
+	return 0, fmt.Errorf("%w: MPEG frame not found", ErrUnsupportedFormat)
+}
+
+// vbrFrameCount возвращает количество фреймов из заголовка Xing/Info или VBRI первого фрейма.
+func vbrFrameCount(data []byte, frame mpegFrame) (frames uint32, ok bool) {
+	xing := 4 + frame.sideInfoSize()
+	if len(data) >= xing+12 {
+		tag := string(data[xing : xing+4])
+		flags := binary.BigEndian.Uint32(data[xing+4 : xing+8])
+		if (tag == "Xing" || tag == "Info") && flags&0x01 != 0 {
+			return binary.BigEndian.Uint32(data[xing+8 : xing+12]), true
+		}
+	}
+
+	const vbri = 4 + 32
+	if len(data) >= vbri+18 && string(data[vbri:vbri+4]) == "VBRI" {
+		return binary.BigEndian.Uint32(data[vbri+14 : vbri+18]), true
+	}
+
+	return 0, false
+}
+
+func hasID3v1(r io.ReadSeeker, size int64) bool {
+	if size < 128 {
+		return false
+	}
+	if _, err := r.Seek(size-128, io.SeekStart); err != nil {
+		return false
+	}
+
+	tag := make([]byte, 3)
+	if _, err := io.ReadFull(r, tag); err != nil {
+		return false
+	}
+
+	return string(tag) == "TAG"
+}
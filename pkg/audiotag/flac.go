@@ -0,0 +1,181 @@
+package audiotag
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	flacBlockVorbisComment = 4
+	flacBlockPicture       = 6
+
+	flacLastBlockFlag = 0x80
+)
+
+var errInvalidVorbisComment = errors.New("invalid vorbis comment block")
+
+func readFLAC(r io.Reader) (tags Tags, err error) {
+	if _, err = io.CopyN(io.Discard, r, 4); err != nil {
+		return Tags{}, fmt.Errorf("failed to skip fLaC marker: %w", err)
+	}
+
+	var (
+		found    bool
+		pictures pictureSelector
+		header   = make([]byte, 4)
+	)
+
+	for {
+		if _, err = io.ReadFull(r, header); err != nil {
+			return Tags{}, fmt.Errorf("failed to read metadata block header: %w", err)
+		}
+
+		blockType := header[0] &^ flacLastBlockFlag
+		size := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+
+		switch blockType {
+		case flacBlockVorbisComment, flacBlockPicture:
+			block := make([]byte, size)
+			if _, err = io.ReadFull(r, block); err != nil {
+				return Tags{}, fmt.Errorf("failed to read metadata block: %w", err)
+			}
+
+			if blockType == flacBlockVorbisComment {
+				if tags, err = parseVorbisComment(block); err != nil {
+					return Tags{}, err
+				}
+				found = true
+			} else if pictureType, picture, ok := parseFLACPicture(block); ok {
+				pictures.add(pictureType, picture)
+			}
+		default:
+			if _, err = io.CopyN(io.Discard, r, size); err != nil {
+				return Tags{}, fmt.Errorf("failed to skip metadata block: %w", err)
+			}
+		}
+
+		if header[0]&flacLastBlockFlag != 0 {
+			break
+		}
+	}
+
+	if !found && pictures.picture == nil {
+		return Tags{}, ErrNoTags
+	}
+
+	tags.Picture = pictures.picture
+
+	return tags, nil
+}
+
+// parseFLACPicture разбирает блок PICTURE (все числа big-endian):
+// тип, MIME, описание, ширина, высота, глубина цвета, количество цветов, данные.
+func parseFLACPicture(block []byte) (pictureType uint32, picture Picture, ok bool) {
+	next := func() ([]byte, bool) {
+		if len(block) < 4 {
+			return nil, false
+		}
+		size := binary.BigEndian.Uint32(block[:4])
+		if uint64(size) > uint64(len(block)-4) {
+			return nil, false
+		}
+		value := block[4 : 4+size]
+		block = block[4+size:]
+		return value, true
+	}
+
+	if len(block) < 4 {
+		return 0, Picture{}, false
+	}
+	pictureType, block = binary.BigEndian.Uint32(block[:4]), block[4:]
+
+	mime, ok := next()
+	if !ok {
+		return 0, Picture{}, false
+	}
+	if _, ok = next(); !ok { // описание
+		return 0, Picture{}, false
+	}
+	if len(block) < 16 {
+		return 0, Picture{}, false
+	}
+	block = block[16:]
+
+	if picture.Data, ok = next(); !ok || len(picture.Data) == 0 {
+		return 0, Picture{}, false
+	}
+	picture.MIMEType = normalizePictureMIME(string(mime))
+
+	return pictureType, picture, true
+}
+
+// parseVorbisComment разбирает блок Vorbis comment (все числа little-endian).
+func parseVorbisComment(block []byte) (tags Tags, err error) {
+	next := func() ([]byte, error) {
+		if len(block) < 4 {
+			return nil, errInvalidVorbisComment
+		}
+		size := binary.LittleEndian.Uint32(block[:4])
+		if uint64(size) > uint64(len(block)-4) {
+			return nil, errInvalidVorbisComment
+		}
+		value := block[4 : 4+size]
+		block = block[4+size:]
+		return value, nil
+	}
+
+	// vendor string
+	if _, err = next(); err != nil {
+		return Tags{}, err
+	}
+
+	if len(block) < 4 {
+		return Tags{}, errInvalidVorbisComment
+	}
+	count := binary.LittleEndian.Uint32(block[:4])
+	block = block[4:]
+
+	for i := uint32(0); i < count; i++ {
+		comment, err := next()
+		if err != nil {
+			return Tags{}, err
+		}
+
+		key, value, ok := strings.Cut(string(comment), "=")
+		if !ok {
+			continue
+		}
+
+		// Ключи регистронезависимы, повторяющиеся значения (несколько ARTIST) объединяются.
+		switch strings.ToUpper(key) {
+		case "ARTIST":
+			tags.Artist = appendValue(tags.Artist, value)
+		case "TITLE":
+			tags.Title = appendValue(tags.Title, value)
+		case "ALBUM":
+			tags.Album = appendValue(tags.Album, value)
+		case "DATE", "YEAR", "ORIGINALDATE":
+			if tags.Year == 0 {
+				tags.Year = parseYear(value)
+			}
+		case "LYRICS", "UNSYNCEDLYRICS":
+			if tags.Lyrics == "" {
+				tags.Lyrics = strings.TrimSpace(normalizeNewlines(value))
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+func appendValue(current, value string) string {
+	value = strings.TrimSpace(value)
+	if current == "" {
+		return value
+	}
+
+	return current + "/" + value
+}
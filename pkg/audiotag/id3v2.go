@@ -0,0 +1,335 @@
+package audiotag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	id3HeaderSize = 10
+
+	id3FlagUnsynchronisation = 0x80
+	id3FlagExtendedHeader    = 0x40
+
+	// id3FrameFlagUnsync флаг unsynchronisation кадра в ID3v2.4.
+	id3FrameFlagUnsync = 0x02
+	// id3FrameFlagDataLength флаг наличия 4-байтового data length indicator в ID3v2.4.
+	id3FrameFlagDataLength = 0x01
+)
+
+// Кодировки текста в кадрах ID3v2.
+const (
+	encodingISO88591 = 0
+	encodingUTF16    = 1
+	encodingUTF16BE  = 2
+	encodingUTF8     = 3
+)
+
+var errInvalidID3 = errors.New("invalid ID3v2 tag")
+
+// id3Frames соответствие идентификаторов кадров ID3v2.2 (3 символа) и v2.3/2.4 (4 символа).
+var id3v22Frames = map[string]string{
+	"TP1": "TPE1",
+	"TT2": "TIT2",
+	"TAL": "TALB",
+	"TYE": "TYER",
+	"ULT": "USLT",
+	"PIC": "PIC",
+}
+
+func readID3v2(r io.Reader) (tags Tags, err error) {
+	header := make([]byte, id3HeaderSize)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return Tags{}, fmt.Errorf("failed to read ID3v2 header: %w", err)
+	}
+
+	version, flags := header[3], header[5]
+	if version < 2 || version > 4 {
+		return Tags{}, fmt.Errorf("%w: unsupported version 2.%d", errInvalidID3, version)
+	}
+
+	body := make([]byte, syncsafe(header[6:10]))
+	if _, err = io.ReadFull(r, body); err != nil {
+		return Tags{}, fmt.Errorf("failed to read ID3v2 body: %w", err)
+	}
+
+	// В v2.2/v2.3 unsynchronisation применяется ко всему тегу, в v2.4 - к отдельным кадрам.
+	if flags&id3FlagUnsynchronisation != 0 && version < 4 {
+		body = removeUnsync(body)
+	}
+
+	if flags&id3FlagExtendedHeader != 0 && version >= 3 {
+		if body, err = skipExtendedHeader(body, version); err != nil {
+			return Tags{}, err
+		}
+	}
+
+	var pictures pictureSelector
+
+	for len(body) > 0 {
+		id, data, rest, ok := nextID3Frame(body, version)
+		if !ok {
+			break
+		}
+		body = rest
+
+		switch id {
+		case "TPE1":
+			tags.Artist = decodeText(data)
+		case "TIT2":
+			tags.Title = decodeText(data)
+		case "TALB":
+			tags.Album = decodeText(data)
+		case "TYER", "TDRC", "TORY", "TDOR":
+			if tags.Year == 0 {
+				tags.Year = parseYear(decodeText(data))
+			}
+		case "USLT":
+			if tags.Lyrics == "" {
+				tags.Lyrics = decodeUSLT(data)
+			}
+		case "APIC", "PIC":
+			if pictureType, picture, ok := decodeAPIC(data, id == "PIC"); ok {
+				pictures.add(pictureType, picture)
+			}
+		}
+	}
+
+	tags.Picture = pictures.picture
+
+	return tags, nil
+}
+
+// nextID3Frame возвращает очередной кадр, ok = false на padding или повреждённых данных.
+func nextID3Frame(body []byte, version byte) (id string, data []byte, rest []byte, ok bool) {
+	headerSize := 10
+	if version == 2 {
+		headerSize = 6
+	}
+
+	if len(body) < headerSize || body[0] == 0 {
+		return "", nil, nil, false
+	}
+
+	var (
+		size  int
+		flags byte
+	)
+
+	switch version {
+	case 2:
+		id = id3v22Frames[string(body[:3])]
+		size = int(body[3])<<16 | int(body[4])<<8 | int(body[5])
+	case 3:
+		id = string(body[:4])
+		size = int(binary.BigEndian.Uint32(body[4:8]))
+		flags = body[9]
+	default:
+		id = string(body[:4])
+		size = syncsafe(body[4:8])
+		flags = body[9]
+	}
+
+	if size < 0 || headerSize+size > len(body) {
+		return "", nil, nil, false
+	}
+
+	data = body[headerSize : headerSize+size]
+	rest = body[headerSize+size:]
+
+	if version == 4 {
+		if flags&id3FrameFlagDataLength != 0 && len(data) >= 4 {
+			data = data[4:]
+		}
+		if flags&id3FrameFlagUnsync != 0 {
+			data = removeUnsync(data)
+		}
+	}
+
+	return id, data, rest, true
+}
+
+func skipExtendedHeader(body []byte, version byte) ([]byte, error) {
+	if len(body) < 4 {
+		return nil, fmt.Errorf("%w: truncated extended header", errInvalidID3)
+	}
+
+	// В v2.3 размер не включает 4 байта самого поля размера, в v2.4 - включает.
+	size := int(binary.BigEndian.Uint32(body[:4])) + 4
+	if version == 4 {
+		size = syncsafe(body[:4])
+	}
+
+	if size > len(body) {
+		return nil, fmt.Errorf("%w: truncated extended header", errInvalidID3)
+	}
+
+	return body[size:], nil
+}
+
+// decodeText декодирует текстовый кадр (первый байт - кодировка), значения через \x00 объединяются через "/".
+func decodeText(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	text := decodeString(data[0], data[1:])
+	parts := strings.Split(strings.TrimRight(text, "\x00"), "\x00")
+
+	return strings.TrimSpace(strings.Join(parts, "/"))
+}
+
+// decodeUSLT декодирует кадр USLT: кодировка, язык (3 байта), описание (до терминатора), текст.
+func decodeUSLT(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+
+	encoding, content := data[0], data[4:]
+
+	_, text := splitTerminated(encoding, content)
+
+	return strings.TrimSpace(normalizeNewlines(strings.TrimRight(decodeString(encoding, text), "\x00")))
+}
+
+// decodeAPIC декодирует кадр APIC: кодировка, MIME (в v2.2 - формат из 3 символов), тип, описание, данные.
+func decodeAPIC(data []byte, v22 bool) (pictureType uint32, picture Picture, ok bool) {
+	if len(data) < 2 {
+		return 0, Picture{}, false
+	}
+
+	encoding, rest := data[0], data[1:]
+
+	if v22 {
+		if len(rest) < 3 {
+			return 0, Picture{}, false
+		}
+		switch strings.ToUpper(string(rest[:3])) {
+		case "PNG":
+			picture.MIMEType = "image/png"
+		default:
+			picture.MIMEType = "image/jpeg"
+		}
+		rest = rest[3:]
+	} else {
+		var mime []byte
+		mime, rest = splitTerminated(encodingISO88591, rest)
+		picture.MIMEType = normalizePictureMIME(string(mime))
+	}
+
+	if len(rest) < 1 {
+		return 0, Picture{}, false
+	}
+	pictureType, rest = uint32(rest[0]), rest[1:]
+
+	_, picture.Data = splitTerminated(encoding, rest)
+
+	return pictureType, picture, len(picture.Data) > 0
+}
+
+// normalizePictureMIME приводит встречающиеся в тегах варианты ("jpg", "image/jpg", "") к MIME типу.
+func normalizePictureMIME(mime string) string {
+	switch strings.ToLower(strings.TrimSpace(mime)) {
+	case "image/png", "png":
+		return "image/png"
+	case "image/jpeg", "image/jpg", "jpeg", "jpg", "":
+		return "image/jpeg"
+	default:
+		return strings.ToLower(strings.TrimSpace(mime))
+	}
+}
+
+// splitTerminated отделяет строку до терминатора (один или два нулевых байта в зависимости от кодировки).
+func splitTerminated(encoding byte, data []byte) (head []byte, tail []byte) {
+	if encoding == encodingUTF16 || encoding == encodingUTF16BE {
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				return data[:i], data[i+2:]
+			}
+		}
+		return data, nil
+	}
+
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return data[:i], data[i+1:]
+	}
+
+	return data, nil
+}
+
+func decodeString(encoding byte, data []byte) string {
+	switch encoding {
+	case encodingUTF16:
+		return decodeUTF16(data, nil)
+	case encodingUTF16BE:
+		return decodeUTF16(data, binary.BigEndian)
+	case encodingUTF8:
+		return string(data)
+	default:
+		return decodeLatin1(data)
+	}
+}
+
+// decodeUTF16 декодирует UTF-16, порядок байт берётся из BOM, если order не задан.
+// Несколько строк с BOM, разделённых нулями, тоже поддерживаются.
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	var (
+		units []uint16
+		bo    = order
+	)
+
+	for i := 0; i+1 < len(data); i += 2 {
+		switch {
+		case order == nil && data[i] == 0xFF && data[i+1] == 0xFE:
+			bo = binary.LittleEndian
+			continue
+		case order == nil && data[i] == 0xFE && data[i+1] == 0xFF:
+			bo = binary.BigEndian
+			continue
+		}
+
+		if bo == nil {
+			bo = binary.LittleEndian
+		}
+		units = append(units, bo.Uint16(data[i:i+2]))
+	}
+
+	return string(utf16.Decode(units))
+}
+
+func decodeLatin1(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
+
+func normalizeNewlines(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "\n")
+}
+
+// syncsafe декодирует 28-битное syncsafe число.
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7F)<<21 | int(b[1]&0x7F)<<14 | int(b[2]&0x7F)<<7 | int(b[3]&0x7F)
+}
+
+// removeUnsync убирает байты 0x00, вставленные после 0xFF при unsynchronisation.
+func removeUnsync(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		out = append(out, data[i])
+		if data[i] == 0xFF && i+1 < len(data) && data[i+1] == 0x00 {
+			i++
+		}
+	}
+
+	return out
+}
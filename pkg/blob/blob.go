@@ -0,0 +1,33 @@
+// Package blob хранилище бинарных объектов (аудиофайлов и т.п.) по строковому ключу.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Object метаданные объекта. ETag заполняется только в результате Put.
+type Object struct {
+	Key     string
+	Size    int64
+	ETag    string
+	ModTime time.Time
+}
+
+// File открытый на чтение объект, поддерживает Seek для отдачи по Range.
+type File interface {
+	io.ReadSeekCloser
+}
+
+type Store interface {
+	// Put атомарно сохраняет объект: читатели видят либо старую, либо новую версию целиком.
+	Put(ctx context.Context, key string, r io.Reader) (Object, error)
+	Open(ctx context.Context, key string) (File, Object, error)
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix удаляет все объекты, ключ которых начинается с prefix + "/".
+	DeletePrefix(ctx context.Context, prefix string) error
+}
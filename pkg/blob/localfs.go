@@ -0,0 +1,159 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// LocalFS хранит объекты файлами в каталоге root, ключ "a/b/c" соответствует файлу root/a/b/c.
+type LocalFS struct {
+	root string
+}
+
+func NewLocalFS(root string) (*LocalFS, error) {
+	if err := os.MkdirAll(root, dirPerm); err != nil {
+		return nil, fmt.Errorf("failed to os.MkdirAll(%s): %w", root, err)
+	}
+
+	return &LocalFS{root: root}, nil
+}
+
+// Put пишет объект во временный файл рядом с целевым и переименовывает его, ETag - sha256 содержимого.
+func (s *LocalFS) Put(ctx context.Context, key string, r io.Reader) (object Object, err error) {
+	name, err := s.path(key)
+	if err != nil {
+		return Object{}, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), dirPerm); err != nil {
+		return Object{}, fmt.Errorf("failed to os.MkdirAll: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to os.CreateTemp: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	hash := sha256.New()
+	if object.Size, err = io.Copy(io.MultiWriter(tmp, hash), contextReader{ctx: ctx, r: r}); err != nil {
+		return Object{}, fmt.Errorf("failed to write blob %s: %w", key, err)
+	}
+
+	if err = tmp.Chmod(filePerm); err != nil {
+		return Object{}, fmt.Errorf("failed to tmp.Chmod: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		return Object{}, fmt.Errorf("failed to tmp.Sync: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return Object{}, fmt.Errorf("failed to tmp.Close: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), name); err != nil {
+		return Object{}, fmt.Errorf("failed to os.Rename: %w", err)
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to os.Stat: %w", err)
+	}
+
+	object.Key = key
+	object.ETag = hex.EncodeToString(hash.Sum(nil))
+	object.ModTime = info.ModTime()
+
+	return object, nil
+}
+
+func (s *LocalFS) Open(_ context.Context, key string) (File, Object, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, Object{}, ErrNotFound
+		}
+		return nil, Object{}, fmt.Errorf("failed to os.Open: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, Object{}, fmt.Errorf("failed to file.Stat: %w", err)
+	}
+
+	return file, Object{Key: key, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Delete удаляет объект, отсутствие объекта ошибкой не считается.
+func (s *LocalFS) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to os.Remove: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalFS) DeletePrefix(_ context.Context, prefix string) error {
+	name, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(name); err != nil {
+		return fmt.Errorf("failed to os.RemoveAll: %w", err)
+	}
+
+	return nil
+}
+
+// path переводит ключ в путь внутри root, не позволяя выйти за его пределы.
+func (s *LocalFS) path(key string) (string, error) {
+	clean := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if clean == "" || clean != key {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
+
+// contextReader прерывает копирование при отмене контекста.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.r.Read(p)
+}
@@ -0,0 +1,145 @@
+package blob_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neyrzx/youmusic/pkg/blob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalFSKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		key   string
+		valid bool
+	}{
+		{"case: nested key", "covers/1/original.jpg", true},
+		{"case: single segment", "object", true},
+		{"case: empty", "", false},
+		{"case: parent", "..", false},
+		{"case: escape root", "../secret", false},
+		{"case: escape through nested", "covers/../../secret", false},
+		{"case: dot dot inside", "covers/../secret", false},
+		{"case: absolute", "/secret", false},
+		{"case: dot segment", "./secret", false},
+		{"case: double slash", "covers//secret", false},
+		{"case: trailing slash", "covers/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			store, err := blob.NewLocalFS(filepath.Join(dir, "root"))
+			require.NoError(t, err)
+
+			ctx := context.Background()
+
+			_, err = store.Put(ctx, tt.key, strings.NewReader("data"))
+			_, _, openErr := store.Open(ctx, tt.key)
+			deleteErr := store.Delete(ctx, tt.key)
+
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.NoError(t, openErr)
+				assert.NoError(t, deleteErr)
+				return
+			}
+
+			assert.Error(t, err)
+			assert.Error(t, openErr)
+			assert.NotErrorIs(t, openErr, blob.ErrNotFound)
+			assert.Error(t, deleteErr)
+			assert.Error(t, store.DeletePrefix(ctx, tt.key))
+			assert.NoFileExists(t, filepath.Join(dir, "secret"))
+		})
+	}
+}
+
+// failingReader отдаёт часть данных и возвращает ошибку.
+type failingReader struct {
+	data string
+	read bool
+}
+
+var errRead = errors.New("read failed")
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, errRead
+	}
+	r.read = true
+
+	return copy(p, r.data), nil
+}
+
+func TestLocalFSPut(t *testing.T) {
+	t.Parallel()
+
+	const key = "covers/1/original.jpg"
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		reader        io.Reader
+		expectedErr   error
+		expectedValue string
+	}{
+		{"case: replaces object", context.Background(), strings.NewReader("new"), nil, "new"},
+		{"case: failed write keeps object", context.Background(), &failingReader{data: "partial"}, errRead, "old"},
+		{"case: cancelled write keeps object", cancelled, strings.NewReader("new"), context.Canceled, "old"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			store, err := blob.NewLocalFS(root)
+			require.NoError(t, err)
+
+			_, err = store.Put(context.Background(), key, strings.NewReader("old"))
+			require.NoError(t, err)
+
+			object, err := store.Put(tt.ctx, key, tt.reader)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				sum := sha256.Sum256([]byte(tt.expectedValue))
+				assert.Equal(t, hex.EncodeToString(sum[:]), object.ETag)
+				assert.Equal(t, int64(len(tt.expectedValue)), object.Size)
+				assert.Equal(t, key, object.Key)
+			}
+
+			file, _, err := store.Open(context.Background(), key)
+			require.NoError(t, err)
+			defer file.Close()
+
+			data, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, string(data))
+
+			// Временные файлы не остаются ни после успешной, ни после прерванной записи.
+			entries, err := os.ReadDir(filepath.Join(root, "covers", "1"))
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			assert.Equal(t, "original.jpg", entries[0].Name())
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/neyrzx/youmusic/internal/config"
 	"github.com/neyrzx/youmusic/pkg/logger"
@@ -21,15 +22,39 @@ const (
 
 type HTTPClient struct {
 	logger         *zerolog.Logger
+	client         *http.Client
 	retryStrategy  retry.Strategy
 	badStatusCodes []int
+
+	attemptTimeout time.Duration
+	hedgingEnabled bool
+	hedgingDelay   time.Duration
+	latencies      *latencyWindow
+}
+
+// Option настраивает HTTPClient.
+type Option func(*HTTPClient)
+
+// WithTransport подменяет транспорт, через который выполняются запросы (например, Recorder в тестах).
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *HTTPClient) {
+		c.client = &http.Client{Transport: transport}
+	}
+}
+
+// WithHTTPClient подменяет *http.Client, по умолчанию используется http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *HTTPClient) {
+		c.client = client
+	}
 }
 
-func NewHTTPClient(cfg config.GatewayHTTPClient) *HTTPClient {
+func NewHTTPClient(cfg config.GatewayHTTPClient, opts ...Option) *HTTPClient {
 	logger := logger.DefaultLogger().With().Str(packageKey, packageName).Logger()
 
-	return &HTTPClient{
+	c := &HTTPClient{
 		logger: &logger,
+		client: http.DefaultClient,
 		retryStrategy: retry.Strategy{
 			Delay:       cfg.RetryStratagyDelay,
 			MaxDelay:    cfg.RetryStrategyMaxDelay,
@@ -37,9 +62,23 @@ func NewHTTPClient(cfg config.GatewayHTTPClient) *HTTPClient {
 			Factor:      cfg.RetryStrategyFactor,
 		},
 		badStatusCodes: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		attemptTimeout: cfg.AttemptTimeout,
+		hedgingEnabled: cfg.HedgingEnabled,
+		hedgingDelay:   cfg.HedgingDelay,
+		latencies:      newLatencyWindow(),
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
+// Get выполняет GET запрос с ретраями.
+//
+// Общий бюджет времени задаётся контекстом: по его истечении ретраи прекращаются,
+// каждая попытка дополнительно ограничена attemptTimeout.
 func (c *HTTPClient) Get(ctx context.Context, url string) (io.ReadCloser, error) {
 	var (
 		body []byte
@@ -47,13 +86,17 @@ func (c *HTTPClient) Get(ctx context.Context, url string) (io.ReadCloser, error)
 	)
 
 	for i := c.retryStrategy.Start(); ; {
-		if body, err = c.get(ctx, url); err == nil {
+		if body, err = c.attempt(ctx, url); err == nil {
 			break
 		}
 
 		c.logger.Err(err).Msg("failed getting response")
 
-		if !i.Next(nil) {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to getting response from %s after %d tries: %w", url, i.Count(), ctx.Err())
+		}
+
+		if !i.Next(ctx.Done()) {
 			return nil, fmt.Errorf("failed to getting response from %s after %d tries: %w", url, i.Count(), err)
 		}
 	}
@@ -61,18 +104,81 @@ func (c *HTTPClient) Get(ctx context.Context, url string) (io.ReadCloser, error)
 	return io.NopCloser(bytes.NewBuffer(body)), nil
 }
 
+type attemptResult struct {
+	body []byte
+	err  error
+}
+
+// attempt выполняет одну попытку. При включённом хеджировании, если ответ не пришёл за hedgeDelay,
+// отправляется второй запрос и берётся первый успешный ответ.
+func (c *HTTPClient) attempt(ctx context.Context, url string) ([]byte, error) {
+	if !c.hedgingEnabled {
+		return c.get(ctx, url)
+	}
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	// Буфер на оба запроса, чтобы проигравшая горутина не зависла на отправке.
+	results := make(chan attemptResult, 2)
+	send := func() {
+		go func() {
+			body, err := c.get(ctx, url)
+			results <- attemptResult{body: body, err: err}
+		}()
+	}
+
+	send()
+	inflight := 1
+
+	timer := time.NewTimer(c.hedgeDelay())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			c.logger.Debug().Str("url", url).Msg("sending hedged request")
+			send()
+			inflight++
+		case res := <-results:
+			inflight--
+			if res.err == nil || inflight == 0 {
+				return res.body, res.err
+			}
+		}
+	}
+}
+
+// hedgeDelay возвращает p95 задержек последних попыток, но не меньше hedgingDelay.
+func (c *HTTPClient) hedgeDelay() time.Duration {
+	p95, ok := c.latencies.percentile(0.95)
+	if !ok {
+		return c.hedgingDelay
+	}
+
+	return max(p95, c.hedgingDelay)
+}
+
 func (c *HTTPClient) get(ctx context.Context, url string) (body []byte, err error) {
 	var (
 		res *http.Response
 		req *http.Request
 	)
 
+	if c.attemptTimeout > 0 {
+		var cancelFunc context.CancelFunc
+		ctx, cancelFunc = context.WithTimeout(ctx, c.attemptTimeout)
+		defer cancelFunc()
+	}
+
+	start := time.Now()
+
 	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil); err != nil {
 		return nil, fmt.Errorf("failed to http.NewRequest: %w", err)
 	}
 
-	if res, err = http.DefaultClient.Do(req); err != nil {
-		return nil, fmt.Errorf("failed to http.DefaultClient.Do: %w", err)
+	if res, err = c.client.Do(req); err != nil {
+		return nil, fmt.Errorf("failed to client.Do: %w", err)
 	}
 
 	defer res.Body.Close()
@@ -85,5 +191,7 @@ func (c *HTTPClient) get(ctx context.Context, url string) (body []byte, err erro
 		return nil, fmt.Errorf("failed with statusCode: %d, %s", res.StatusCode, string(body))
 	}
 
+	c.latencies.observe(time.Since(start))
+
 	return body, nil
 }
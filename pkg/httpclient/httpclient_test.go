@@ -0,0 +1,101 @@
+package httpclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/internal/config"
+	"github.com/neyrzx/youmusic/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPClientGet(t *testing.T) {
+	t.Parallel()
+
+	// Первый запрос "зависает", все последующие отвечают сразу.
+	newServer := func() *httptest.Server {
+		var calls atomic.Int32
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				select {
+				case <-time.After(5 * time.Second):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			_, _ = w.Write([]byte("ok"))
+		}))
+	}
+
+	tests := []struct {
+		name        string
+		cfg         config.GatewayHTTPClient
+		ctxTimeout  time.Duration
+		expected    string
+		wantErr     bool
+		maxDuration time.Duration
+	}{
+		{
+			name: "case: hedged request wins",
+			cfg: config.GatewayHTTPClient{
+				RetryStrategyMaxDuration: time.Second,
+				HedgingEnabled:           true,
+				HedgingDelay:             20 * time.Millisecond,
+			},
+			ctxTimeout:  2 * time.Second,
+			expected:    "ok",
+			maxDuration: time.Second,
+		},
+		{
+			name: "case: attempt timeout then retry",
+			cfg: config.GatewayHTTPClient{
+				RetryStratagyDelay:       time.Millisecond,
+				RetryStrategyMaxDuration: time.Second,
+				AttemptTimeout:           50 * time.Millisecond,
+			},
+			ctxTimeout:  2 * time.Second,
+			expected:    "ok",
+			maxDuration: time.Second,
+		},
+		{
+			name: "case: context deadline stops retries",
+			cfg: config.GatewayHTTPClient{
+				RetryStratagyDelay:       time.Millisecond,
+				RetryStrategyMaxDuration: 10 * time.Second,
+			},
+			ctxTimeout:  50 * time.Millisecond,
+			wantErr:     true,
+			maxDuration: time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer()
+			defer server.Close()
+
+			ctx, cancelFunc := context.WithTimeout(context.Background(), test.ctxTimeout)
+			defer cancelFunc()
+
+			start := time.Now()
+			body, err := httpclient.NewHTTPClient(test.cfg).Get(ctx, server.URL)
+			assert.Less(t, time.Since(start), test.maxDuration)
+
+			if test.wantErr {
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+
+			assert.NoError(t, err)
+			actual, _ := io.ReadAll(body)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	latencyWindowSize = 128
+	// latencyMinSamples минимальное число замеров, после которого перцентиль считается осмысленным.
+	latencyMinSamples = 20
+)
+
+// latencyWindow хранит задержки последних успешных попыток в кольцевом буфере.
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+func newLatencyWindow() *latencyWindow {
+	return &latencyWindow{samples: make([]time.Duration, 0, latencyWindowSize)}
+}
+
+func (w *latencyWindow) observe(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.samples) < latencyWindowSize {
+		w.samples = append(w.samples, d)
+		return
+	}
+
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % latencyWindowSize
+}
+
+// percentile возвращает p-й перцентиль (0 < p <= 1) и false, если замеров недостаточно.
+func (w *latencyWindow) percentile(p float64) (time.Duration, bool) {
+	w.mu.Lock()
+	sorted := slices.Clone(w.samples)
+	w.mu.Unlock()
+
+	if len(sorted) < latencyMinSamples {
+		return 0, false
+	}
+
+	slices.Sort(sorted)
+
+	i := int(float64(len(sorted))*p+0.5) - 1
+	i = max(0, min(i, len(sorted)-1))
+
+	return sorted[i], true
+}
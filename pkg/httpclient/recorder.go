@@ -0,0 +1,265 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderMode режим работы Recorder.
+type RecorderMode int
+
+const (
+	// ModeReplay отдаёт ответы только из кассеты, сеть не используется.
+	ModeReplay RecorderMode = iota
+	// ModeRecord выполняет реальные запросы и дописывает их в кассету.
+	ModeRecord
+)
+
+const redacted = "REDACTED"
+
+var ErrInteractionNotFound = errors.New("interaction not found in cassette")
+
+// defaultSecretHeaders и defaultSecretParams маскируются при записи кассеты и при сопоставлении запросов.
+var (
+	defaultSecretHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+	defaultSecretParams  = []string{"api_key", "apikey", "key", "token", "access_token", "secret"}
+)
+
+// Interaction одна пара запрос-ответ в кассете.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Cassette набор записанных взаимодействий.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder http.RoundTripper, который записывает взаимодействия в файл кассеты или проигрывает их из него.
+//
+// Запросы сопоставляются по методу, пути и query-параметрам (порядок параметров не важен),
+// секретные заголовки и параметры маскируются.
+type Recorder struct {
+	path      string
+	mode      RecorderMode
+	transport http.RoundTripper
+
+	secretHeaders []string
+	secretParams  []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	// used сколько раз было проиграно каждое взаимодействие, повторяющиеся запросы проигрываются по порядку.
+	used map[string]int
+}
+
+// NewRecorder создаёт Recorder. В режиме ModeReplay кассета должна существовать,
+// в режиме ModeRecord запросы уходят в transport (http.DefaultTransport, если nil).
+func NewRecorder(path string, mode RecorderMode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path:          path,
+		mode:          mode,
+		transport:     transport,
+		secretHeaders: defaultSecretHeaders,
+		secretParams:  defaultSecretParams,
+		used:          make(map[string]int),
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to os.ReadFile(%s): %w", path, err)
+	}
+
+	if err = json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("failed to json.Unmarshal(%s): %w", path, err)
+	}
+
+	return r, nil
+}
+
+// RoundTrip реализует http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+
+	return r.replay(req)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	recorded := r.recordRequest(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []int
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request.matches(recorded) {
+			matched = append(matched, i)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w: %s %s?%s", ErrInteractionNotFound, recorded.Method, recorded.Path, recorded.Query)
+	}
+
+	// Повторяющиеся запросы получают ответы по порядку записи, последний ответ повторяется.
+	key := recorded.Method + " " + recorded.Path + "?" + recorded.Query
+	i := matched[min(r.used[key], len(matched)-1)]
+	r.used[key]++
+
+	return r.cassette.Interactions[i].Response.toResponse(req), nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to io.ReadAll(response body): %w", err)
+	}
+
+	interaction := Interaction{
+		Request: r.recordRequest(req),
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     r.redactHeader(res.Header),
+			Body:       string(body),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, nil
+}
+
+// Save записывает кассету на диск, имеет смысл только в режиме ModeRecord.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to json.MarshalIndent: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to os.MkdirAll(%s): %w", filepath.Dir(r.path), err)
+	}
+
+	if err = os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to os.WriteFile(%s): %w", r.path, err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) recordRequest(req *http.Request) RecordedRequest {
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  r.redactQuery(req.URL.Query()),
+		Header: r.redactHeader(req.Header),
+	}
+}
+
+// redactQuery маскирует секретные параметры и возвращает query в каноничном (отсортированном) виде.
+func (r *Recorder) redactQuery(query url.Values) string {
+	for name := range query {
+		if containsFold(r.secretParams, name) {
+			query[name] = []string{redacted}
+		}
+	}
+
+	return query.Encode()
+}
+
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	header = header.Clone()
+	for name := range header {
+		if containsFold(r.secretHeaders, name) {
+			header[name] = []string{redacted}
+		}
+	}
+
+	return header
+}
+
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+	return rr.Method == other.Method && rr.Path == other.Path && rr.Query == other.Query
+}
+
+func (rr RecordedResponse) toResponse(req *http.Request) *http.Response {
+	header := rr.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(rr.Body)),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
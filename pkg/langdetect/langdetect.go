@@ -0,0 +1,184 @@
+// Package langdetect определяет язык текста по n-граммам символов (наивный байесовский
+// классификатор). Профили языков строятся при старте из образцов текста в corpus, сеть не нужна.
+package langdetect
+
+import (
+	"embed"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MinLetters минимальное количество букв в тексте, по более короткому язык не определяется.
+	MinLetters = 20
+
+	// maxN наибольшая длина n-граммы.
+	maxN = 3
+	// maxGrams ограничивает количество n-грамм текста, длинные тексты определяются по началу.
+	maxGrams = 3000
+
+	// minCoverage минимальная доля триграмм текста, встречающихся в профиле лучшего языка. Текст
+	// на неподдерживаемом языке выигрывает у ближайшего по алфавиту профиля, но покрывается им хуже:
+	// на образцах поддерживаемые языки дают от 0.69, шведский, казахский, польский, турецкий - до 0.58.
+	minCoverage = 0.64
+	// temperature масштабирует средний на n-грамму логарифм правдоподобия перед softmax: разница
+	// 0.1 между двумя языками даёт уверенность около 0.7, 0.3 - около 0.95. Без нормировки на длину
+	// уверенность для любого длинного текста была бы близка к 1.
+	temperature = 10
+)
+
+//go:embed corpus/*.txt
+var corpus embed.FS
+
+// Result язык текста (код ISO 639-1) и уверенность от 0 до 1. Language пустой, если язык не определён.
+type Result struct {
+	Language   string
+	Confidence float64
+}
+
+type profile struct {
+	language string
+	counts   map[string]int
+	total    int
+}
+
+var (
+	profiles   []profile
+	vocabulary int
+)
+
+func init() {
+	files, err := corpus.ReadDir("corpus")
+	if err != nil {
+		panic(err)
+	}
+
+	seen := make(map[string]struct{})
+	for _, file := range files {
+		text, err := corpus.ReadFile(path.Join("corpus", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		p := profile{language: strings.TrimSuffix(file.Name(), ".txt"), counts: make(map[string]int)}
+		for _, gram := range ngrams(string(text), -1) {
+			p.counts[gram]++
+			p.total++
+			seen[gram] = struct{}{}
+		}
+		profiles = append(profiles, p)
+	}
+	vocabulary = len(seen)
+}
+
+// Languages коды ISO 639-1 поддерживаемых языков по алфавиту.
+func Languages() []string {
+	languages := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		languages = append(languages, p.language)
+	}
+	slices.Sort(languages)
+
+	return languages
+}
+
+// Supported поддерживается ли язык с кодом language.
+func Supported(language string) bool {
+	return slices.ContainsFunc(profiles, func(p profile) bool { return p.language == language })
+}
+
+// Detect определяет язык текста. Confidence - апостериорная вероятность языка при равных
+// априорных, посчитанная по среднему на n-грамму правдоподобию. Пустой Result возвращается для
+// текста короче MinLetters букв и для текста, триграммы которого плохо покрываются профилем
+// лучшего языка (minCoverage), - скорее всего, это неподдерживаемый язык.
+//
+// Близкие к поддерживаемым языки (например, нидерландский к немецкому) могут определяться как они.
+func Detect(text string) Result {
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	if letters < MinLetters {
+		return Result{}
+	}
+
+	grams := ngrams(text, maxGrams)
+
+	scores := make([]float64, len(profiles))
+	for i, p := range profiles {
+		denominator := math.Log(float64(p.total + vocabulary))
+		for _, gram := range grams {
+			scores[i] += math.Log(float64(p.counts[gram]+1)) - denominator
+		}
+		scores[i] /= float64(len(grams))
+	}
+
+	best := 0
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+
+	if coverage(grams, profiles[best]) < minCoverage {
+		return Result{}
+	}
+
+	// Вероятности через softmax, логарифмы сдвигаются на максимум, чтобы не было переполнения.
+	sum := 0.0
+	for i := range scores {
+		sum += math.Exp(temperature * (scores[i] - scores[best]))
+	}
+
+	return Result{Language: profiles[best].language, Confidence: 1 / sum}
+}
+
+// coverage доля триграмм из grams, встречающихся в профиле p.
+func coverage(grams []string, p profile) float64 {
+	var total, seen int
+	for _, gram := range grams {
+		if utf8.RuneCountInString(gram) != maxN {
+			continue
+		}
+		total++
+		if p.counts[gram] > 0 {
+			seen++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	return float64(seen) / float64(total)
+}
+
+// ngrams возвращает n-граммы длиной от 1 до maxN слов текста в нижнем регистре, слова дополняются
+// пробелами по краям. limit ограничивает количество n-грамм, -1 - без ограничения.
+func ngrams(text string, limit int) (grams []string) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for n := 1; n <= maxN; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				if n == 1 && runes[i] == ' ' {
+					continue
+				}
+				if limit >= 0 && len(grams) == limit {
+					return grams
+				}
+				grams = append(grams, string(runes[i:i+n]))
+			}
+		}
+	}
+
+	return grams
+}
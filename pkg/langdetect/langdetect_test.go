@@ -0,0 +1,57 @@
+package langdetect_test
+
+import (
+	"testing"
+
+	"github.com/neyrzx/youmusic/pkg/langdetect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"case: english", "Is this the real life? Is this just fantasy? Caught in a landslide, no escape from reality", "en"},
+		{"case: russian", "Группа крови на рукаве, мой порядковый номер на рукаве, пожелай мне удачи в бою", "ru"},
+		{"case: ukrainian", "Я так хочу до тебе, коли ти далеко, і кожен день без тебе як зима", "uk"},
+		{"case: german", "Du hast mich gefragt und ich hab nichts gesagt, willst du bis der Tod euch scheidet treu ihr sein", "de"},
+		{"case: french", "Non, je ne regrette rien, ni le bien qu'on m'a fait, ni le mal, tout ça m'est bien égal", "fr"},
+		{"case: spanish", "Despacito, quiero respirar tu cuello despacito, deja que te diga cosas al oído", "es"},
+		{"case: italian", "Nel blu dipinto di blu, felice di stare lassù, e volavo volavo felice più in alto del sole", "it"},
+		{"case: portuguese", "Olha que coisa mais linda, mais cheia de graça, é ela menina que vem e que passa", "pt"},
+		{"case: japanese is not supported", "夜に駆けるように君と二人で走り出した、あの日の約束を覚えているかな", ""},
+		{"case: kazakh is not supported", "Менің Қазақстаным, алтын күн аспаны, алтын дән даласы, ерліктің дастаны", ""},
+		{"case: swedish is not supported", "Det är en vacker dag och solen skiner över sjön där barnen badar", ""},
+		{"case: polish is not supported", "Jeszcze Polska nie zginęła, kiedy my żyjemy, co nam obca przemoc wzięła", ""},
+		{"case: turkish is not supported", "Bugün hava çok güzel ve güneş çocukların yüzdüğü gölün üzerinde parlıyor", ""},
+		{"case: too short", "la la la", ""},
+		{"case: no letters", "1234 5678 !!! ??? 1234 5678 !!! ??? 1234 5678", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := langdetect.Detect(tt.text)
+			assert.Equal(t, tt.expected, result.Language)
+			if tt.expected != "" {
+				assert.Greater(t, result.Confidence, 0.5)
+				assert.LessOrEqual(t, result.Confidence, 1.0)
+			} else {
+				assert.Zero(t, result.Confidence)
+			}
+		})
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"de", "en", "es", "fr", "it", "pt", "ru", "uk"}, langdetect.Languages())
+	assert.True(t, langdetect.Supported("ru"))
+	assert.False(t, langdetect.Supported("xx"))
+}
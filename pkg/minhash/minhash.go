@@ -0,0 +1,128 @@
+// Package minhash оценивает сходство текстов по коэффициенту Жаккара их шинглов
+// и ищет кандидатов через LSH (совпадение хотя бы одной полосы сигнатуры).
+package minhash
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Size количество хеш-функций в сигнатуре.
+	Size = 128
+	// Bands количество полос LSH. При 4 хешах в полосе тексты со сходством 0.5 становятся
+	// кандидатами с вероятностью ~0.87, со сходством 0.2 - ~0.05.
+	Bands = 32
+
+	rowsPerBand = Size / Bands
+)
+
+// seeds соли хеш-функций. Сигнатуры хранятся в базе, поэтому соли не должны меняться.
+var seeds = func() (seeds [Size]uint64) {
+	state := uint64(0x6c797269637331)
+	for i := range seeds {
+		state, seeds[i] = splitmix64(state)
+	}
+	return seeds
+}()
+
+// Signature MinHash сигнатура. Пустая сигнатура у текста без слов.
+type Signature []uint64
+
+// Shingles возвращает множество шинглов из k подряд идущих слов текста.
+// Слова приводятся к нижнему регистру, знаки препинания отбрасываются.
+// Текст короче k слов даёт один шингл из всех слов.
+func Shingles(text string, k int) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	shingles := make(map[string]struct{})
+	if len(words) == 0 {
+		return shingles
+	}
+	if len(words) < k {
+		shingles[strings.Join(words, " ")] = struct{}{}
+		return shingles
+	}
+	for i := 0; i+k <= len(words); i++ {
+		shingles[strings.Join(words[i:i+k], " ")] = struct{}{}
+	}
+
+	return shingles
+}
+
+// Sign считает сигнатуру множества шинглов.
+func Sign(shingles map[string]struct{}) Signature {
+	if len(shingles) == 0 {
+		return Signature{}
+	}
+
+	sig := make(Signature, Size)
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+
+	for shingle := range shingles {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(shingle))
+		base := h.Sum64()
+
+		for i, seed := range seeds {
+			if _, v := splitmix64(base ^ seed); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+
+	return sig
+}
+
+// Similarity оценка коэффициента Жаккара: доля совпавших позиций сигнатур.
+func Similarity(a, b Signature) float64 {
+	if len(a) != Size || len(b) != Size {
+		return 0
+	}
+
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / Size
+}
+
+// BandHashes хеши полос сигнатуры. Тексты с совпадающим хешем хотя бы одной полосы
+// (с тем же номером) считаются кандидатами в похожие.
+func BandHashes(sig Signature) []uint64 {
+	if len(sig) != Size {
+		return nil
+	}
+
+	hashes := make([]uint64, Bands)
+	buf := make([]byte, 8)
+	for band := range hashes {
+		h := fnv.New64a()
+		for _, v := range sig[band*rowsPerBand : (band+1)*rowsPerBand] {
+			binary.LittleEndian.PutUint64(buf, v)
+			_, _ = h.Write(buf)
+		}
+		hashes[band] = h.Sum64()
+	}
+
+	return hashes
+}
+
+// splitmix64 шаг генератора SplitMix64: следующее состояние и перемешанное значение.
+func splitmix64(state uint64) (next uint64, value uint64) {
+	next = state + 0x9e3779b97f4a7c15
+	z := next
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return next, z ^ (z >> 31)
+}
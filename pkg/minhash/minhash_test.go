@@ -0,0 +1,74 @@
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/neyrzx/youmusic/pkg/minhash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShingles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"case: empty", " ,. ", nil},
+		{"case: shorter than k", "Hello!", []string{"hello"}},
+		{"case: punctuation and case", "Группа крови, на рукаве. Группа крови", []string{"группа крови", "крови на", "на рукаве", "рукаве группа"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			shingles := minhash.Shingles(tt.text, 2)
+			assert.Len(t, shingles, len(tt.expected))
+			for _, s := range tt.expected {
+				assert.Contains(t, shingles, s)
+			}
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Parallel()
+
+	base := "we are the champions my friends and we will keep on fighting till the end"
+
+	tests := []struct {
+		name      string
+		a, b      string
+		min, max  float64
+		candidate bool
+	}{
+		{"case: identical", base, base, 1, 1, true},
+		{"case: one word changed", base, "we are the champions my friends and we will keep on singing till the end", 0.6, 0.95, true},
+		{"case: unrelated", base, "yesterday all my troubles seemed so far away now it looks as though they are here to stay", 0, 0.1, false},
+		{"case: empty", base, "", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := minhash.Sign(minhash.Shingles(tt.a, 2))
+			b := minhash.Sign(minhash.Shingles(tt.b, 2))
+
+			similarity := minhash.Similarity(a, b)
+			assert.GreaterOrEqual(t, similarity, tt.min)
+			assert.LessOrEqual(t, similarity, tt.max)
+
+			shared := false
+			bandsA, bandsB := minhash.BandHashes(a), minhash.BandHashes(b)
+			for i := range bandsA {
+				if i < len(bandsB) && bandsA[i] == bandsB[i] {
+					shared = true
+				}
+			}
+			assert.Equal(t, tt.candidate, shared)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package playlistfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func encodeM3U8(w io.Writer, playlist Playlist) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("#EXTM3U\n")
+	if playlist.Title != "" {
+		fmt.Fprintf(bw, "#PLAYLIST:%s\n", oneLine(playlist.Title))
+	}
+
+	for _, entry := range playlist.Entries {
+		seconds := int64(-1)
+		if entry.Duration > 0 {
+			seconds = int64((entry.Duration + time.Second/2) / time.Second)
+		}
+
+		display := entry.Title
+		if entry.Artist != "" {
+			display = entry.Artist + " - " + entry.Title
+		}
+
+		fmt.Fprintf(bw, "#EXTINF:%d,%s\n%s\n", seconds, oneLine(display), oneLine(entry.Location))
+	}
+
+	return bw.Flush()
+}
+
+func decodeM3U8(r io.Reader) (playlist Playlist, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var (
+		pending    Entry
+		hasPending bool
+		first      = true
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first {
+			line = strings.TrimPrefix(line, string(utf8BOM))
+			first = false
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			pending, hasPending = parseEXTINF(strings.TrimPrefix(line, "#EXTINF:")), true
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			playlist.Title = strings.TrimSpace(strings.TrimPrefix(line, "#PLAYLIST:"))
+		case strings.HasPrefix(line, "#"):
+			// Прочие директивы и комментарии не нужны.
+		default:
+			if len(playlist.Entries) >= MaxEntries {
+				return Playlist{}, ErrTooManyItems
+			}
+			if !hasPending {
+				pending = Entry{}
+			}
+			pending.Location = line
+			playlist.Entries = append(playlist.Entries, pending)
+			pending, hasPending = Entry{}, false
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return Playlist{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	return playlist, nil
+}
+
+// parseEXTINF разбирает "<секунды>[ атрибуты],<Исполнитель - Название>".
+func parseEXTINF(value string) (entry Entry) {
+	info, display, _ := strings.Cut(value, ",")
+
+	if fields := strings.Fields(info); len(fields) > 0 {
+		if seconds, err := strconv.ParseFloat(fields[0], 64); err == nil && seconds > 0 {
+			entry.Duration = time.Duration(seconds * float64(time.Second))
+		}
+	}
+
+	entry.Artist, entry.Title = splitDisplayName(display)
+
+	return entry
+}
+
+// oneLine убирает переводы строк, которые сломали бы построчный формат.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(strings.NewReplacer("\r", " ", "\n", " ").Replace(s)), " ")
+}
@@ -0,0 +1,146 @@
+// Package playlistfile читает и пишет плейлисты в форматах extended M3U (M3U8) и XSPF.
+package playlistfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatM3U8 Format = "m3u8"
+	FormatXSPF Format = "xspf"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown playlist format")
+	ErrInvalid       = errors.New("invalid playlist file")
+	ErrTooManyItems  = errors.New("too many playlist entries")
+)
+
+// MaxEntries максимальное число записей, которое читает Decode.
+const MaxEntries = 10000
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatXSPF:
+		return "application/xspf+xml; charset=utf-8"
+	default:
+		return "audio/x-mpegurl; charset=utf-8"
+	}
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatM3U8, "m3u":
+		return FormatM3U8, nil
+	case FormatXSPF:
+		return FormatXSPF, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+	}
+}
+
+// DetectFormat определяет формат по Content-Type, а если он не говорит ничего определённого - по началу файла.
+func DetectFormat(contentType string, head []byte) (Format, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "audio/x-mpegurl", "audio/mpegurl", "application/x-mpegurl", "application/vnd.apple.mpegurl":
+		return FormatM3U8, nil
+	case "application/xspf+xml":
+		return FormatXSPF, nil
+	}
+
+	head = bytes.TrimSpace(bytes.TrimPrefix(head, utf8BOM))
+	switch {
+	case bytes.HasPrefix(head, []byte("#EXTM3U")):
+		return FormatM3U8, nil
+	case bytes.HasPrefix(head, []byte("<")) && bytes.Contains(head, []byte("<playlist")):
+		return FormatXSPF, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// Entry запись плейлиста. Duration = 0 - длительность неизвестна.
+type Entry struct {
+	Location string
+	Artist   string
+	Title    string
+	Duration time.Duration
+}
+
+type Playlist struct {
+	Title   string
+	Entries []Entry
+}
+
+func Encode(w io.Writer, format Format, playlist Playlist) error {
+	switch format {
+	case FormatM3U8:
+		return encodeM3U8(w, playlist)
+	case FormatXSPF:
+		return encodeXSPF(w, playlist)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// Decode читает плейлист. Если у записи нет исполнителя и названия, они берутся из имени файла
+// вида "Исполнитель - Название.mp3".
+func Decode(r io.Reader, format Format) (playlist Playlist, err error) {
+	switch format {
+	case FormatM3U8:
+		playlist, err = decodeM3U8(r)
+	case FormatXSPF:
+		playlist, err = decodeXSPF(r)
+	default:
+		return Playlist{}, ErrUnknownFormat
+	}
+	if err != nil {
+		return Playlist{}, err
+	}
+
+	for i, entry := range playlist.Entries {
+		if entry.Artist == "" && entry.Title == "" {
+			playlist.Entries[i].Artist, playlist.Entries[i].Title = splitDisplayName(locationName(entry.Location))
+		}
+	}
+
+	return playlist, nil
+}
+
+// splitDisplayName делит "Исполнитель - Название", без разделителя всё считается названием.
+func splitDisplayName(name string) (artist, title string) {
+	if artist, title, ok := strings.Cut(name, " - "); ok {
+		return strings.TrimSpace(artist), strings.TrimSpace(title)
+	}
+
+	return "", strings.TrimSpace(name)
+}
+
+// locationName имя файла из пути или URL без расширения.
+func locationName(location string) string {
+	if u, err := url.Parse(location); err == nil && u.Path != "" {
+		location = u.Path
+	}
+	location = strings.ReplaceAll(location, `\`, "/")
+
+	name := path.Base(location)
+	if name == "." || name == "/" {
+		return ""
+	}
+
+	return strings.TrimSuffix(name, path.Ext(name))
+}
@@ -0,0 +1,107 @@
+package playlistfile_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/pkg/playlistfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	playlist := playlistfile.Playlist{
+		Title: "Road trip",
+		Entries: []playlistfile.Entry{
+			{Location: "https://example.com/api/v1/tracks/1/audio", Artist: "Muse", Title: "Uprising", Duration: 305 * time.Second},
+			{Location: "https://y.be/asd2d2cW", Artist: "Кино", Title: "Группа крови"},
+		},
+	}
+
+	for _, format := range []playlistfile.Format{playlistfile.FormatM3U8, playlistfile.FormatXSPF} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, playlistfile.Encode(&buf, format, playlist))
+
+			detected, err := playlistfile.DetectFormat("", buf.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, format, detected)
+
+			decoded, err := playlistfile.Decode(&buf, format)
+			require.NoError(t, err)
+			assert.Equal(t, playlist, decoded)
+		})
+	}
+}
+
+func TestDecodeM3U8(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []playlistfile.Entry
+	}{
+		{
+			"case: extinf with attributes",
+			"\xef\xbb\xbf#EXTM3U\r\n#EXTINF:123 tvg-id=\"x\",Muse - Uprising\r\nhttp://a/1.mp3\r\n",
+			[]playlistfile.Entry{{Location: "http://a/1.mp3", Artist: "Muse", Title: "Uprising", Duration: 123 * time.Second}},
+		},
+		{
+			"case: unknown duration and title only",
+			"#EXTM3U\n#EXTINF:-1,Uprising\n/music/1.mp3\n",
+			[]playlistfile.Entry{{Location: "/music/1.mp3", Title: "Uprising"}},
+		},
+		{
+			"case: plain m3u falls back to file name",
+			"# comment\nC:\\Music\\Muse - Uprising.mp3\nhttp://a/Radiohead%20-%20Creep.flac\n",
+			[]playlistfile.Entry{
+				{Location: "C:\\Music\\Muse - Uprising.mp3", Artist: "Muse", Title: "Uprising"},
+				{Location: "http://a/Radiohead%20-%20Creep.flac", Artist: "Radiohead", Title: "Creep"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			playlist, err := playlistfile.Decode(strings.NewReader(test.input), playlistfile.FormatM3U8)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, playlist.Entries)
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		head        string
+		expected    playlistfile.Format
+		expectedErr error
+	}{
+		{"case: m3u content type", "audio/x-mpegurl; charset=utf-8", "", playlistfile.FormatM3U8, nil},
+		{"case: xspf content type", "application/xspf+xml", "", playlistfile.FormatXSPF, nil},
+		{"case: xspf by content", "application/octet-stream", `<?xml version="1.0"?><playlist version="1">`, playlistfile.FormatXSPF, nil},
+		{"case: unknown", "text/plain", "hello", "", playlistfile.ErrUnknownFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			format, err := playlistfile.DetectFormat(test.contentType, []byte(test.head))
+			assert.ErrorIs(t, err, test.expectedErr)
+			assert.Equal(t, test.expected, format)
+		})
+	}
+}
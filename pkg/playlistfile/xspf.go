@@ -0,0 +1,93 @@
+package playlistfile
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const xspfNamespace = "http://xspf.org/ns/0/"
+
+type xspfPlaylist struct {
+	XMLName   xml.Name    `xml:"playlist"`
+	Version   string      `xml:"version,attr"`
+	Namespace string      `xml:"xmlns,attr,omitempty"`
+	Title     string      `xml:"title,omitempty"`
+	Tracks    []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location []string `xml:"location"`
+	Creator  string   `xml:"creator,omitempty"`
+	Title    string   `xml:"title,omitempty"`
+	// Duration длительность в миллисекундах.
+	Duration int64 `xml:"duration,omitempty"`
+}
+
+func encodeXSPF(w io.Writer, playlist Playlist) error {
+	doc := xspfPlaylist{
+		Version:   "1",
+		Namespace: xspfNamespace,
+		Title:     playlist.Title,
+		Tracks:    make([]xspfTrack, 0, len(playlist.Entries)),
+	}
+
+	for _, entry := range playlist.Entries {
+		track := xspfTrack{
+			Creator:  entry.Artist,
+			Title:    entry.Title,
+			Duration: entry.Duration.Milliseconds(),
+		}
+		if entry.Location != "" {
+			track.Location = []string{entry.Location}
+		}
+		doc.Tracks = append(doc.Tracks, track)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encoder.Encode: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func decodeXSPF(r io.Reader) (Playlist, error) {
+	var doc xspfPlaylist
+
+	decoder := xml.NewDecoder(r)
+	// Только UTF-8: для других кодировок нужен CharsetReader, а плееры пишут XSPF в UTF-8.
+	if err := decoder.Decode(&doc); err != nil {
+		return Playlist{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	if len(doc.Tracks) > MaxEntries {
+		return Playlist{}, ErrTooManyItems
+	}
+
+	playlist := Playlist{
+		Title:   strings.TrimSpace(doc.Title),
+		Entries: make([]Entry, 0, len(doc.Tracks)),
+	}
+	for _, track := range doc.Tracks {
+		entry := Entry{
+			Artist:   strings.TrimSpace(track.Creator),
+			Title:    strings.TrimSpace(track.Title),
+			Duration: time.Duration(track.Duration) * time.Millisecond,
+		}
+		if len(track.Location) > 0 {
+			entry.Location = strings.TrimSpace(track.Location[0])
+		}
+		playlist.Entries = append(playlist.Entries, entry)
+	}
+
+	return playlist, nil
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepEvery через сколько вызовов Incr из памяти удаляются истёкшие счётчики.
+const memorySweepEvery = 1024
+
+// MemoryStore хранит счётчики в памяти процесса. Подходит для одного экземпляра сервиса,
+// при нескольких экземплярах каждый считает свои запросы.
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]memoryCounter
+	calls    int
+}
+
+type memoryCounter struct {
+	windowStart time.Time
+	expiresAt   time.Time
+	count       int64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]memoryCounter)}
+}
+
+func (s *MemoryStore) Incr(_ context.Context, key string, n int64, windowStart time.Time, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%memorySweepEvery == 0 {
+		for k, counter := range s.counters {
+			if counter.expiresAt.Before(windowStart) {
+				delete(s.counters, k)
+			}
+		}
+	}
+
+	counter := s.counters[key]
+	if !counter.windowStart.Equal(windowStart) {
+		counter = memoryCounter{windowStart: windowStart, expiresAt: windowStart.Add(window)}
+	}
+	counter.count += n
+	s.counters[key] = counter
+
+	return counter.count, nil
+}
@@ -0,0 +1,75 @@
+// Package ratelimit считает запросы в фиксированных окнах времени.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Store счётчики запросов по ключу.
+type Store interface {
+	// Incr увеличивает на n счётчик ключа в окне, начинающемся в windowStart, и возвращает новое значение.
+	// Счётчик прошлого окна сбрасывается. Окно длится window, после этого счётчик можно удалить.
+	Incr(ctx context.Context, key string, n int64, windowStart time.Time, window time.Duration) (count int64, err error)
+}
+
+// Result состояние лимита после запроса.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// RetryAfter через сколько откроется следующее окно.
+func (r Result) RetryAfter(now time.Time) time.Duration {
+	if d := r.ResetAt.Sub(now); d > 0 {
+		return d
+	}
+
+	return 0
+}
+
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+// WithClock подменяет источник текущего времени (для тестов).
+func (l *Limiter) WithClock(now func() time.Time) *Limiter {
+	l.now = now
+	return l
+}
+
+// Allow учитывает запрос и сообщает, укладывается ли он в limit запросов за window.
+// Отклонённые запросы тоже учитываются, поэтому клиент, не соблюдающий Retry-After, не получит новых попыток раньше.
+func (l *Limiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	return l.AllowN(ctx, key, 1, limit, window)
+}
+
+// AllowN как Allow, но запрос расходует n единиц лимита, например по одной на элемент пакета.
+func (l *Limiter) AllowN(ctx context.Context, key string, n, limit int, window time.Duration) (Result, error) {
+	now := l.now()
+	windowStart := now.Truncate(window)
+
+	count, err := l.store.Incr(ctx, key, int64(n), windowStart, window)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to store.Incr: %w", err)
+	}
+
+	result := Result{
+		Allowed: count <= int64(limit),
+		Limit:   limit,
+		ResetAt: windowStart.Add(window),
+	}
+	if result.Allowed {
+		result.Remaining = limit - int(count)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neyrzx/youmusic/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		offsets   []time.Duration
+		limit     int
+		allowed   []bool
+		remaining []int
+	}{
+		{
+			"case: within limit",
+			[]time.Duration{0, time.Second, 2 * time.Second},
+			3,
+			[]bool{true, true, true},
+			[]int{2, 1, 0},
+		},
+		{
+			"case: over limit",
+			[]time.Duration{0, time.Second, 2 * time.Second},
+			2,
+			[]bool{true, true, false},
+			[]int{1, 0, 0},
+		},
+		{
+			"case: new window resets counter",
+			[]time.Duration{0, time.Second, time.Minute, time.Minute + time.Second},
+			1,
+			[]bool{true, false, true, false},
+			[]int{0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var now time.Time
+			limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore()).WithClock(func() time.Time { return now })
+
+			for i, offset := range tt.offsets {
+				now = start.Add(offset)
+
+				result, err := limiter.Allow(context.Background(), "key", tt.limit, time.Minute)
+				require.NoError(t, err)
+				assert.Equal(t, tt.allowed[i], result.Allowed, "request %d", i)
+				assert.Equal(t, tt.remaining[i], result.Remaining, "request %d", i)
+				assert.Equal(t, now.Truncate(time.Minute).Add(time.Minute), result.ResetAt, "request %d", i)
+			}
+		})
+	}
+}
+
+func TestLimiterKeysAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+
+	first, err := limiter.Allow(context.Background(), "a", 1, time.Hour)
+	require.NoError(t, err)
+	second, err := limiter.Allow(context.Background(), "b", 1, time.Hour)
+	require.NoError(t, err)
+
+	assert.True(t, first.Allowed)
+	assert.True(t, second.Allowed)
+}
+
+func TestLimiterAllowN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		costs     []int
+		limit     int
+		allowed   []bool
+		remaining []int
+	}{
+		{"case: costs within limit", []int{2, 1}, 3, []bool{true, true}, []int{1, 0}},
+		{"case: cost above remaining", []int{2, 2, 1}, 3, []bool{true, false, false}, []int{1, 0, 0}},
+		{"case: cost above limit", []int{4}, 3, []bool{false}, []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+
+			for i, cost := range tt.costs {
+				result, err := limiter.AllowN(context.Background(), "key", cost, tt.limit, time.Minute)
+				require.NoError(t, err)
+				assert.Equal(t, tt.allowed[i], result.Allowed, "request %d", i)
+				assert.Equal(t, tt.remaining[i], result.Remaining, "request %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Package thumbnail уменьшает изображения и кодирует их в JPEG/PNG средствами стандартной библиотеки.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+type Format string
+
+const (
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+)
+
+// maxPixels ограничение разрешения декодируемого изображения, защищает от "бомб" на несколько гигабайт в памяти.
+const maxPixels = 40_000_000
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrImageTooLarge     = errors.New("image resolution is too large")
+)
+
+func (f Format) ContentType() string {
+	if f == FormatPNG {
+		return "image/png"
+	}
+
+	return "image/jpeg"
+}
+
+// FormatFromContentType возвращает формат по MIME типу.
+func FormatFromContentType(contentType string) (Format, error) {
+	switch contentType {
+	case "image/jpeg", "image/jpg":
+		return FormatJPEG, nil
+	case "image/png":
+		return FormatPNG, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, contentType)
+	}
+}
+
+// Decode декодирует JPEG или PNG, предварительно проверив разрешение по заголовку.
+func Decode(data []byte) (img image.Image, format Format, err error) {
+	cfg, name, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrUnsupportedFormat, err)
+	}
+
+	switch name {
+	case "jpeg":
+		format = FormatJPEG
+	case "png":
+		format = FormatPNG
+	default:
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, "", ErrImageTooLarge
+	}
+
+	if img, _, err = image.Decode(bytes.NewReader(data)); err != nil {
+		return nil, "", fmt.Errorf("failed to image.Decode: %w", err)
+	}
+
+	return img, format, nil
+}
+
+// Fit уменьшает изображение так, чтобы оно вписалось в квадрат size×size с сохранением пропорций.
+//
+// Каждый пиксель результата - среднее по соответствующему прямоугольнику исходника (box filter),
+// этого достаточно для уменьшения и не даёт муара. Изображения меньше size не увеличиваются.
+func Fit(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+
+	if size <= 0 || (sw <= size && sh <= size) {
+		return src
+	}
+
+	dw, dh := size, size
+	if sw > sh {
+		dh = max(1, sh*size/sw)
+	} else {
+		dw = max(1, sw*size/sh)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := dy*sh/dh, max((dy+1)*sh/dh, dy*sh/dh+1)
+
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := dx*sw/dw, max((dx+1)*sw/dw, dx*sw/dw+1)
+
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				row := rgba.Pix[y*rgba.Stride+x0*4 : y*rgba.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += uint64(row[i])
+					g += uint64(row[i+1])
+					b += uint64(row[i+2])
+					a += uint64(row[i+3])
+					n++
+				}
+			}
+
+			offset := dy*dst.Stride + dx*4
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// Encode кодирует изображение, для JPEG прозрачные области заливаются белым.
+func Encode(w io.Writer, img image.Image, format Format, jpegQuality int) error {
+	switch format {
+	case FormatPNG:
+		return png.Encode(w, img)
+	case FormatJPEG:
+		bounds := img.Bounds()
+		opaque := image.NewRGBA(bounds)
+		draw.Draw(opaque, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(opaque, bounds, img, bounds.Min, draw.Over)
+
+		return jpeg.Encode(w, opaque, &jpeg.Options{Quality: jpegQuality})
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
@@ -0,0 +1,72 @@
+package thumbnail_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/neyrzx/youmusic/pkg/thumbnail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solid(w, h int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestFit(t *testing.T) {
+	t.Parallel()
+
+	red := color.RGBA{R: 255, A: 255}
+
+	tests := []struct {
+		name           string
+		src            image.Image
+		size           int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{"case: landscape", solid(400, 200, red), 100, 100, 50},
+		{"case: portrait", solid(300, 600, red), 128, 64, 128},
+		{"case: square", solid(500, 500, red), 64, 64, 64},
+		{"case: smaller than size is not upscaled", solid(40, 30, red), 64, 40, 30},
+		{"case: extreme aspect ratio keeps at least one pixel", solid(1000, 2, red), 100, 100, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := thumbnail.Fit(test.src, test.size)
+
+			assert.Equal(t, test.expectedWidth, actual.Bounds().Dx())
+			assert.Equal(t, test.expectedHeight, actual.Bounds().Dy())
+			assert.Equal(t, color.RGBAModel.Convert(red), color.RGBAModel.Convert(actual.At(0, 0)))
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []thumbnail.Format{thumbnail.FormatJPEG, thumbnail.FormatPNG} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, thumbnail.Encode(&buf, solid(32, 16, color.Transparent), format, 80))
+
+			img, decodedFormat, err := thumbnail.Decode(buf.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, format, decodedFormat)
+			assert.Equal(t, image.Rect(0, 0, 32, 16), img.Bounds())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package translit переводит кириллицу в латиницу по ГОСТ 7.79-2000 (система Б, ISO 9 в ASCII)
+// и строит ключи поиска, совпадающие для написаний одного имени кириллицей и латиницей.
+package translit
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// gost таблица ГОСТ 7.79-2000 системы Б для строчных букв русского, украинского и белорусского алфавитов.
+// Ц передаётся как "c" перед е, и, ы, й (и их латинскими заменами) и как "cz" в остальных случаях.
+var gost = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "x", 'ц': "cz",
+	'ч': "ch", 'ш': "sh", 'щ': "shh", 'ъ': "``", 'ы': "y'", 'ь': "`", 'э': "e`", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g`", 'ў': "u`",
+}
+
+// CyrillicPattern класс букв таблицы gost для регулярных выражений Go и Postgres.
+const CyrillicPattern = "[а-яёіїєґўА-ЯЁІЇЄҐЎ]"
+
+// HasCyrillic есть ли в s буквы, которые транслитерирует Latin.
+func HasCyrillic(s string) bool {
+	return strings.ContainsFunc(norm.NFC.String(s), func(r rune) bool {
+		_, ok := gost[unicode.ToLower(r)]
+		return ok
+	})
+}
+
+// Latin транслитерирует кириллицу в s по ГОСТ 7.79-2000 (система Б), остальные символы
+// не меняются. У заглавных букв заглавной становится первая латинская буква.
+func Latin(s string) string {
+	runes := []rune(norm.NFC.String(s))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i, r := range runes {
+		lower := unicode.ToLower(r)
+		latin, ok := gost[lower]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if lower == 'ц' && i+1 < len(runes) && strings.ContainsRune("еиыйeiyj", unicode.ToLower(runes[i+1])) {
+			latin = "c"
+		}
+		if lower != r {
+			latin = strings.ToUpper(latin[:1]) + latin[1:]
+		}
+		b.WriteString(latin)
+	}
+
+	return b.String()
+}
+
+// searchFolds сводит распространённые варианты латинского написания к одному:
+// Tsoi/Czoj, Mumiy/Mumij, Shchors/Shhors, Khleb/Xleb.
+var searchFolds = strings.NewReplacer(
+	"shch", "shh",
+	"kh", "x",
+	"ts", "c",
+	"cz", "c",
+	"j", "i",
+	"y", "i",
+)
+
+// SearchKey ключ поиска имени исполнителя или названия трека: NFKC, нижний регистр, ё как е,
+// транслитерация по ГОСТ 7.79-2000, знаки ГОСТ (` и ') отбрасываются, варианты написания
+// сводятся через searchFolds, прочие знаки заменяются пробелом, пробелы схлопываются.
+//
+// Ключ предназначен только для сравнения: "Кино" и "Kino", "Виктор Цой" и "Viktor Tsoi" дают одинаковый ключ.
+// searchFolds сводят латинские написания кириллического имени, поэтому совпадение ключей значимо, только
+// если хотя бы одно из имён записано кириллицей (HasCyrillic): у латинских Jay и Iai ключ тоже общий.
+func SearchKey(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+	s = strings.ReplaceAll(s, "ё", "е")
+	s = strings.Map(func(r rune) rune {
+		if r == '`' || r == '\'' || r == '’' {
+			return -1
+		}
+		return r
+	}, Latin(s))
+	s = searchFolds.Replace(s)
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ReplaceAll(word, "ii", "i")
+	}
+
+	return strings.Join(words, " ")
+}
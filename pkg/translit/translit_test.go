@@ -0,0 +1,102 @@
+package translit_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/neyrzx/youmusic/pkg/translit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"case: latin is kept", "Muse", "Muse"},
+		{"case: simple", "Кино", "Kino"},
+		{"case: ts before i", "Цикада", "Cikada"},
+		{"case: ts before o", "Виктор Цой", "Viktor Czoj"},
+		{"case: multi-letter", "Шахматы Щорса", "Shaxmaty' Shhorsa"},
+		{"case: soft and hard signs", "Мумий Тролль, Любэ, Подъезд", "Mumij Troll`, Lyube`, Pod``ezd"},
+		{"case: yo", "Алла Пугачёва", "Alla Pugachyova"},
+		{"case: ukrainian", "Океан Ельзи, Їжак, Ґорґани", "Okean El`zi, Yizhak, G`org`ani"},
+		{"case: digits and punctuation", "Би-2", "Bi-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, translit.Latin(tt.value))
+		})
+	}
+}
+
+func TestSearchKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cyrillic string
+		latin    string
+		expected string
+	}{
+		{"case: Кино", "Кино", "Kino", "kino"},
+		{"case: ДДТ", "ДДТ", "DDT", "ddt"},
+		{"case: Земфира", "Земфира", "Zemfira", "zemfira"},
+		{"case: Виктор Цой", "Виктор Цой", "Viktor Tsoi", "viktor coi"},
+		{"case: Мумий Тролль", "Мумий Тролль", "Mumiy Troll", "mumi troll"},
+		{"case: Алла Пугачёва", "Алла Пугачёва", "Alla Pugacheva", "alla pugacheva"},
+		{"case: Ария", "Ария", "Aria", "aria"},
+		{"case: Чайф", "Чайф", "Chaif", "chaif"},
+		{"case: Любэ", "Любэ", "Lyube", "liube"},
+		{"case: Би-2", "Би-2", "Bi-2", "bi 2"},
+		{"case: Хлеб", "Хлеб", "Khleb", "xleb"},
+		{"case: Гражданская оборона", "Гражданская оборона", "Grazhdanskaya Oborona", "grazhdanskaia oborona"},
+		{"case: Наутилус Помпилиус", "Наутилус  Помпилиус", "Nautilus Pompilius", "nautilus pompilius"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, translit.SearchKey(tt.cyrillic))
+			assert.Equal(t, tt.expected, translit.SearchKey(tt.latin))
+		})
+	}
+}
+
+func TestHasCyrillic(t *testing.T) {
+	t.Parallel()
+
+	pattern := regexp.MustCompile(translit.CyrillicPattern)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{"case: cyrillic", "Кино", true},
+		{"case: ukrainian", "Їжак", true},
+		{"case: mixed", "Би-2 feat. Jay", true},
+		{"case: latin Jay", "Jay", false},
+		{"case: latin Iai", "Iai", false},
+		{"case: latin Mayer", "Mayer", false},
+		{"case: latin Maier", "Maier", false},
+		{"case: latin diacritics", "Motörhead", false},
+		{"case: empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, translit.HasCyrillic(tt.value))
+			assert.Equal(t, tt.expected, pattern.MatchString(tt.value))
+		})
+	}
+}